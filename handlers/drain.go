@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/reza-gholizade/k8s-mcp-server/pkg/auth"
+)
+
+// Drainer tracks the tool calls in flight and, once draining, rejects new ones, so
+// that the server can be taken out of rotation and stopped without cutting off the
+// calls it is still running, e.g. during a rolling update of its Deployment.
+type Drainer struct {
+	mu       sync.Mutex
+	draining bool
+	inFlight int
+	// idle is closed once draining has started and no call is in flight
+	idle chan struct{}
+}
+
+// NewDrainer creates a Drainer that accepts calls until Drain is called.
+func NewDrainer() *Drainer {
+	return &Drainer{idle: make(chan struct{})}
+}
+
+// Drain stops the server from accepting new tool calls. Calls already in flight
+// are left to finish. Calling it again has no effect.
+func (d *Drainer) Drain() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.draining {
+		return
+	}
+	d.draining = true
+	if d.inFlight == 0 {
+		close(d.idle)
+	}
+}
+
+// Draining reports whether Drain has been called.
+func (d *Drainer) Draining() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.draining
+}
+
+// Wait blocks until the server is draining and every call in flight has finished,
+// or until ctx is done, in which case it returns the number of calls still running
+// with ctx's error.
+func (d *Drainer) Wait(ctx context.Context) (int, error) {
+	select {
+	case <-d.idle:
+		return 0, nil
+	case <-ctx.Done():
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		return d.inFlight, ctx.Err()
+	}
+}
+
+// ToolMiddleware rejects tool calls while draining and counts the calls in flight.
+func (d *Drainer) ToolMiddleware() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			d.mu.Lock()
+			if d.draining {
+				d.mu.Unlock()
+				return nil, fmt.Errorf("server is draining and no longer accepts tool calls; retry against another replica")
+			}
+			d.inFlight++
+			d.mu.Unlock()
+
+			defer func() {
+				d.mu.Lock()
+				defer d.mu.Unlock()
+				d.inFlight--
+				if d.draining && d.inFlight == 0 {
+					close(d.idle)
+				}
+			}()
+			return next(ctx, request)
+		}
+	}
+}
+
+// ReadyzHandler serves the readiness probe: 200 while the server accepts tool calls
+// and 503 once it is draining, so that Kubernetes stops routing new sessions to it.
+func (d *Drainer) ReadyzHandler(w http.ResponseWriter, r *http.Request) {
+	if d.Draining() {
+		http.Error(w, "draining", http.StatusServiceUnavailable)
+		return
+	}
+	fmt.Fprintln(w, "ok")
+}
+
+// DrainHandler returns a handler that starts draining on a POST request, e.g. from
+// a preStop hook. It only accepts requests from the loopback interface and, if tokens
+// is set, only with a bearer token granting the write scope, since other containers
+// of the pod and port-forwarded connections also arrive on the loopback interface.
+func (d *Drainer) DrainHandler(tokens auth.TokenStore) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if ip := net.ParseIP(host); err != nil || ip == nil || !ip.IsLoopback() {
+			http.Error(w, "drain is only accepted from localhost", http.StatusForbidden)
+			return
+		}
+		if tokens != nil {
+			scope, ok := auth.ScopeFromContext(tokens.ContextFunc(r.Context(), r))
+			if !ok {
+				http.Error(w, "unauthorized: a valid bearer token is required", http.StatusUnauthorized)
+				return
+			}
+			if scope != auth.ScopeWrite {
+				http.Error(w, fmt.Sprintf("forbidden: drain requires the %q scope", auth.ScopeWrite), http.StatusForbidden)
+				return
+			}
+		}
+		d.Drain()
+		fmt.Fprintln(w, "draining")
+	}
+}
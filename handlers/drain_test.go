@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/reza-gholizade/k8s-mcp-server/pkg/auth"
+)
+
+func TestDrainerWaitsForCallsInFlight(t *testing.T) {
+	drainer := NewDrainer()
+	started, release := make(chan struct{}), make(chan struct{})
+	handler := drainer.ToolMiddleware()(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		close(started)
+		<-release
+		return mcp.NewToolResultText("done"), nil
+	})
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := handler(context.Background(), callRequest(nil))
+		done <- err
+	}()
+	<-started
+	drainer.Drain()
+
+	if _, err := handler(context.Background(), callRequest(nil)); err == nil {
+		t.Error("expected new calls to be rejected while draining")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if running, err := drainer.Wait(ctx); err == nil || running != 1 {
+		t.Errorf("Wait: got %d, %v; want 1 call still running", running, err)
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Errorf("call in flight failed: %v", err)
+	}
+	if running, err := drainer.Wait(context.Background()); err != nil || running != 0 {
+		t.Errorf("Wait: got %d, %v; want no call running", running, err)
+	}
+}
+
+func TestDrainHandler(t *testing.T) {
+	tokens := auth.TokenStore{"reader": auth.ScopeRead, "admin": auth.ScopeWrite}
+	tests := []struct {
+		name       string
+		tokens     auth.TokenStore
+		method     string
+		remoteAddr string
+		token      string
+		wantStatus int
+	}{
+		{name: "loopback without auth", method: http.MethodPost, remoteAddr: "127.0.0.1:40000", wantStatus: http.StatusOK},
+		{name: "get", method: http.MethodGet, remoteAddr: "127.0.0.1:40000", wantStatus: http.StatusMethodNotAllowed},
+		{name: "remote", method: http.MethodPost, remoteAddr: "10.0.0.7:40000", token: "admin", tokens: tokens, wantStatus: http.StatusForbidden},
+		{name: "missing token", method: http.MethodPost, remoteAddr: "127.0.0.1:40000", tokens: tokens, wantStatus: http.StatusUnauthorized},
+		{name: "read token", method: http.MethodPost, remoteAddr: "127.0.0.1:40000", token: "reader", tokens: tokens, wantStatus: http.StatusForbidden},
+		{name: "write token", method: http.MethodPost, remoteAddr: "[::1]:40000", token: "admin", tokens: tokens, wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			drainer := NewDrainer()
+			request := httptest.NewRequest(tt.method, "/drain", nil)
+			request.RemoteAddr = tt.remoteAddr
+			if tt.token != "" {
+				request.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			recorder := httptest.NewRecorder()
+			drainer.DrainHandler(tt.tokens)(recorder, request)
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("status: got %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			if drainer.Draining() != (tt.wantStatus == http.StatusOK) {
+				t.Errorf("draining: got %v", drainer.Draining())
+			}
+
+			readyz := httptest.NewRecorder()
+			drainer.ReadyzHandler(readyz, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+			wantReadyz := http.StatusOK
+			if drainer.Draining() {
+				wantReadyz = http.StatusServiceUnavailable
+			}
+			if readyz.Code != wantReadyz {
+				t.Errorf("readyz: got %d, want %d", readyz.Code, wantReadyz)
+			}
+		})
+	}
+}
@@ -2,14 +2,53 @@ package handlers
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/reza-gholizade/k8s-mcp-server/pkg/helm"
 )
 
+// withHelmProgress returns a context that streams the Helm action log of the
+// operation as progress notifications, one per line, if the client asked for progress.
+// Long installs and upgrades otherwise give no sign of life until they finish.
+func withHelmProgress(ctx context.Context, request mcp.CallToolRequest) context.Context {
+	progress := newProgressReporter(ctx, request)
+	if !progress.enabled() {
+		return ctx
+	}
+	var mu sync.Mutex
+	lines := 0
+	return helm.WithLogSink(ctx, func(line string) {
+		mu.Lock()
+		defer mu.Unlock()
+		lines++
+		progress.report(float64(lines), 0, line)
+	})
+}
+
+// getChartArgs reads the chart to install or upgrade to: a chart name, or a packaged
+// chart archive passed base64-encoded in chartData, which takes precedence.
+func getChartArgs(args map[string]interface{}) (string, []byte, error) {
+	chartName := getStringArg(args, "chartName", "")
+	encoded := getStringArg(args, "chartData", "")
+	if encoded == "" {
+		if chartName == "" {
+			return "", nil, fmt.Errorf("either chartName or chartData is required")
+		}
+		return chartName, nil, nil
+	}
+	chartData, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", nil, fmt.Errorf("chartData must be a base64-encoded chart archive: %w", err)
+	}
+	return chartName, chartData, nil
+}
+
 // HelmInstall returns a handler function for the helmInstall tool
 
 func HelmInstall(client *helm.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -24,7 +63,7 @@ func HelmInstall(client *helm.Client) func(ctx context.Context, request mcp.Call
 			return nil, err
 		}
 
-		chartName, err := getRequiredStringArg(args, "chartName")
+		chartName, chartData, err := getChartArgs(args)
 		if err != nil {
 			return nil, err
 		}
@@ -39,7 +78,7 @@ func HelmInstall(client *helm.Client) func(ctx context.Context, request mcp.Call
 			}
 		}
 
-		release, err := client.InstallChart(ctx, namespace, releaseName, chartName, repoURL, values)
+		release, err := client.InstallChart(withHelmProgress(ctx, request), namespace, releaseName, chartName, repoURL, chartData, values)
 		if err != nil {
 			return nil, fmt.Errorf("failed to install chart: %w", err)
 		}
@@ -66,7 +105,7 @@ func HelmUpgrade(client *helm.Client) func(ctx context.Context, request mcp.Call
 			return nil, err
 		}
 
-		chartName, err := getRequiredStringArg(args, "chartName")
+		chartName, chartData, err := getChartArgs(args)
 		if err != nil {
 			return nil, err
 		}
@@ -80,7 +119,7 @@ func HelmUpgrade(client *helm.Client) func(ctx context.Context, request mcp.Call
 			}
 		}
 
-		release, err := client.UpgradeChart(ctx, namespace, releaseName, chartName, values)
+		release, err := client.UpgradeChart(withHelmProgress(ctx, request), namespace, releaseName, chartName, chartData, values)
 		if err != nil {
 			return nil, fmt.Errorf("failed to upgrade chart: %w", err)
 		}
@@ -109,7 +148,7 @@ func HelmUninstall(client *helm.Client) func(ctx context.Context, request mcp.Ca
 
 		namespace := getStringArg(args, "namespace", "default")
 
-		err = client.UninstallChart(ctx, namespace, releaseName)
+		err = client.UninstallChart(withHelmProgress(ctx, request), namespace, releaseName)
 		if err != nil {
 			return nil, fmt.Errorf("failed to uninstall chart: %w", err)
 		}
@@ -137,8 +176,9 @@ func HelmList(client *helm.Client) func(ctx context.Context, request mcp.CallToo
 		}
 
 		namespace := getStringArg(args, "namespace", "")
+		selector := getStringArg(args, "selector", "")
 
-		releases, err := client.ListReleases(ctx, namespace)
+		releases, err := client.ListReleases(ctx, namespace, selector)
 		if err != nil {
 			return nil, fmt.Errorf("failed to list releases: %w", err)
 		}
@@ -232,7 +272,7 @@ func HelmRollback(client *helm.Client) func(ctx context.Context, request mcp.Cal
 			}
 		}
 
-		err = client.RollbackRelease(ctx, namespace, releaseName, revision)
+		err = client.RollbackRelease(withHelmProgress(ctx, request), namespace, releaseName, revision)
 		if err != nil {
 			return nil, fmt.Errorf("failed to rollback release: %w", err)
 		}
@@ -304,3 +344,304 @@ func HelmRepoList(client *helm.Client) func(ctx context.Context, request mcp.Cal
 		return mcp.NewToolResultText(string(jsonResponse)), nil
 	}
 }
+
+// HelmReleaseStatus returns a handler function for the helmReleaseStatus tool
+func HelmReleaseStatus(client *helm.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		releaseName, err := getRequiredStringArg(args, "releaseName")
+		if err != nil {
+			return nil, err
+		}
+
+		namespace := getStringArg(args, "namespace", "default")
+
+		status, err := client.GetReleaseResources(ctx, namespace, releaseName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get release resources: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(status)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// HelmRecover returns a handler function for the helmRecover tool
+func HelmRecover(client *helm.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		releaseName, err := getRequiredStringArg(args, "releaseName")
+		if err != nil {
+			return nil, err
+		}
+
+		namespace := getStringArg(args, "namespace", "default")
+		strategy := getStringArg(args, "strategy", helm.RecoverAuto)
+
+		result, err := client.RecoverRelease(withHelmProgress(ctx, request), namespace, releaseName, strategy)
+		if err != nil {
+			return nil, fmt.Errorf("failed to recover release: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// HelmValidateValues returns a handler function for the helmValidateValues tool
+func HelmValidateValues(client *helm.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		chartName, err := getRequiredStringArg(args, "chartName")
+		if err != nil {
+			return nil, err
+		}
+
+		repoURL := getStringArg(args, "repoURL", "")
+		version := getStringArg(args, "version", "")
+
+		values := make(map[string]interface{})
+		if v, exists := args["values"]; exists {
+			if valuesMap, ok := v.(map[string]interface{}); ok {
+				values = valuesMap
+			}
+		}
+
+		result, err := client.ValidateValues(ctx, chartName, repoURL, version, values)
+		if err != nil {
+			return nil, fmt.Errorf("failed to validate values: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// HelmListFailedReleases returns a handler function for the listFailedReleases tool
+func HelmListFailedReleases(client *helm.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		namespace := getStringArg(args, "namespace", "")
+
+		releases, err := client.ListFailedReleases(ctx, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list failed releases: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(releases)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// HelmGetOverrides returns a handler function for the helmGetOverrides tool
+func HelmGetOverrides(client *helm.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		releaseName, err := getRequiredStringArg(args, "releaseName")
+		if err != nil {
+			return nil, err
+		}
+
+		namespace := getStringArg(args, "namespace", "default")
+
+		overrides, err := client.GetValueOverrides(ctx, namespace, releaseName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get value overrides: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(overrides)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// HelmGetValues returns a handler function for the helmGetValues tool
+func HelmGetValues(client *helm.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		releaseName, err := getRequiredStringArg(args, "releaseName")
+		if err != nil {
+			return nil, err
+		}
+
+		namespace := getStringArg(args, "namespace", "default")
+		revision := getIntArg(args, "revision", 0)
+		view := getStringArg(args, "view", helm.ValuesViewUser)
+
+		values, err := client.GetValues(ctx, namespace, releaseName, revision, view)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get release values: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(values)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// HelmDetectDrift returns a handler function for the detectDrift tool
+func HelmDetectDrift(client *helm.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		namespace := getStringArg(args, "namespace", "")
+
+		drift, err := client.DetectReleaseDrift(ctx, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to detect release drift: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(drift)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// HelmBatch returns a handler function for the helmBatch tool
+func HelmBatch(client *helm.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		operation, err := getRequiredStringArg(args, "operation")
+		if err != nil {
+			return nil, err
+		}
+
+		// Releases are given as "namespace/name", or as a bare name in the default namespace
+		defaultNamespace := getStringArg(args, "namespace", "default")
+		var releases []helm.ReleaseRef
+		for _, entry := range getStringListArg(args, "releases") {
+			ref := helm.ReleaseRef{Namespace: defaultNamespace, Name: entry}
+			if namespace, name, found := strings.Cut(entry, "/"); found {
+				ref = helm.ReleaseRef{Namespace: namespace, Name: name}
+			}
+			releases = append(releases, ref)
+		}
+		if len(releases) == 0 {
+			return nil, fmt.Errorf("missing required parameter: releases")
+		}
+
+		op := helm.BatchOperation{
+			Operation:   operation,
+			Revision:    getIntArg(args, "revision", 0),
+			Parallelism: getIntArg(args, "parallelism", 4),
+		}
+		if operation == "upgrade" {
+			op.ChartName, op.ChartData, err = getChartArgs(args)
+			if err != nil {
+				return nil, err
+			}
+			if values, ok := args["values"].(map[string]interface{}); ok {
+				op.Values = values
+			}
+		}
+
+		progress := newProgressReporter(ctx, request)
+		finished := 0
+		onResult := func(result map[string]interface{}) {
+			resultJSON, err := json.Marshal(result)
+			if err != nil {
+				return
+			}
+			finished++
+			progress.report(float64(finished), float64(len(releases)), string(resultJSON))
+		}
+
+		result, err := client.BatchHelmOperation(ctx, op, releases, onResult)
+		if err != nil {
+			return nil, fmt.Errorf("failed to run batch operation: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// HelmReleaseObjects returns a handler function for the helmReleaseObjects tool
+func HelmReleaseObjects(client *helm.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		releaseName, err := getRequiredStringArg(args, "releaseName")
+		if err != nil {
+			return nil, err
+		}
+
+		namespace := getStringArg(args, "namespace", "default")
+		allNamespaces := getBoolArg(args, "allNamespaces", false)
+
+		objects, err := client.ListReleaseObjectsByLabel(ctx, namespace, releaseName, allNamespaces)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list release objects: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(objects)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
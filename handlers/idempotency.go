@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/reza-gholizade/k8s-mcp-server/pkg/auth"
+)
+
+// idempotencyEntry holds the outcome of a mutating call made with an idempotency key.
+// done is closed once the call has finished.
+type idempotencyEntry struct {
+	done    chan struct{}
+	args    string
+	result  *mcp.CallToolResult
+	err     error
+	expires time.Time
+}
+
+// IdempotentCalls returns a middleware that deduplicates retried mutating calls. When
+// a call to a tool that is not read-only carries an idempotencyKey argument, its result
+// is cached under the tool, the key, and the caller for ttl, and a repeated call by the
+// same caller with the same key returns the cached result instead of executing again.
+// The caller is the token the call was authenticated with or, without authentication,
+// the client session, so that one client cannot replay another's result. Sessions
+// only identify a client when the transport issues and checks them, as stdio, SSE,
+// and stateful streamable-http do, which trustSessions tells; otherwise calls with an
+// idempotencyKey and no token are rejected. A repeat that arrives while the first
+// call is still running waits for it. Failed calls are not cached, so they can be
+// retried, and reusing a key with different arguments is rejected; full, which only
+// affects truncation, is not compared. It must run outside TruncateResponses, so
+// that the complete result is cached.
+func IdempotentCalls(ttl time.Duration, trustSessions bool, lookup func(name string) (mcp.Tool, bool)) server.ToolHandlerMiddleware {
+	var mu sync.Mutex
+	entries := map[string]*idempotencyEntry{}
+
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args, ok := request.Params.Arguments.(map[string]interface{})
+			if !ok {
+				return next(ctx, request)
+			}
+			key := getStringArg(args, "idempotencyKey", "")
+			if key == "" {
+				return next(ctx, request)
+			}
+			if tool, found := lookup(request.Params.Name); found && tool.Annotations.ReadOnlyHint != nil && *tool.Annotations.ReadOnlyHint {
+				return next(ctx, request)
+			}
+
+			// Fingerprint the arguments so a key cannot silently replay a different call
+			fingerprint := make(map[string]interface{}, len(args))
+			for name, value := range args {
+				if name != "idempotencyKey" && name != "full" {
+					fingerprint[name] = value
+				}
+			}
+			argsJSON, err := json.Marshal(fingerprint)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fingerprint arguments: %w", err)
+			}
+			caller, ok := auth.PrincipalFromContext(ctx)
+			if !ok {
+				if session := server.ClientSessionFromContext(ctx); trustSessions && session != nil {
+					caller = session.SessionID()
+				}
+				// Without a caller, any client could replay another's result by its key
+				if caller == "" {
+					return nil, fmt.Errorf("idempotencyKey requires the call to be authenticated with a token or made in a stateful session; retry without it, or enable --auth-tokens or --stateful")
+				}
+			}
+			cacheKey := request.Params.Name + "\x00" + caller + "\x00" + key
+
+			mu.Lock()
+			now := time.Now()
+			for k, entry := range entries {
+				if !entry.expires.IsZero() && now.After(entry.expires) {
+					delete(entries, k)
+				}
+			}
+			if entry, exists := entries[cacheKey]; exists {
+				mu.Unlock()
+				if entry.args != string(argsJSON) {
+					return nil, fmt.Errorf("idempotencyKey '%s' was already used for a %s call with different arguments", key, request.Params.Name)
+				}
+				select {
+				case <-entry.done:
+					return entry.result, entry.err
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				}
+			}
+			entry := &idempotencyEntry{done: make(chan struct{}), args: string(argsJSON)}
+			entries[cacheKey] = entry
+			mu.Unlock()
+
+			entry.result, entry.err = next(ctx, request)
+
+			mu.Lock()
+			if entry.err != nil || entry.result == nil || entry.result.IsError {
+				delete(entries, cacheKey)
+			} else {
+				entry.expires = time.Now().Add(ttl)
+			}
+			mu.Unlock()
+			close(entry.done)
+
+			return entry.result, entry.err
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/reza-gholizade/k8s-mcp-server/pkg/auth"
+)
+
+// testSession is a client session identified only by its ID.
+type testSession struct {
+	id string
+}
+
+func (s testSession) Initialize()                                         {}
+func (s testSession) Initialized() bool                                   { return true }
+func (s testSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return nil }
+func (s testSession) SessionID() string                                   { return s.id }
+
+// countingHandler returns a handler that counts its calls and answers with the count.
+func countingHandler(calls *int) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		*calls++
+		return mcp.NewToolResultText(strings.Repeat("x", *calls)), nil
+	}
+}
+
+func callRequest(args map[string]interface{}) mcp.CallToolRequest {
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "deleteResource"
+	request.Params.Arguments = args
+	return request
+}
+
+func noTools(name string) (mcp.Tool, bool) {
+	return mcp.Tool{}, false
+}
+
+func TestIdempotentCallsReplaysPerSession(t *testing.T) {
+	mcpServer := server.NewMCPServer("test", "0")
+	calls := 0
+	handler := IdempotentCalls(time.Minute, true, noTools)(countingHandler(&calls))
+	args := map[string]interface{}{"kind": "Pod", "name": "web", "idempotencyKey": "k1"}
+
+	first := mcpServer.WithContext(context.Background(), testSession{id: "session-1"})
+	second := mcpServer.WithContext(context.Background(), testSession{id: "session-2"})
+	for _, ctx := range []context.Context{first, first, second} {
+		if _, err := handler(ctx, callRequest(args)); err != nil {
+			t.Fatalf("call failed: %v", err)
+		}
+	}
+	// The retry in the first session is replayed; the second session runs on its own
+	if calls != 2 {
+		t.Errorf("got %d executions, want 2", calls)
+	}
+
+	// full only affects truncation, so it does not count as different arguments
+	withFull := map[string]interface{}{"kind": "Pod", "name": "web", "idempotencyKey": "k1", "full": true}
+	if _, err := handler(first, callRequest(withFull)); err != nil {
+		t.Errorf("replay with full: %v", err)
+	}
+	changed := map[string]interface{}{"kind": "Pod", "name": "api", "idempotencyKey": "k1"}
+	if _, err := handler(first, callRequest(changed)); err == nil {
+		t.Error("expected reusing the key with different arguments to be rejected")
+	}
+	if calls != 2 {
+		t.Errorf("got %d executions, want 2", calls)
+	}
+}
+
+func TestIdempotentCallsByToken(t *testing.T) {
+	tokens := auth.TokenStore{"token-a": auth.ScopeWrite, "token-b": auth.ScopeWrite}
+	withToken := func(token string) context.Context {
+		r := httptest.NewRequest("POST", "/mcp", nil)
+		r.Header.Set("Authorization", "Bearer "+token)
+		return tokens.ContextFunc(context.Background(), r)
+	}
+	calls := 0
+	// Sessions are not trusted, as in stateless streamable-http, but tokens are
+	handler := IdempotentCalls(time.Minute, false, noTools)(countingHandler(&calls))
+	args := map[string]interface{}{"name": "web", "idempotencyKey": "k1"}
+
+	for _, ctx := range []context.Context{withToken("token-a"), withToken("token-a"), withToken("token-b")} {
+		if _, err := handler(ctx, callRequest(args)); err != nil {
+			t.Fatalf("call failed: %v", err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("got %d executions, want 2", calls)
+	}
+}
+
+func TestIdempotentCallsWithoutCaller(t *testing.T) {
+	mcpServer := server.NewMCPServer("test", "0")
+	calls := 0
+	handler := IdempotentCalls(time.Minute, false, noTools)(countingHandler(&calls))
+
+	// A client-chosen session ID does not identify the client
+	ctx := mcpServer.WithContext(context.Background(), testSession{id: "guessable"})
+	if _, err := handler(ctx, callRequest(map[string]interface{}{"idempotencyKey": "k1"})); err == nil {
+		t.Error("expected a call with an idempotencyKey and no token to be rejected")
+	}
+	// Calls without a key are not affected
+	if _, err := handler(ctx, callRequest(map[string]interface{}{"name": "web"})); err != nil {
+		t.Errorf("call without a key: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("got %d executions, want 1", calls)
+	}
+}
+
+func TestIdempotentCallsDoesNotCacheFailures(t *testing.T) {
+	mcpServer := server.NewMCPServer("test", "0")
+	ctx := mcpServer.WithContext(context.Background(), testSession{id: "session-1"})
+	calls := 0
+	handler := IdempotentCalls(time.Minute, true, noTools)(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		calls++
+		return mcp.NewToolResultError("failed"), nil
+	})
+	args := map[string]interface{}{"idempotencyKey": "k1"}
+	handler(ctx, callRequest(args))
+	handler(ctx, callRequest(args))
+	if calls != 2 {
+		t.Errorf("got %d executions, want failed calls to be retried", calls)
+	}
+}
@@ -5,6 +5,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/reza-gholizade/k8s-mcp-server/pkg/k8s"
 
@@ -26,6 +29,52 @@ func getBoolArg(args map[string]interface{}, key string, defaultValue bool) bool
 	return defaultValue
 }
 
+func getIntArg(args map[string]interface{}, key string, defaultValue int) int {
+	switch val := args[key].(type) {
+	case float64:
+		return int(val)
+	case int:
+		return val
+	case string:
+		if i, err := strconv.Atoi(val); err == nil {
+			return i
+		}
+	}
+	return defaultValue
+}
+
+func getStringListArg(args map[string]interface{}, key string) []string {
+	var values []string
+	switch val := args[key].(type) {
+	case []interface{}:
+		for _, item := range val {
+			if str, ok := item.(string); ok && str != "" {
+				values = append(values, str)
+			}
+		}
+	case string:
+		for _, item := range strings.Split(val, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				values = append(values, item)
+			}
+		}
+	}
+	return values
+}
+
+// uniqueStrings returns values without duplicates, keeping the first occurrence of each.
+func uniqueStrings(values []string) []string {
+	seen := map[string]bool{}
+	var unique []string
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
+
 func getRequiredStringArg(args map[string]interface{}, key string) (string, error) {
 	val, ok := args[key].(string)
 	if !ok || val == "" {
@@ -34,6 +83,19 @@ func getRequiredStringArg(args map[string]interface{}, key string) (string, erro
 	return val, nil
 }
 
+// getNamespaceScopeArg resolves the namespace to query from the namespace and
+// allNamespaces arguments. An empty namespace only means "all namespaces" when
+// allNamespaces is explicitly set; otherwise it falls back to the default namespace.
+func getNamespaceScopeArg(args map[string]interface{}) string {
+	if getBoolArg(args, "allNamespaces", false) {
+		return ""
+	}
+	if namespace := getStringArg(args, "namespace", ""); namespace != "" {
+		return namespace
+	}
+	return "default"
+}
+
 // GetAPIResources returns a handler function for the getAPIResources tool.
 // It retrieves API resources from the Kubernetes cluster based on the provided
 // context and parameters (includeNamespaceScoped, includeClusterScoped).
@@ -82,16 +144,105 @@ func ListResources(client *k8s.Client) func(ctx context.Context, request mcp.Cal
 			return nil, err
 		}
 
-		namespace := getStringArg(args, "namespace", "")
+		namespace := getNamespaceScopeArg(args)
 		labelSelector := getStringArg(args, "labelSelector", "")
 		fieldSelector := getStringArg(args, "fieldSelector", "")
+		reveal := getBoolArg(args, "reveal", false)
+
+		consistency, err := k8s.ParseReadConsistency(getStringArg(args, "consistency", ""))
+		if err != nil {
+			return nil, err
+		}
+
+		now := time.Now()
+		createdAfter, err := k8s.ParseTimeBound(getStringArg(args, "createdAfter", ""), now)
+		if err != nil {
+			return nil, fmt.Errorf("invalid createdAfter: %w", err)
+		}
+		createdBefore, err := k8s.ParseTimeBound(getStringArg(args, "createdBefore", ""), now)
+		if err != nil {
+			return nil, fmt.Errorf("invalid createdBefore: %w", err)
+		}
+
+		fields, err := k8s.ParseFieldPaths(getStringArg(args, "fields", ""))
+		if err != nil {
+			return nil, err
+		}
+
+		var columns []k8s.PrinterColumn
+		if getBoolArg(args, "printerColumns", false) {
+			columns, err = client.GetPrinterColumns(ctx, kind)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// With a page size, list page by page; if the client asked for progress,
+		// each page is streamed to it as a notification as soon as it arrives
+		var paging *k8s.ListPaging
+		progress := newProgressReporter(ctx, request)
+		if pageSize := getIntArg(args, "pageSize", 0); pageSize > 0 {
+			received := 0
+			paging = &k8s.ListPaging{PageSize: int64(pageSize)}
+			if progress.enabled() {
+				paging.OnPage = func(page []map[string]interface{}) error {
+					if !reveal {
+						for i := range page {
+							page[i] = client.MaskSensitiveFields(page[i])
+						}
+					}
+					pageJSON, err := json.Marshal(page)
+					if err != nil {
+						return fmt.Errorf("failed to serialize page: %w", err)
+					}
+					received += len(page)
+					progress.report(float64(received), 0, string(pageJSON))
+					return nil
+				}
+			}
+		}
+
+		opts := k8s.ListOptions{
+			LabelSelector: labelSelector,
+			FieldSelector: fieldSelector,
+			CreatedAfter:  createdAfter,
+			CreatedBefore: createdBefore,
+			Fields:        fields,
+			Columns:       columns,
+			Consistency:   consistency,
+			Paging:        paging,
+		}
 
-		// Fetch resources
-		resources, err := client.ListResources(ctx, kind, namespace, labelSelector, fieldSelector)
+		// Fetch resources, from each of several namespaces if given
+		var resources []map[string]interface{}
+		if namespaces := getStringListArg(args, "namespaces"); len(namespaces) > 0 && !getBoolArg(args, "allNamespaces", false) {
+			resources, err = client.ListResourcesInNamespaces(ctx, kind, uniqueStrings(namespaces), opts)
+		} else {
+			resources, err = client.ListResources(ctx, kind, namespace, opts)
+		}
 		if err != nil {
 			return nil, fmt.Errorf("failed to list resources for kind '%s': %w", kind, err)
 		}
 
+		// Streamed items were already delivered; only summarize them
+		if paging != nil && paging.OnPage != nil {
+			jsonResponse, err := json.Marshal(map[string]interface{}{
+				"kind":     kind,
+				"count":    len(resources),
+				"streamed": true,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to serialize response: %w", err)
+			}
+			return mcp.NewToolResultText(string(jsonResponse)), nil
+		}
+
+		if !reveal {
+			for i := range resources {
+				resources[i] = client.MaskSensitiveFields(resources[i])
+			}
+		}
+
 		// Serialize response to JSON
 		jsonResponse, err := json.Marshal(resources)
 		if err != nil {
@@ -124,11 +275,28 @@ func GetResources(client *k8s.Client) func(ctx context.Context, request mcp.Call
 		}
 
 		namespace := getStringArg(args, "namespace", "")
+		reveal := getBoolArg(args, "reveal", false)
+
+		consistency, err := k8s.ParseReadConsistency(getStringArg(args, "consistency", ""))
+		if err != nil {
+			return nil, err
+		}
 
-		resource, err := client.GetResource(ctx, kind, name, namespace)
+		fields, err := k8s.ParseFieldPaths(getStringArg(args, "fields", ""))
+		if err != nil {
+			return nil, err
+		}
+
+		resource, err := client.GetResource(ctx, kind, name, namespace, consistency)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get resource '%s' of kind '%s': %w", name, kind, err)
 		}
+		if len(fields) > 0 {
+			resource = k8s.ProjectFields(resource, fields)
+		}
+		if !reveal {
+			resource = client.MaskSensitiveFields(resource)
+		}
 
 		jsonResponse, err := json.Marshal(resource)
 		if err != nil {
@@ -162,12 +330,16 @@ func DescribeResources(client *k8s.Client) func(ctx context.Context, request mcp
 		}
 
 		namespace := getStringArg(args, "namespace", "")
+		reveal := getBoolArg(args, "reveal", false)
 
 		// Fetch resource description
 		resourceDescription, err := client.DescribeResource(ctx, kind, name, namespace)
 		if err != nil {
 			return nil, fmt.Errorf("failed to describe resource '%s' of kind '%s': %w", name, kind, err)
 		}
+		if !reveal {
+			resourceDescription = client.MaskSensitiveFields(resourceDescription)
+		}
 
 		// Serialize response to JSON
 		jsonResponse, err := json.Marshal(resourceDescription)
@@ -202,8 +374,13 @@ func GetPodsLogs(client *k8s.Client) func(ctx context.Context, request mcp.CallT
 		}
 
 		containerName := getStringArg(args, "containerName", "")
+		includeInit := getBoolArg(args, "includeInit", true)
+		limitBytes := getIntArg(args, "limitBytes", 0)
+		if limitBytes < 0 {
+			return nil, fmt.Errorf("limitBytes must not be negative")
+		}
 
-		logs, err := client.GetPodsLogs(ctx, namespace, containerName, name)
+		logs, err := client.GetPodsLogs(ctx, namespace, containerName, name, includeInit, int64(limitBytes))
 		if err != nil {
 			return nil, fmt.Errorf("failed to get logs for pod '%s': %w", name, err)
 		}
@@ -265,7 +442,9 @@ func GetPodMetrics(client *k8s.Client) func(ctx context.Context, request mcp.Cal
 			return nil, err
 		}
 
-		metrics, err := client.GetPodMetrics(ctx, namespace, podName)
+		includeUtilization := getBoolArg(args, "includeUtilization", false)
+
+		metrics, err := client.GetPodMetrics(ctx, namespace, podName, includeUtilization)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get metrics for pod '%s' in namespace '%s': %w", podName, namespace, err)
 		}
@@ -289,9 +468,15 @@ func GetEvents(client *k8s.Client) func(ctx context.Context, request mcp.CallToo
 			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
 		}
 
-		namespace := getStringArg(args, "namespace", "")
+		namespace := getNamespaceScopeArg(args)
 
-		events, err := client.GetEvents(ctx, namespace)
+		var events []map[string]interface{}
+		var err error
+		if getBoolArg(args, "sorted", false) {
+			events, err = client.GetSortedEvents(ctx, namespace, getIntArg(args, "limit", 0))
+		} else {
+			events, err = client.GetEvents(ctx, namespace)
+		}
 		if err != nil {
 			return nil, fmt.Errorf("failed to get events: %w", err)
 		}
@@ -305,6 +490,37 @@ func GetEvents(client *k8s.Client) func(ctx context.Context, request mcp.CallToo
 	}
 }
 
+// withOwnerArgs adds an owner reference to the manifest when the ownerKind and
+// ownerName arguments are set, resolving the owner's UID from the cluster.
+func withOwnerArgs(ctx context.Context, client *k8s.Client, args map[string]interface{}, namespace, manifest string) (string, error) {
+	ownerKind := getStringArg(args, "ownerKind", "")
+	ownerName := getStringArg(args, "ownerName", "")
+	if ownerKind == "" && ownerName == "" {
+		return manifest, nil
+	}
+	if ownerKind == "" || ownerName == "" {
+		return "", fmt.Errorf("ownerKind and ownerName must be set together")
+	}
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	ref, err := client.ResolveOwnerReference(ctx, namespace, ownerKind, ownerName, getStringArg(args, "ownerUID", ""), getBoolArg(args, "ownerController", false))
+	if err != nil {
+		return "", err
+	}
+	return k8s.AddOwnerReference(manifest, ref)
+}
+
+// withLastAppliedArg records the manifest in its last-applied-configuration
+// annotation when the recordLastApplied argument is set.
+func withLastAppliedArg(args map[string]interface{}, namespace, manifest string) (string, error) {
+	if !getBoolArg(args, "recordLastApplied", false) {
+		return manifest, nil
+	}
+	return k8s.SetLastAppliedConfiguration(manifest, namespace)
+}
+
 // CreateOrUpdateResource returns a handler function for the createOrUpdateResource tool.
 // It creates or updates a resource in the Kubernetes cluster based on the provided
 // namespace and manifest. The result is serialized to JSON and returned.
@@ -323,7 +539,27 @@ func CreateOrUpdateResourceJSON(client *k8s.Client) func(ctx context.Context, re
 		namespace := getStringArg(args, "namespace", "")
 		kind := getStringArg(args, "kind", "")
 
-		resource, err := client.CreateOrUpdateResourceJSON(ctx, namespace, manifest, kind)
+		fieldValidation, err := k8s.ParseFieldValidation(getStringArg(args, "fieldValidation", ""))
+		if err != nil {
+			return nil, err
+		}
+
+		manifest, err = withOwnerArgs(ctx, client, args, namespace, manifest)
+		if err != nil {
+			return nil, err
+		}
+
+		manifest, err = withLastAppliedArg(args, namespace, manifest)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := client.CheckQuotaHeadroom(ctx, namespace, manifest, kind); err != nil {
+			return nil, err
+		}
+
+		ctx, warnings := k8s.CollectWarnings(ctx)
+		resource, err := client.CreateOrUpdateResourceJSON(ctx, namespace, manifest, kind, fieldValidation)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create or update resource: %w", err)
 		}
@@ -333,7 +569,7 @@ func CreateOrUpdateResourceJSON(client *k8s.Client) func(ctx context.Context, re
 			return nil, fmt.Errorf("failed to serialize response: %w", err)
 		}
 
-		return mcp.NewToolResultText(string(jsonResponse)), nil
+		return withServerWarnings(mcp.NewToolResultText(string(jsonResponse)), warnings()), nil
 	}
 }
 
@@ -356,7 +592,27 @@ func CreateOrUpdateResourceYAML(client *k8s.Client) func(ctx context.Context, re
 		namespace := getStringArg(args, "namespace", "")
 		kind := getStringArg(args, "kind", "")
 
-		resource, err := client.CreateOrUpdateResourceYAML(ctx, namespace, yamlManifest, kind)
+		fieldValidation, err := k8s.ParseFieldValidation(getStringArg(args, "fieldValidation", ""))
+		if err != nil {
+			return nil, err
+		}
+
+		yamlManifest, err = withOwnerArgs(ctx, client, args, namespace, yamlManifest)
+		if err != nil {
+			return nil, err
+		}
+
+		yamlManifest, err = withLastAppliedArg(args, namespace, yamlManifest)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := client.CheckQuotaHeadroom(ctx, namespace, yamlManifest, kind); err != nil {
+			return nil, err
+		}
+
+		ctx, warnings := k8s.CollectWarnings(ctx)
+		resource, err := client.CreateOrUpdateResourceYAML(ctx, namespace, yamlManifest, kind, fieldValidation)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create or update resource from YAML: %w", err)
 		}
@@ -366,8 +622,18 @@ func CreateOrUpdateResourceYAML(client *k8s.Client) func(ctx context.Context, re
 			return nil, fmt.Errorf("failed to serialize response: %w", err)
 		}
 
-		return mcp.NewToolResultText(string(jsonResponse)), nil
+		return withServerWarnings(mcp.NewToolResultText(string(jsonResponse)), warnings()), nil
+	}
+}
+
+// withServerWarnings appends the warnings the API server returned, such as those for
+// unknown fields dropped with Warn field validation, to a result as a separate text
+// content, leaving the JSON content unchanged.
+func withServerWarnings(result *mcp.CallToolResult, warnings []string) *mcp.CallToolResult {
+	if len(warnings) > 0 {
+		result.Content = append(result.Content, mcp.NewTextContent("API server warnings:\n"+strings.Join(warnings, "\n")))
 	}
+	return result
 }
 
 // DeleteResource returns a handler function for the deleteResource tool.
@@ -457,3 +723,2012 @@ func RolloutRestart(client *k8s.Client) func(ctx context.Context, request mcp.Ca
 		return mcp.NewToolResultText(string(jsonResponse)), nil
 	}
 }
+
+// RunJobAndWait returns a handler function for the runJobAndWait tool.
+// It optionally creates a Job from a YAML manifest, then waits for the Job to
+// complete or fail and returns its final status together with its pods' logs.
+func RunJobAndWait(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		namespace, err := getRequiredStringArg(args, "namespace")
+		if err != nil {
+			return nil, err
+		}
+
+		name := getStringArg(args, "name", "")
+		manifest := getStringArg(args, "manifest", "")
+		timeoutSeconds := getIntArg(args, "timeoutSeconds", 300)
+
+		// Create the job first if a manifest was provided
+		if manifest != "" {
+			name, err = client.CreateJob(ctx, namespace, manifest)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if name == "" {
+			return nil, fmt.Errorf("either name or manifest is required")
+		}
+
+		result, err := client.WaitForJob(ctx, namespace, name, time.Duration(timeoutSeconds)*time.Second)
+		if err != nil {
+			return nil, fmt.Errorf("failed to wait for job '%s': %w", name, err)
+		}
+
+		jsonResponse, err := json.Marshal(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// GetClusterCapacity returns a handler function for the getClusterCapacity tool.
+// It summarizes allocatable node resources against pod requests and limits.
+// The result is serialized to JSON and returned.
+func GetClusterCapacity(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		capacity, err := client.GetClusterCapacity(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get cluster capacity: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(capacity)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// EvictPod returns a handler function for the evictPod tool.
+// It evicts a pod through the eviction API, honoring PodDisruptionBudgets.
+// The result is serialized to JSON and returned.
+func EvictPod(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		name, err := getRequiredStringArg(args, "name")
+		if err != nil {
+			return nil, err
+		}
+
+		namespace, err := getRequiredStringArg(args, "namespace")
+		if err != nil {
+			return nil, err
+		}
+
+		var gracePeriodSeconds *int64
+		if _, exists := args["gracePeriodSeconds"]; exists {
+			gracePeriod := int64(getIntArg(args, "gracePeriodSeconds", 0))
+			gracePeriodSeconds = &gracePeriod
+		}
+
+		result, err := client.EvictPod(ctx, namespace, name, gracePeriodSeconds)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evict pod: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// ScaleResource returns a handler function for the scaleResource tool.
+// It reads or sets the replica count of a workload, refusing to scale workloads
+// managed by a HorizontalPodAutoscaler unless force is set.
+// The result is serialized to JSON and returned.
+func ScaleResource(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		kind, err := getRequiredStringArg(args, "kind")
+		if err != nil {
+			return nil, err
+		}
+
+		name, err := getRequiredStringArg(args, "name")
+		if err != nil {
+			return nil, err
+		}
+
+		namespace, err := getRequiredStringArg(args, "namespace")
+		if err != nil {
+			return nil, err
+		}
+
+		var replicas *int64
+		if _, exists := args["replicas"]; exists {
+			count := int64(getIntArg(args, "replicas", 0))
+			if count < 0 {
+				return nil, fmt.Errorf("replicas must not be negative")
+			}
+			replicas = &count
+		}
+		force := getBoolArg(args, "force", false)
+
+		result, err := client.ScaleResource(ctx, kind, name, namespace, replicas, force)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scale resource: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// ApplyAndPrune returns a handler function for the applyAndPrune tool.
+// It applies a bundle of manifests and prunes labeled objects that are no longer
+// part of the bundle. The result is serialized to JSON and returned.
+func ApplyAndPrune(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		manifests, err := getRequiredStringArg(args, "manifests")
+		if err != nil {
+			return nil, err
+		}
+
+		pruneSelector, err := getRequiredStringArg(args, "pruneSelector")
+		if err != nil {
+			return nil, err
+		}
+
+		namespace := getStringArg(args, "namespace", "default")
+		pruneKinds := getStringListArg(args, "pruneKinds")
+
+		result, err := client.ApplyAndPrune(ctx, namespace, manifests, pruneSelector, pruneKinds)
+		if err != nil {
+			return nil, fmt.Errorf("failed to apply and prune: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// GetControlPlaneHealth returns a handler function for the getControlPlaneHealth tool.
+// It reports API server readiness, component statuses, and control-plane pod health.
+// The result is serialized to JSON and returned.
+func GetControlPlaneHealth(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		health, err := client.GetControlPlaneHealth(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get control plane health: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(health)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// GetStorage returns a handler function for the getStorage tool.
+// It returns persistent volume claims with their bound volumes and any unbound
+// persistent volumes. The result is serialized to JSON and returned.
+func GetStorage(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		namespace := getNamespaceScopeArg(args)
+
+		storage, err := client.ListStorage(ctx, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get storage overview: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(storage)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// ResolveSelector returns a handler function for the resolveSelector tool.
+// It lists the pods matched by a label selector in a namespace.
+// The result is serialized to JSON and returned.
+func ResolveSelector(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		labelSelector, err := getRequiredStringArg(args, "labelSelector")
+		if err != nil {
+			return nil, err
+		}
+
+		namespace, err := getRequiredStringArg(args, "namespace")
+		if err != nil {
+			return nil, err
+		}
+
+		pods, err := client.ResolveSelector(ctx, namespace, labelSelector)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve selector: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(pods)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// GetWorkloadReadiness returns a handler function for the getWorkloadReadiness tool.
+// It lists Deployments, StatefulSets, and DaemonSets whose ready replicas differ
+// from the desired count. The result is serialized to JSON and returned.
+func GetWorkloadReadiness(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		namespace := getNamespaceScopeArg(args)
+
+		workloads, err := client.GetWorkloadReadiness(ctx, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get workload readiness: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(workloads)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// GetPodEnv returns a handler function for the getPodEnv tool.
+// It resolves the effective environment of a pod's containers, including values
+// sourced from ConfigMaps and Secrets. Secret values are redacted unless reveal
+// is set. The result is serialized to JSON and returned.
+func GetPodEnv(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		name, err := getRequiredStringArg(args, "name")
+		if err != nil {
+			return nil, err
+		}
+
+		namespace, err := getRequiredStringArg(args, "namespace")
+		if err != nil {
+			return nil, err
+		}
+
+		containerName := getStringArg(args, "containerName", "")
+		reveal := getBoolArg(args, "reveal", false)
+
+		env, err := client.GetPodEnv(ctx, namespace, name, containerName, reveal)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get environment for pod '%s': %w", name, err)
+		}
+
+		jsonResponse, err := json.Marshal(env)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// CreateFromTemplate returns a handler function for the createFromTemplate tool.
+// It renders a templated manifest with the provided variables and applies the
+// resulting resources. The result is serialized to JSON and returned.
+func CreateFromTemplate(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		manifestTemplate, err := getRequiredStringArg(args, "template")
+		if err != nil {
+			return nil, err
+		}
+
+		namespace := getStringArg(args, "namespace", "default")
+
+		variables := make(map[string]interface{})
+		if v, exists := args["variables"]; exists {
+			if variablesMap, ok := v.(map[string]interface{}); ok {
+				variables = variablesMap
+			}
+		}
+
+		result, err := client.CreateFromTemplate(ctx, namespace, manifestTemplate, variables)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create resources from template: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// DiagnoseAlert returns a handler function for the diagnoseAlert tool.
+// It maps an alert's labels to the Kubernetes object it concerns and gathers
+// that object's state, events, logs, and metrics in one response.
+// The result is serialized to JSON and returned.
+func DiagnoseAlert(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		labelsArg, ok := args["labels"].(map[string]interface{})
+		if !ok || len(labelsArg) == 0 {
+			return nil, fmt.Errorf("missing required parameter: labels")
+		}
+		alertLabels := make(map[string]string, len(labelsArg))
+		for key, value := range labelsArg {
+			alertLabels[key] = fmt.Sprint(value)
+		}
+
+		diagnosis, err := client.CorrelateAlert(ctx, alertLabels)
+		if err != nil {
+			return nil, fmt.Errorf("failed to correlate alert: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(diagnosis)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// ExportNamespace returns a handler function for the exportNamespace tool.
+// It exports the resources of a namespace as a multi-document YAML bundle with
+// server-populated fields removed. Kinds that could not be exported are listed
+// as comments at the top of the bundle.
+func ExportNamespace(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		namespace, err := getRequiredStringArg(args, "namespace")
+		if err != nil {
+			return nil, err
+		}
+
+		kinds := getStringListArg(args, "kinds")
+		reveal := getBoolArg(args, "reveal", false)
+
+		bundle, skipped, err := client.ExportNamespace(ctx, namespace, kinds, reveal)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export namespace '%s': %w", namespace, err)
+		}
+
+		var header strings.Builder
+		for _, reason := range skipped {
+			header.WriteString("# skipped " + reason + "\n")
+		}
+
+		return mcp.NewToolResultText(header.String() + bundle), nil
+	}
+}
+
+// CloneResource returns a handler function for the cloneResource tool.
+// It copies a resource into another namespace or under a new name after stripping
+// server-populated fields, applying any overrides. The result is serialized to JSON and returned.
+func CloneResource(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		kind, err := getRequiredStringArg(args, "kind")
+		if err != nil {
+			return nil, err
+		}
+
+		name, err := getRequiredStringArg(args, "name")
+		if err != nil {
+			return nil, err
+		}
+
+		namespace := getStringArg(args, "namespace", "default")
+		targetNamespace := getStringArg(args, "targetNamespace", "")
+		targetName := getStringArg(args, "targetName", "")
+
+		var overrides map[string]interface{}
+		if v, exists := args["overrides"]; exists {
+			if overridesMap, ok := v.(map[string]interface{}); ok {
+				overrides = overridesMap
+			}
+		}
+
+		clone, err := client.CloneResource(ctx, kind, name, namespace, targetNamespace, targetName, overrides)
+		if err != nil {
+			return nil, fmt.Errorf("failed to clone %s '%s': %w", kind, name, err)
+		}
+
+		jsonResponse, err := json.Marshal(client.MaskSensitiveFields(clone))
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// GetPodOwner returns a handler function for the getPodOwner tool.
+// It resolves the top-level workload controlling a pod by walking its
+// ownerReferences. The result is serialized to JSON and returned.
+func GetPodOwner(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		name, err := getRequiredStringArg(args, "name")
+		if err != nil {
+			return nil, err
+		}
+
+		namespace, err := getRequiredStringArg(args, "namespace")
+		if err != nil {
+			return nil, err
+		}
+
+		owner, err := client.GetPodOwner(ctx, namespace, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get owner of pod '%s': %w", name, err)
+		}
+
+		jsonResponse, err := json.Marshal(owner)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// DryRunResource returns a handler function for the dryRunResource tool.
+// It submits a manifest to the API server in dry-run mode and returns the object
+// as it would be stored after defaulting and admission, with sensitive fields
+// masked unless reveal is set. The result is serialized to JSON and returned.
+func DryRunResource(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		manifest, err := getRequiredStringArg(args, "manifest")
+		if err != nil {
+			return nil, err
+		}
+
+		namespace := getStringArg(args, "namespace", "")
+		kind := getStringArg(args, "kind", "")
+		reveal := getBoolArg(args, "reveal", false)
+
+		result, err := client.DryRunResource(ctx, namespace, manifest, kind)
+		if err != nil {
+			return nil, fmt.Errorf("failed to dry-run resource: %w", err)
+		}
+		if !reveal {
+			if obj, ok := result["result"].(map[string]interface{}); ok {
+				result["result"] = client.MaskSensitiveFields(obj)
+			}
+		}
+
+		jsonResponse, err := json.Marshal(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// GetClusterWarnings returns a handler function for the getClusterWarnings tool.
+// It summarizes recent Warning events across all namespaces, grouped by reason and
+// involved object kind. The result is serialized to JSON and returned.
+func GetClusterWarnings(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		window, err := time.ParseDuration(getStringArg(args, "window", "1h"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid window: %w", err)
+		}
+		if window <= 0 {
+			return nil, fmt.Errorf("window must be positive")
+		}
+
+		warnings, err := client.GetClusterWarnings(ctx, window)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get cluster warnings: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(warnings)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// WatchRollout returns a handler function for the watchRollout tool.
+// It follows a workload rollout, optionally triggering it first, until it completes
+// or times out. If the client asked for progress, every event and status change is
+// streamed as a progress notification as it happens. The result is serialized to JSON and returned.
+func WatchRollout(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		kind, err := getRequiredStringArg(args, "kind")
+		if err != nil {
+			return nil, err
+		}
+
+		name, err := getRequiredStringArg(args, "name")
+		if err != nil {
+			return nil, err
+		}
+
+		namespace := getStringArg(args, "namespace", "default")
+		restart := getBoolArg(args, "restart", false)
+
+		timeout, err := time.ParseDuration(getStringArg(args, "timeout", "5m"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout: %w", err)
+		}
+		if timeout <= 0 {
+			return nil, fmt.Errorf("timeout must be positive")
+		}
+
+		progress := newProgressReporter(ctx, request)
+		updates := 0
+		onUpdate := func(update map[string]interface{}) {
+			updateJSON, err := json.Marshal(update)
+			if err != nil {
+				return
+			}
+			updates++
+			progress.report(float64(updates), 0, string(updateJSON))
+		}
+
+		result, err := client.WatchRollout(ctx, kind, name, namespace, restart, timeout, onUpdate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to watch rollout: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// GetRolloutProgress returns a handler function for the rolloutProgress tool.
+// It reports a workload rollout's completion as percentages and, by sampling it twice,
+// an estimate of the time remaining. The result is serialized to JSON and returned.
+func GetRolloutProgress(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		kind, err := getRequiredStringArg(args, "kind")
+		if err != nil {
+			return nil, err
+		}
+
+		name, err := getRequiredStringArg(args, "name")
+		if err != nil {
+			return nil, err
+		}
+
+		namespace := getStringArg(args, "namespace", "default")
+
+		sampleFor, err := time.ParseDuration(getStringArg(args, "sampleFor", "10s"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid sampleFor: %w", err)
+		}
+		if sampleFor < 0 || sampleFor > time.Minute {
+			return nil, fmt.Errorf("sampleFor must be between 0s and 1m")
+		}
+
+		result, err := client.GetRolloutProgress(ctx, kind, name, namespace, sampleFor)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get rollout progress: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// RestartNamespace returns a handler function for the restartNamespace tool.
+// It performs a rollout restart of every workload in a namespace, but only when
+// confirm is set; otherwise it lists the workloads that would be restarted.
+// The result is serialized to JSON and returned.
+func RestartNamespace(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		namespace, err := getRequiredStringArg(args, "namespace")
+		if err != nil {
+			return nil, err
+		}
+
+		labelSelector := getStringArg(args, "labelSelector", "")
+		confirm := getBoolArg(args, "confirm", false)
+
+		workloads, err := client.RestartNamespaceWorkloads(ctx, namespace, labelSelector, !confirm)
+		if err != nil {
+			return nil, fmt.Errorf("failed to restart workloads in namespace '%s': %w", namespace, err)
+		}
+
+		response := map[string]interface{}{
+			"namespace": namespace,
+			"confirmed": confirm,
+			"workloads": workloads,
+		}
+		if !confirm {
+			response["message"] = fmt.Sprintf("No workloads were restarted. Call again with confirm set to true to restart these %d workloads.", len(workloads))
+		}
+
+		jsonResponse, err := json.Marshal(response)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// GetMergedLogs returns a handler function for the getMergedLogs tool.
+// It merges the logs of every container in a workload's pods into one
+// time-ordered stream. The result is serialized to JSON and returned.
+func GetMergedLogs(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		kind, err := getRequiredStringArg(args, "kind")
+		if err != nil {
+			return nil, err
+		}
+
+		name, err := getRequiredStringArg(args, "name")
+		if err != nil {
+			return nil, err
+		}
+
+		namespace := getStringArg(args, "namespace", "default")
+		tailLines := getIntArg(args, "tailLines", 100)
+		if tailLines <= 0 {
+			return nil, fmt.Errorf("tailLines must be positive")
+		}
+		limitBytes := getIntArg(args, "limitBytes", 0)
+		if limitBytes < 0 {
+			return nil, fmt.Errorf("limitBytes must not be negative")
+		}
+
+		logs, err := client.GetMergedWorkloadLogs(ctx, kind, name, namespace, int64(tailLines), int64(limitBytes))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get merged logs: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(logs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// GetTerminationDetails returns a handler function for the getTerminationDetails tool.
+// It reports how each terminated container of a pod exited, including its
+// termination message. The result is serialized to JSON and returned.
+func GetTerminationDetails(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		podName, err := getRequiredStringArg(args, "podName")
+		if err != nil {
+			return nil, err
+		}
+
+		namespace := getStringArg(args, "namespace", "default")
+
+		details, err := client.GetTerminationDetails(ctx, namespace, podName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get termination details: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(details)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// GetResourceBundle returns a handler function for the getResourceBundle tool.
+// It returns a resource together with its related objects and recent events.
+// The result is serialized to JSON and returned.
+func GetResourceBundle(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		kind, err := getRequiredStringArg(args, "kind")
+		if err != nil {
+			return nil, err
+		}
+
+		name, err := getRequiredStringArg(args, "name")
+		if err != nil {
+			return nil, err
+		}
+
+		namespace := getStringArg(args, "namespace", "default")
+
+		bundle, err := client.GetResourceBundle(ctx, kind, name, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get resource bundle: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(bundle)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// TestDNS returns a handler function for the testDNS tool.
+// It resolves a hostname through the cluster DNS from a short-lived pod.
+// The result is serialized to JSON and returned.
+func TestDNS(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		hostname, err := getRequiredStringArg(args, "hostname")
+		if err != nil {
+			return nil, err
+		}
+
+		namespace := getStringArg(args, "namespace", "default")
+		image := getStringArg(args, "image", "")
+
+		timeout, err := time.ParseDuration(getStringArg(args, "timeout", "30s"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout: %w", err)
+		}
+		if timeout <= 0 {
+			return nil, fmt.Errorf("timeout must be positive")
+		}
+
+		result, err := client.TestDNS(ctx, namespace, hostname, image, timeout)
+		if err != nil {
+			return nil, fmt.Errorf("failed to test DNS resolution: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// TestConnectivity returns a handler function for the testConnectivity tool.
+// It connects to a Service from a short-lived pod and reports the outcome.
+// The result is serialized to JSON and returned.
+func TestConnectivity(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		service, err := getRequiredStringArg(args, "service")
+		if err != nil {
+			return nil, err
+		}
+
+		namespace := getStringArg(args, "namespace", "default")
+		port := getStringArg(args, "port", "")
+		if p, ok := args["port"].(float64); ok {
+			port = strconv.Itoa(int(p))
+		}
+		mode := getStringArg(args, "mode", "tcp")
+		path := getStringArg(args, "path", "")
+		sourceNamespace := getStringArg(args, "sourceNamespace", "")
+		image := getStringArg(args, "image", "")
+
+		timeout, err := time.ParseDuration(getStringArg(args, "timeout", "10s"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout: %w", err)
+		}
+		if timeout < time.Second {
+			return nil, fmt.Errorf("timeout must be at least 1s")
+		}
+
+		result, err := client.TestServiceConnectivity(ctx, namespace, service, port, mode, path, sourceNamespace, image, timeout)
+		if err != nil {
+			return nil, fmt.Errorf("failed to test connectivity: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// GetRBAC returns a handler function for the getRBAC tool.
+// It lists the role bindings that apply in a namespace (or cluster-wide) with the
+// rules they grant, optionally filtered to one subject. The result is serialized to JSON and returned.
+func GetRBAC(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		namespace := getStringArg(args, "namespace", "")
+
+		var subject *k8s.RBACSubject
+		subjectKind := getStringArg(args, "subjectKind", "")
+		subjectName := getStringArg(args, "subjectName", "")
+		if subjectKind != "" || subjectName != "" {
+			if subjectKind == "" || subjectName == "" {
+				return nil, fmt.Errorf("subjectKind and subjectName must be set together")
+			}
+			subject = &k8s.RBACSubject{
+				Kind:      subjectKind,
+				Name:      subjectName,
+				Namespace: getStringArg(args, "subjectNamespace", ""),
+			}
+		}
+
+		rbac, err := client.GetRBAC(ctx, namespace, subject)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get RBAC: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(rbac)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// ListStuckResources returns a handler function for the listStuckResources tool.
+// It lists objects stuck terminating past a threshold along with the finalizers
+// blocking them. The result is serialized to JSON and returned.
+func ListStuckResources(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		namespace := getStringArg(args, "namespace", "")
+		kinds := getStringListArg(args, "kinds")
+
+		olderThan, err := time.ParseDuration(getStringArg(args, "olderThan", "5m"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid olderThan: %w", err)
+		}
+
+		stuck, skipped, err := client.ListStuckResources(ctx, namespace, kinds, olderThan)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list stuck resources: %w", err)
+		}
+
+		response := map[string]interface{}{
+			"stuck": stuck,
+		}
+		if len(skipped) > 0 {
+			response["skippedKinds"] = skipped
+		}
+
+		jsonResponse, err := json.Marshal(response)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// RemoveFinalizers returns a handler function for the removeFinalizers tool.
+// It clears the finalizers of a terminating object, but only when confirm is set;
+// otherwise it reports the finalizers that would be removed. The result is serialized to JSON and returned.
+func RemoveFinalizers(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		kind, err := getRequiredStringArg(args, "kind")
+		if err != nil {
+			return nil, err
+		}
+
+		name, err := getRequiredStringArg(args, "name")
+		if err != nil {
+			return nil, err
+		}
+
+		namespace := getStringArg(args, "namespace", "")
+		confirm := getBoolArg(args, "confirm", false)
+
+		result, err := client.RemoveFinalizers(ctx, kind, name, namespace, !confirm)
+		if err != nil {
+			return nil, fmt.Errorf("failed to remove finalizers: %w", err)
+		}
+		if _, hasMessage := result["message"]; !confirm && !hasMessage {
+			result["message"] = "No finalizers were removed. Removing them skips whatever cleanup they guard; call again with confirm set to true to proceed."
+		}
+
+		jsonResponse, err := json.Marshal(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// AddFinalizer returns a handler function for the addFinalizer tool.
+// It adds a finalizer to an object. The result is serialized to JSON and returned.
+func AddFinalizer(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		kind, err := getRequiredStringArg(args, "kind")
+		if err != nil {
+			return nil, err
+		}
+
+		name, err := getRequiredStringArg(args, "name")
+		if err != nil {
+			return nil, err
+		}
+
+		finalizer, err := getRequiredStringArg(args, "finalizer")
+		if err != nil {
+			return nil, err
+		}
+
+		namespace := getStringArg(args, "namespace", "")
+
+		result, err := client.AddFinalizer(ctx, kind, name, namespace, finalizer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add finalizer: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// RemoveFinalizer returns a handler function for the removeFinalizer tool.
+// It removes a single finalizer from an object, but only when confirm is set;
+// otherwise it reports what the finalizers would become. The result is serialized to JSON and returned.
+func RemoveFinalizer(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		kind, err := getRequiredStringArg(args, "kind")
+		if err != nil {
+			return nil, err
+		}
+
+		name, err := getRequiredStringArg(args, "name")
+		if err != nil {
+			return nil, err
+		}
+
+		finalizer, err := getRequiredStringArg(args, "finalizer")
+		if err != nil {
+			return nil, err
+		}
+
+		namespace := getStringArg(args, "namespace", "")
+		confirm := getBoolArg(args, "confirm", false)
+
+		result, err := client.RemoveFinalizer(ctx, kind, name, namespace, finalizer, !confirm)
+		if err != nil {
+			return nil, fmt.Errorf("failed to remove finalizer: %w", err)
+		}
+		if !confirm {
+			result["message"] = fmt.Sprintf("Finalizer '%s' was not removed. Removing it skips the cleanup it guards; call again with confirm set to true to proceed.", finalizer)
+		}
+
+		jsonResponse, err := json.Marshal(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// GetConfigMapConsumers returns a handler function for the getConfigMapConsumers tool.
+// It lists a ConfigMap's keys and the workloads that use it. The result is serialized to JSON and returned.
+func GetConfigMapConsumers(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		name, err := getRequiredStringArg(args, "name")
+		if err != nil {
+			return nil, err
+		}
+
+		namespace := getStringArg(args, "namespace", "default")
+
+		result, err := client.GetConfigMapConsumers(ctx, namespace, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get configmap consumers: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// UpdateConfigAndRestart returns a handler function for the updateConfigAndRestart tool.
+// It updates a ConfigMap or Secret and restarts the workloads that consume it, but only
+// when confirm is set; otherwise it reports the planned changes. The result is serialized to JSON and returned.
+func UpdateConfigAndRestart(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		kind, err := getRequiredStringArg(args, "kind")
+		if err != nil {
+			return nil, err
+		}
+
+		name, err := getRequiredStringArg(args, "name")
+		if err != nil {
+			return nil, err
+		}
+
+		rawData, ok := args["data"].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("data parameter must be an object of key/value pairs")
+		}
+		data := make(map[string]string, len(rawData))
+		for key, value := range rawData {
+			if s, ok := value.(string); ok {
+				data[key] = s
+			} else {
+				data[key] = fmt.Sprint(value)
+			}
+		}
+
+		namespace := getStringArg(args, "namespace", "default")
+		replace := getBoolArg(args, "replace", false)
+		confirm := getBoolArg(args, "confirm", false)
+
+		result, err := client.UpdateConfigAndRestart(ctx, kind, namespace, name, data, replace, !confirm)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update %s '%s': %w", kind, name, err)
+		}
+		result["confirmed"] = confirm
+		if !confirm {
+			result["message"] = fmt.Sprintf("Nothing was changed. Call again with confirm set to true to update %s '%s' and restart its consumers.", kind, name)
+		}
+
+		jsonResponse, err := json.Marshal(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// PlanReconcile returns a handler function for the planReconcile tool.
+// It computes the creates, updates, and deletes that applying a bundle with pruning
+// would perform, without changing anything. The result is serialized to JSON and returned.
+func PlanReconcile(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		manifests, err := getRequiredStringArg(args, "manifests")
+		if err != nil {
+			return nil, err
+		}
+
+		pruneSelector, err := getRequiredStringArg(args, "pruneSelector")
+		if err != nil {
+			return nil, err
+		}
+
+		namespace := getStringArg(args, "namespace", "default")
+		pruneKinds := getStringListArg(args, "pruneKinds")
+
+		plan, err := client.PlanReconcile(ctx, namespace, manifests, pruneSelector, pruneKinds)
+		if err != nil {
+			return nil, fmt.Errorf("failed to plan reconcile: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(plan)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// RecommendResources returns a handler function for the recommendResources tool.
+// It compares a workload's configured requests and limits with its pods' current
+// usage and suggests adjusted values. The result is serialized to JSON and returned.
+func RecommendResources(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		kind, err := getRequiredStringArg(args, "kind")
+		if err != nil {
+			return nil, err
+		}
+
+		name, err := getRequiredStringArg(args, "name")
+		if err != nil {
+			return nil, err
+		}
+
+		namespace := getStringArg(args, "namespace", "default")
+
+		recommendations, err := client.RecommendResources(ctx, kind, name, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to recommend resources: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(recommendations)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// ListOOMKills returns a handler function for the listOOMKills tool.
+// It lists containers that were OOMKilled, with their memory limits.
+// The result is serialized to JSON and returned.
+func ListOOMKills(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		namespace := getNamespaceScopeArg(args)
+
+		kills, err := client.ListOOMKills(ctx, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list OOM kills: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(kills)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// ListImagePullErrors returns a handler function for the listImagePullErrors tool.
+// It lists images that cannot be pulled, with the registry error and affected workloads.
+// The result is serialized to JSON and returned.
+func ListImagePullErrors(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		namespace := getNamespaceScopeArg(args)
+
+		failures, err := client.ListImagePullErrors(ctx, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list image pull errors: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(failures)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// WatchEvents returns a handler function for the watchEvents tool.
+// It follows new events for the requested duration, optionally filtered by type and
+// involved object, and streams each one as a progress notification when the request
+// carries a progress token. The result is serialized to JSON and returned.
+func WatchEvents(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		filter := k8s.EventFilter{
+			Namespace:    getNamespaceScopeArg(args),
+			Type:         getStringArg(args, "type", ""),
+			InvolvedKind: getStringArg(args, "involvedObjectKind", ""),
+			InvolvedName: getStringArg(args, "involvedObjectName", ""),
+		}
+
+		watchFor, err := time.ParseDuration(getStringArg(args, "duration", "1m"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration: %w", err)
+		}
+		if watchFor <= 0 {
+			return nil, fmt.Errorf("duration must be positive")
+		}
+
+		progress := newProgressReporter(ctx, request)
+		seen := 0
+		onEvent := func(event map[string]interface{}) {
+			eventJSON, err := json.Marshal(event)
+			if err != nil {
+				return
+			}
+			seen++
+			progress.report(float64(seen), 0, string(eventJSON))
+		}
+
+		result, err := client.WatchEvents(ctx, filter, watchFor, onEvent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to watch events: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// ListWebhooks returns a handler function for the listWebhooks tool.
+// It lists the validating and mutating admission webhooks with their rules, failure
+// policies, and target services. The result is serialized to JSON and returned.
+func ListWebhooks(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		webhooks, err := client.ListWebhooks(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list webhooks: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(webhooks)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// SemanticDiff returns a handler function for the semanticDiff tool.
+// It compares a manifest with the live object field by field, reporting the fields
+// that would be added, removed, or changed. The result is serialized to JSON and returned.
+func SemanticDiff(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		manifest, err := getRequiredStringArg(args, "manifest")
+		if err != nil {
+			return nil, err
+		}
+
+		namespace := getStringArg(args, "namespace", "")
+		kind := getStringArg(args, "kind", "")
+		reveal := getBoolArg(args, "reveal", false)
+
+		result, err := client.SemanticDiff(ctx, namespace, manifest, kind, reveal)
+		if err != nil {
+			return nil, fmt.Errorf("failed to diff resource: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// DeployAndVerify returns a handler function for the deployAndVerify tool.
+// It applies a workload manifest, follows its rollout, and on failure or timeout
+// collects the logs and events of the failing pods. Rollout updates are streamed as
+// progress notifications when the request carries a progress token.
+// The result is serialized to JSON and returned.
+func DeployAndVerify(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		manifest, err := getRequiredStringArg(args, "manifest")
+		if err != nil {
+			return nil, err
+		}
+
+		namespace := getStringArg(args, "namespace", "")
+		kind := getStringArg(args, "kind", "")
+
+		timeout, err := time.ParseDuration(getStringArg(args, "timeout", "5m"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout: %w", err)
+		}
+		if timeout <= 0 {
+			return nil, fmt.Errorf("timeout must be positive")
+		}
+
+		if err := client.CheckQuotaHeadroom(ctx, namespace, manifest, kind); err != nil {
+			return nil, err
+		}
+
+		progress := newProgressReporter(ctx, request)
+		updates := 0
+		onUpdate := func(update map[string]interface{}) {
+			updateJSON, err := json.Marshal(update)
+			if err != nil {
+				return
+			}
+			updates++
+			progress.report(float64(updates), 0, string(updateJSON))
+		}
+
+		result, err := client.DeployAndVerify(ctx, namespace, manifest, kind, timeout, onUpdate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to deploy and verify: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// AnalyzeDeletionImpact returns a handler function for the analyzeDeletionImpact tool.
+// It reports what deleting a resource would cascade to and what refers to it, without
+// deleting anything. The result is serialized to JSON and returned.
+func AnalyzeDeletionImpact(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		kind, err := getRequiredStringArg(args, "kind")
+		if err != nil {
+			return nil, err
+		}
+
+		name, err := getRequiredStringArg(args, "name")
+		if err != nil {
+			return nil, err
+		}
+
+		namespace := getStringArg(args, "namespace", "")
+
+		impact, err := client.AnalyzeDeletionImpact(ctx, kind, name, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to analyze deletion impact: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(impact)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// SuspendResource returns a handler function for the suspendResource tool.
+// It sets the configured GitOps suspend annotations on an object so that its
+// controllers stop reverting manual changes. The result is serialized to JSON and returned.
+func SuspendResource(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		kind, err := getRequiredStringArg(args, "kind")
+		if err != nil {
+			return nil, err
+		}
+
+		name, err := getRequiredStringArg(args, "name")
+		if err != nil {
+			return nil, err
+		}
+
+		namespace := getStringArg(args, "namespace", "")
+
+		result, err := client.SuspendResource(ctx, kind, name, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to suspend resource: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// ResumeResource returns a handler function for the resumeResource tool.
+// It removes the GitOps suspend annotations from an object, handing it back to its
+// controllers. The result is serialized to JSON and returned.
+func ResumeResource(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		kind, err := getRequiredStringArg(args, "kind")
+		if err != nil {
+			return nil, err
+		}
+
+		name, err := getRequiredStringArg(args, "name")
+		if err != nil {
+			return nil, err
+		}
+
+		namespace := getStringArg(args, "namespace", "")
+
+		result, err := client.ResumeResource(ctx, kind, name, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resume resource: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// GetClusterOverview returns a handler function for the clusterOverview tool.
+// It gathers node, pod, workload, warning, and top-consumer summaries in one call.
+// The result is serialized to JSON and returned.
+func GetClusterOverview(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		overview, err := client.GetClusterOverview(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get cluster overview: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(overview)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// CheckSchedulability returns a handler function for the diagnoseScheduling tool.
+// It checks a pending pod against every node and reports why it cannot be scheduled.
+// The result is serialized to JSON and returned.
+func CheckSchedulability(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		podName, err := getRequiredStringArg(args, "podName")
+		if err != nil {
+			return nil, err
+		}
+
+		namespace := getStringArg(args, "namespace", "default")
+
+		diagnosis, err := client.CheckSchedulability(ctx, namespace, podName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check schedulability: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(diagnosis)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// SnapshotResource returns a handler function for the snapshotResource tool.
+// It captures an object's current state in memory for a later diffSnapshot.
+// The result is serialized to JSON and returned.
+func SnapshotResource(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		kind, err := getRequiredStringArg(args, "kind")
+		if err != nil {
+			return nil, err
+		}
+
+		name, err := getRequiredStringArg(args, "name")
+		if err != nil {
+			return nil, err
+		}
+
+		namespace := getStringArg(args, "namespace", "")
+
+		snapshot, err := client.SnapshotResource(ctx, kind, name, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to snapshot resource: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(snapshot)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// DiffSnapshot returns a handler function for the diffSnapshot tool.
+// It compares an object's current state with a snapshot taken by snapshotResource.
+// The result is serialized to JSON and returned.
+func DiffSnapshot(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		snapshotID, err := getRequiredStringArg(args, "snapshotId")
+		if err != nil {
+			return nil, err
+		}
+
+		includeStatus := getBoolArg(args, "includeStatus", false)
+		reveal := getBoolArg(args, "reveal", false)
+
+		diff, err := client.DiffSnapshot(ctx, snapshotID, includeStatus, reveal)
+		if err != nil {
+			return nil, fmt.Errorf("failed to diff snapshot: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(diff)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// GetImageDetails returns a handler function for the getImageDetails tool.
+// It reports each container's image, pull policy, and the digests its pods run.
+// The result is serialized to JSON and returned.
+func GetImageDetails(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		kind, err := getRequiredStringArg(args, "kind")
+		if err != nil {
+			return nil, err
+		}
+
+		name, err := getRequiredStringArg(args, "name")
+		if err != nil {
+			return nil, err
+		}
+
+		namespace := getStringArg(args, "namespace", "default")
+
+		details, err := client.GetImageDetails(ctx, kind, name, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get image details: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(details)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// DiagnosePod returns a handler function for the diagnosePod tool.
+// It gathers a pod's status, container states, events, logs, metrics, and owner.
+// The result is serialized to JSON and returned.
+func DiagnosePod(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		podName, err := getRequiredStringArg(args, "podName")
+		if err != nil {
+			return nil, err
+		}
+
+		namespace := getStringArg(args, "namespace", "default")
+
+		diagnosis, err := client.DiagnosePod(ctx, namespace, podName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to diagnose pod: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(diagnosis)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// CheckCertificates returns a handler function for the checkCertificates tool.
+// It reports the expiry of the TLS certificates in Secrets and those used by Ingresses.
+// The result is serialized to JSON and returned.
+func CheckCertificates(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		namespace := getNamespaceScopeArg(args)
+		warnDays := getIntArg(args, "warnDays", k8s.DefaultCertificateWarnDays)
+		if warnDays < 0 {
+			return nil, fmt.Errorf("warnDays must not be negative")
+		}
+
+		report, err := client.CheckCertificates(ctx, namespace, warnDays)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check certificates: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(report)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// RollbackImage returns a handler function for the rollbackImage tool.
+// It sets a Deployment's container images back to those of an earlier revision.
+// The result is serialized to JSON and returned.
+func RollbackImage(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		name, err := getRequiredStringArg(args, "name")
+		if err != nil {
+			return nil, err
+		}
+
+		namespace := getStringArg(args, "namespace", "default")
+		container := getStringArg(args, "container", "")
+		toRevision := getIntArg(args, "toRevision", 0)
+		if toRevision < 0 {
+			return nil, fmt.Errorf("toRevision must be a positive revision number")
+		}
+		confirm := getBoolArg(args, "confirm", false)
+
+		result, err := client.RollbackImage(ctx, namespace, name, container, int64(toRevision), !confirm)
+		if err != nil {
+			return nil, fmt.Errorf("failed to roll back image of deployment '%s': %w", name, err)
+		}
+		result["confirmed"] = confirm
+		if !confirm {
+			result["message"] = fmt.Sprintf("Nothing was changed. Call again with confirm set to true to set the images of deployment '%s' back to revision %d.", name, result["toRevision"])
+		}
+
+		jsonResponse, err := json.Marshal(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// FindConsumers returns a handler function for the findConsumers tool.
+// It finds the workloads that reference a ConfigMap, Secret, PersistentVolumeClaim, or ServiceAccount.
+// The result is serialized to JSON and returned.
+func FindConsumers(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		kind, err := getRequiredStringArg(args, "kind")
+		if err != nil {
+			return nil, err
+		}
+
+		name, err := getRequiredStringArg(args, "name")
+		if err != nil {
+			return nil, err
+		}
+
+		namespace := getStringArg(args, "namespace", "default")
+
+		result, err := client.FindResourceConsumers(ctx, namespace, kind, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find consumers of %s '%s': %w", kind, name, err)
+		}
+
+		jsonResponse, err := json.Marshal(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// GetStateMetrics returns a handler function for the getStateMetrics tool.
+// It computes kube-state-metrics style gauges for pods, deployments, and nodes.
+// The result is returned as JSON or in the Prometheus text exposition format.
+func GetStateMetrics(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		namespace := getStringArg(args, "namespace", "")
+		format := getStringArg(args, "format", "json")
+		if format != "json" && format != "prometheus" {
+			return nil, fmt.Errorf("invalid format '%s': expected json or prometheus", format)
+		}
+
+		metrics, err := client.GetStateMetrics(ctx, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute state metrics: %w", err)
+		}
+
+		if format == "prometheus" {
+			return mcp.NewToolResultText(k8s.FormatPrometheus(metrics)), nil
+		}
+
+		jsonResponse, err := json.Marshal(metrics)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// NodeDiagnostics returns a handler function for the nodeDiagnostics tool.
+// It gathers a node's conditions, pressure, capacity and usage, pod count, and events.
+// The result is serialized to JSON and returned.
+func NodeDiagnostics(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		nodeName, err := getRequiredStringArg(args, "nodeName")
+		if err != nil {
+			return nil, err
+		}
+
+		diagnosis, err := client.DiagnoseNode(ctx, nodeName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to diagnose node: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(diagnosis)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// CollectIncidentLogs returns a handler function for the collectIncidentLogs tool.
+// It gathers the recent logs of every pod matching a namespace and label selector.
+// The result is serialized to JSON and returned.
+func CollectIncidentLogs(client *k8s.Client) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		since, err := time.ParseDuration(getStringArg(args, "since", "10m"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid since: %w", err)
+		}
+		if since <= 0 {
+			return nil, fmt.Errorf("since must be positive")
+		}
+
+		opts := k8s.IncidentLogOptions{
+			Namespace:     getStringArg(args, "namespace", ""),
+			LabelSelector: getStringArg(args, "labelSelector", ""),
+			Since:         since,
+			MaxLines:      int64(getIntArg(args, "maxLinesPerContainer", int(k8s.DefaultIncidentLogLines))),
+			MaxBytes:      int64(getIntArg(args, "maxBytesPerContainer", int(k8s.DefaultIncidentLogBytes))),
+			MaxPods:       getIntArg(args, "maxPods", k8s.DefaultIncidentLogPods),
+			Parallelism:   getIntArg(args, "parallelism", k8s.DefaultIncidentParallelism),
+		}
+
+		logs, err := client.CollectIncidentLogs(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to collect incident logs: %w", err)
+		}
+
+		jsonResponse, err := json.Marshal(logs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
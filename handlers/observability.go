@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/reza-gholizade/k8s-mcp-server/pkg/k8s"
+	"github.com/reza-gholizade/k8s-mcp-server/pkg/observability"
+)
+
+// QueryMetrics returns a handler function for the queryMetrics tool.
+// It runs a PromQL query against the configured Prometheus, as an instant query
+// or, when start is given, as a range query. The result is serialized to JSON and returned.
+func QueryMetrics(client *observability.PrometheusClient) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		query, err := getRequiredStringArg(args, "query")
+		if err != nil {
+			return nil, err
+		}
+
+		now := time.Now()
+		start, err := k8s.ParseTimeBound(getStringArg(args, "start", ""), now)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start: %w", err)
+		}
+		end, err := k8s.ParseTimeBound(getStringArg(args, "end", ""), now)
+		if err != nil {
+			return nil, fmt.Errorf("invalid end: %w", err)
+		}
+
+		var step time.Duration
+		if stepStr := getStringArg(args, "step", ""); stepStr != "" {
+			if step, err = time.ParseDuration(stepStr); err != nil {
+				return nil, fmt.Errorf("invalid step '%s': %w", stepStr, err)
+			}
+		}
+
+		result, err := client.QueryPrometheus(ctx, query, start, end, step)
+		if err != nil {
+			return nil, err
+		}
+
+		jsonResponse, err := json.Marshal(result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// QueryLogs returns a handler function for the queryLogs tool.
+// It runs a LogQL query against the configured Loki over the requested time range.
+// The result is serialized to JSON and returned.
+func QueryLogs(client *observability.LokiClient) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		query, err := getRequiredStringArg(args, "query")
+		if err != nil {
+			return nil, err
+		}
+
+		now := time.Now()
+		start, err := k8s.ParseTimeBound(getStringArg(args, "start", ""), now)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start: %w", err)
+		}
+		end, err := k8s.ParseTimeBound(getStringArg(args, "end", ""), now)
+		if err != nil {
+			return nil, fmt.Errorf("invalid end: %w", err)
+		}
+
+		limit := getIntArg(args, "limit", 100)
+
+		lines, err := client.QueryLogs(ctx, query, start, end, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		jsonResponse, err := json.Marshal(lines)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
+
+// GetAlerts returns a handler function for the getAlerts tool.
+// It retrieves the alerts currently firing in the configured Alertmanager,
+// optionally filtered by label matchers. The result is serialized to JSON and returned.
+func GetAlerts(client *observability.AlertmanagerClient) func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		args, ok := request.Params.Arguments.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid arguments type: expected map[string]interface{}")
+		}
+
+		matchers := getStringListArg(args, "filter")
+		includeSuppressed := getBoolArg(args, "includeSuppressed", false)
+
+		alerts, err := client.GetActiveAlerts(ctx, matchers, includeSuppressed)
+		if err != nil {
+			return nil, err
+		}
+
+		jsonResponse, err := json.Marshal(alerts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize response: %w", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResponse)), nil
+	}
+}
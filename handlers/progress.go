@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// progressReporter sends MCP progress notifications for a tool call. Notifications
+// are only sent when the client supplied a progress token with the request.
+type progressReporter struct {
+	ctx    context.Context
+	server *server.MCPServer
+	token  mcp.ProgressToken
+}
+
+// newProgressReporter returns a reporter for the request, or nil if the client did
+// not ask for progress. A nil reporter is safe to use and reports nothing.
+func newProgressReporter(ctx context.Context, request mcp.CallToolRequest) *progressReporter {
+	if request.Params.Meta == nil || request.Params.Meta.ProgressToken == nil {
+		return nil
+	}
+	srv := server.ServerFromContext(ctx)
+	if srv == nil {
+		return nil
+	}
+	return &progressReporter{ctx: ctx, server: srv, token: request.Params.Meta.ProgressToken}
+}
+
+// enabled reports whether progress notifications will be delivered.
+func (p *progressReporter) enabled() bool {
+	return p != nil
+}
+
+// report sends a progress notification. A zero total means the total is unknown.
+// Delivery failures are ignored: progress is best-effort.
+func (p *progressReporter) report(progress, total float64, message string) {
+	if p == nil {
+		return
+	}
+	params := map[string]any{
+		"progressToken": p.token,
+		"progress":      progress,
+	}
+	if total > 0 {
+		params["total"] = total
+	}
+	if message != "" {
+		params["message"] = message
+	}
+	_ = p.server.SendNotificationToClient(p.ctx, "notifications/progress", params)
+}
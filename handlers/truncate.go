@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// TruncateResponses returns a middleware that caps the text returned by a tool call
+// at maxBytes, replacing the remainder with a marker stating how much was omitted.
+// Calls that pass full=true are returned untouched.
+func TruncateResponses(maxBytes int) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			result, err := next(ctx, request)
+			if err != nil || result == nil || maxBytes <= 0 {
+				return result, err
+			}
+			if args, ok := request.Params.Arguments.(map[string]interface{}); ok && getBoolArg(args, "full", false) {
+				return result, err
+			}
+
+			// Truncate a copy, since the result may be shared, e.g. cached by IdempotentCalls
+			truncated := *result
+			truncated.Content = slices.Clone(result.Content)
+			result = &truncated
+			remaining := maxBytes
+			for i, content := range result.Content {
+				text, ok := content.(mcp.TextContent)
+				if !ok {
+					continue
+				}
+				if len(text.Text) <= remaining {
+					remaining -= len(text.Text)
+					continue
+				}
+				text.Text = truncateText(text.Text, remaining)
+				result.Content[i] = text
+				remaining = 0
+			}
+			return result, err
+		}
+	}
+}
+
+// truncateText cuts text to at most limit bytes without splitting a UTF-8 sequence
+// and appends a marker with the number of bytes omitted.
+func truncateText(text string, limit int) string {
+	cut := limit
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	return fmt.Sprintf("%s\n[truncated, %d bytes omitted; call again with full=true for the complete response]", text[:cut], len(text)-cut)
+}
@@ -10,15 +10,26 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"net/http"
 	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
 
 	"github.com/reza-gholizade/k8s-mcp-server/handlers"
+	"github.com/reza-gholizade/k8s-mcp-server/pkg/auth"
 	"github.com/reza-gholizade/k8s-mcp-server/pkg/helm"
 	"github.com/reza-gholizade/k8s-mcp-server/pkg/k8s"
+	"github.com/reza-gholizade/k8s-mcp-server/pkg/notify"
+	"github.com/reza-gholizade/k8s-mcp-server/pkg/observability"
 	"github.com/reza-gholizade/k8s-mcp-server/tools"
 
+	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
@@ -31,14 +42,70 @@ func main() {
 	var readOnly bool
 	var noK8s bool
 	var noHelm bool
+	var stateful bool
+	var sseBasePath string
+	var sseEndpoint string
+	var sseMessageEndpoint string
+	var sseBaseURL string
+	var authTokens string
+	var maskFields string
+	var prometheusURL string
+	var prometheusToken string
+	var lokiURL string
+	var lokiToken string
+	var lokiOrgID string
+	var alertmanagerURL string
+	var alertmanagerToken string
+	var notifyWebhook string
+	var maxResponseBytes int
+	var idempotencyTTL time.Duration
+	var authMethodName string
+	var registryConfig string
+	var suspendAnnotations string
+	var kindAliasesFile string
+	var watchKubeconfig bool
+	var drainTimeout time.Duration
+	var drainEndpoint bool
 
 	flag.StringVar(&port, "port", getEnvOrDefault("SERVER_PORT", "8080"), "Server port")
 	flag.StringVar(&mode, "mode", getEnvOrDefault("SERVER_MODE", "sse"), "Server mode: 'stdio', 'sse', or 'streamable-http'")
 	flag.BoolVar(&readOnly, "read-only", false, "Enable read-only mode (disables write operations)")
 	flag.BoolVar(&noK8s, "no-k8s", false, "Disable Kubernetes tools")
 	flag.BoolVar(&noHelm, "no-helm", false, "Disable Helm tools")
+	flag.BoolVar(&stateful, "stateful", getEnvOrDefault("SERVER_STATEFUL", "false") == "true", "Enable stateful sessions for streamable-http mode (required for subscriptions and watches)")
+	flag.StringVar(&sseBasePath, "sse-base-path", getEnvOrDefault("SSE_BASE_PATH", ""), "Base path the SSE endpoints are mounted under (e.g. '/mcp' when served behind a reverse proxy)")
+	flag.StringVar(&sseEndpoint, "sse-endpoint", getEnvOrDefault("SSE_ENDPOINT", "/sse"), "Path of the SSE endpoint, relative to the base path")
+	flag.StringVar(&sseMessageEndpoint, "sse-message-endpoint", getEnvOrDefault("SSE_MESSAGE_ENDPOINT", "/message"), "Path of the SSE message endpoint, relative to the base path")
+	flag.StringVar(&sseBaseURL, "sse-base-url", getEnvOrDefault("SSE_BASE_URL", ""), "Public base URL advertised to SSE clients (e.g. 'https://example.com')")
+	flag.StringVar(&authTokens, "auth-tokens", getEnvOrDefault("MCP_AUTH_TOKENS", ""), "Comma-separated bearer tokens with scopes for HTTP modes, e.g. 'reader-token:read,admin-token:write'")
+	flag.StringVar(&maskFields, "mask-fields", getEnvOrDefault("MASK_FIELDS", ""), "Additional comma-separated 'Kind:path' fields to redact in get/list/describe output, e.g. 'ConfigMap:data,*:metadata.annotations[example.com/token]'")
+	flag.StringVar(&prometheusURL, "prometheus-url", getEnvOrDefault("PROMETHEUS_URL", ""), "Prometheus base URL; enables the queryMetrics tool when set")
+	flag.StringVar(&prometheusToken, "prometheus-token", getEnvOrDefault("PROMETHEUS_TOKEN", ""), "Bearer token for Prometheus")
+	flag.StringVar(&lokiURL, "loki-url", getEnvOrDefault("LOKI_URL", ""), "Loki base URL; enables the queryLogs tool when set")
+	flag.StringVar(&lokiToken, "loki-token", getEnvOrDefault("LOKI_TOKEN", ""), "Bearer token for Loki")
+	flag.StringVar(&lokiOrgID, "loki-org-id", getEnvOrDefault("LOKI_ORG_ID", ""), "Tenant ID sent to multi-tenant Loki as X-Scope-OrgID")
+	flag.StringVar(&alertmanagerURL, "alertmanager-url", getEnvOrDefault("ALERTMANAGER_URL", ""), "Alertmanager base URL; enables the getAlerts tool when set")
+	flag.StringVar(&alertmanagerToken, "alertmanager-token", getEnvOrDefault("ALERTMANAGER_TOKEN", ""), "Bearer token for Alertmanager")
+	flag.StringVar(&notifyWebhook, "notify-webhook", getEnvOrDefault("NOTIFY_WEBHOOK", ""), "URL to POST a JSON notification to after each mutating operation (e.g. a Slack or Teams incoming webhook)")
+	flag.IntVar(&maxResponseBytes, "max-response-bytes", getEnvIntOrDefault("MAX_RESPONSE_BYTES", 0), "Truncate tool responses larger than this many bytes unless the call passes full=true (0 disables truncation)")
+	flag.DurationVar(&idempotencyTTL, "idempotency-ttl", getEnvDurationOrDefault("IDEMPOTENCY_TTL", 10*time.Minute), "How long results of mutating calls made with an idempotencyKey are kept for replay (0 disables deduplication)")
+	flag.StringVar(&authMethodName, "auth-method", getEnvOrDefault("KUBERNETES_AUTH_METHOD", "auto"), "Kubernetes authentication method: 'auto' (first available of the others, in this order), 'kubeconfig-data', 'server-token', 'in-cluster', or 'kubeconfig-file'")
+	flag.StringVar(&suspendAnnotations, "suspend-annotations", getEnvOrDefault("SUSPEND_ANNOTATIONS", ""), "Comma-separated 'key=value' annotations suspendResource sets to pause GitOps reconciliation, replacing the Flux and Argo CD defaults")
+	flag.StringVar(&kindAliasesFile, "kind-aliases", getEnvOrDefault("KIND_ALIASES_FILE", ""), "Path to a YAML or JSON file mapping shorthand names to kinds (e.g. 'deploy: Deployment'), accepted wherever a resource kind is expected")
+	flag.StringVar(&registryConfig, "registry-config", getEnvOrDefault("HELM_REGISTRY_CONFIG", ""), "Path to the OCI registry credentials file used by Helm (defaults to Helm's standard location, e.g. ~/.config/helm/registry/config.json)")
+	flag.BoolVar(&watchKubeconfig, "watch-kubeconfig", getEnvOrDefault("WATCH_KUBECONFIG", "false") == "true", "Reload the Kubernetes and Helm clients when the kubeconfig file changes (e.g. after credential rotation or a context switch)")
+	flag.DurationVar(&drainTimeout, "drain-timeout", getEnvDurationOrDefault("DRAIN_TIMEOUT", 30*time.Second), "How long to wait for tool calls in flight to finish when draining on SIGTERM or SIGINT before the server stops")
+	flag.BoolVar(&drainEndpoint, "drain-endpoint", getEnvOrDefault("DRAIN_ENDPOINT", "false") == "true", "Serve POST /drain in the HTTP modes to start draining from localhost, e.g. from a preStop hook; requires a write token when --auth-tokens is set")
 	flag.Parse()
 
+	// In stdio mode stdout carries the JSON-RPC stream, and any other write to it
+	// corrupts the protocol framing. Keep the real stdout for the transport and send
+	// all other output, including anything printed by dependencies, to stderr.
+	protocolOut := os.Stdout
+	if mode == "stdio" {
+		os.Stdout = os.Stderr
+	}
+
 	// Validate flag combinations
 	if noK8s && noHelm {
 		fmt.Println("Error: Cannot disable both Kubernetes and Helm tools. At least one tool category must be enabled.")
@@ -58,26 +125,148 @@ func main() {
 		fmt.Println("Helm tools disabled")
 	}
 
+	// Reject new tool calls once draining; this runs before every other middleware
+	drainer := handlers.NewDrainer()
+	serverOptions := []server.ServerOption{
+		server.WithResourceCapabilities(true, true), // Enable resource listing and subscription capabilities
+		server.WithToolHandlerMiddleware(drainer.ToolMiddleware()),
+	}
+
+	// Middlewares resolve tool annotations through the server once it is created
+	var s *server.MCPServer
+	lookupTool := func(name string) (mcp.Tool, bool) {
+		if tool := s.GetTool(name); tool != nil {
+			return tool.Tool, true
+		}
+		return mcp.Tool{}, false
+	}
+
+	// Configure per-token scopes: read tokens may only call read-only tools
+	var tokenStore auth.TokenStore
+	if authTokens != "" {
+		if mode == "stdio" {
+			fmt.Println("Warning: --auth-tokens is ignored in stdio mode")
+		} else {
+			var err error
+			tokenStore, err = auth.ParseTokens(authTokens)
+			if err != nil {
+				fmt.Printf("Error: invalid --auth-tokens: %v\n", err)
+				os.Exit(1)
+			}
+			serverOptions = append(serverOptions,
+				server.WithToolHandlerMiddleware(auth.ToolMiddleware(lookupTool)),
+				server.WithToolFilter(auth.ToolFilter),
+			)
+			fmt.Println("Token authentication enabled")
+		}
+	}
+
+	// Truncate oversized responses to protect the client's context budget; this
+	// runs outside deduplication so that complete results are replayed
+	if maxResponseBytes > 0 {
+		serverOptions = append(serverOptions, server.WithToolHandlerMiddleware(handlers.TruncateResponses(maxResponseBytes)))
+	}
+
+	// Replay the result of a retried mutating call instead of executing it twice
+	if idempotencyTTL > 0 {
+		// Stateless streamable-http sessions are not issued by the server, so they do
+		// not tell clients apart
+		trustSessions := mode != "streamable-http" || stateful
+		serverOptions = append(serverOptions, server.WithToolHandlerMiddleware(handlers.IdempotentCalls(idempotencyTTL, trustSessions, lookupTool)))
+	}
+
+	// Notify a webhook about mutating operations
+	if notifyWebhook != "" {
+		notifier := notify.NewWebhookNotifier(notifyWebhook)
+		serverOptions = append(serverOptions, server.WithToolHandlerMiddleware(notifier.ToolMiddleware(lookupTool)))
+		fmt.Println("Webhook notifications enabled for mutating operations")
+	}
+
 	// Create MCP server
-	s := server.NewMCPServer(
+	s = server.NewMCPServer(
 		"MCP K8S & Helm Server",
 		"1.0.0",
-		server.WithResourceCapabilities(true, true), // Enable resource listing and subscription capabilities
+		serverOptions...,
 	)
 
+	authMethod, err := k8s.ParseAuthMethod(authMethodName)
+	if err != nil {
+		fmt.Printf("Error: invalid --auth-method: %v\n", err)
+		os.Exit(1)
+	}
+
 	// Create a Kubernetes client
-	client, err := k8s.NewClient("")
+	client, err := k8s.NewClient("", authMethod)
 	if err != nil {
 		fmt.Printf("Failed to create Kubernetes client: %v\n", err)
 		return
 	}
 
+	// Configure additional sensitive fields to mask in resource output
+	if maskFields != "" {
+		maskRules, err := k8s.ParseMaskRules(maskFields)
+		if err != nil {
+			fmt.Printf("Error: invalid --mask-fields: %v\n", err)
+			os.Exit(1)
+		}
+		client.SetMaskRules(maskRules)
+	}
+
+	// Configure the annotations used to pause GitOps reconciliation
+	if suspendAnnotations != "" {
+		annotations, err := k8s.ParseSuspendAnnotations(suspendAnnotations)
+		if err != nil {
+			fmt.Printf("Error: invalid --suspend-annotations: %v\n", err)
+			os.Exit(1)
+		}
+		client.SetSuspendAnnotations(annotations)
+	}
+
+	// Configure shorthand names for resource kinds
+	if kindAliasesFile != "" {
+		aliases, err := k8s.LoadKindAliases(kindAliasesFile)
+		if err != nil {
+			fmt.Printf("Error: invalid --kind-aliases: %v\n", err)
+			os.Exit(1)
+		}
+		client.SetKindAliases(aliases)
+	}
+
 	// Create Helm client with default kubeconfig path
-	helmClient, err := helm.NewClient("")
+	helmClient, err := helm.NewClient("", authMethod, registryConfig)
 	if err != nil {
 		fmt.Printf("Failed to create Helm client: %v\n", err)
 		return
 	}
+	// Discover the API once for both clients
+	helmClient.ShareDiscovery(client.DiscoveryClient(), client.RESTMapper())
+
+	// Rebuild the clients when the kubeconfig file is rewritten
+	if watchKubeconfig {
+		kubeconfigPath := k8s.ResolveKubeconfigPath("")
+		if authMethod != k8s.AuthMethodAuto && authMethod != k8s.AuthMethodKubeconfigFile {
+			fmt.Printf("Warning: --watch-kubeconfig has no effect with --auth-method %s\n", authMethod)
+		}
+		go k8s.WatchKubeconfig(context.Background(), kubeconfigPath, func() {
+			// Load both clients before switching either, so that they never point at
+			// different clusters
+			applyK8s, err := client.PrepareReload("", authMethod)
+			if err != nil {
+				fmt.Printf("Failed to reload Kubernetes client after kubeconfig change: %v\n", err)
+				return
+			}
+			applyHelm, err := helmClient.PrepareReload("", authMethod)
+			if err != nil {
+				fmt.Printf("Failed to reload Helm client after kubeconfig change: %v\n", err)
+				return
+			}
+			applyK8s()
+			applyHelm()
+			helmClient.ShareDiscovery(client.DiscoveryClient(), client.RESTMapper())
+			fmt.Printf("Reloaded kubeconfig %s\n", kubeconfigPath)
+		})
+		fmt.Printf("Watching kubeconfig %s for changes\n", kubeconfigPath)
+	}
 
 	// Register Kubernetes tools
 	if !noK8s {
@@ -90,6 +279,43 @@ func main() {
 		s.AddTool(tools.GetPodMetricsTool(), handlers.GetPodMetrics(client))
 		s.AddTool(tools.GetEventsTool(), handlers.GetEvents(client))
 		s.AddTool(tools.GetIngressesTool(), handlers.GetIngresses(client))
+		s.AddTool(tools.GetClusterCapacityTool(), handlers.GetClusterCapacity(client))
+		s.AddTool(tools.GetControlPlaneHealthTool(), handlers.GetControlPlaneHealth(client))
+		s.AddTool(tools.GetStorageTool(), handlers.GetStorage(client))
+		s.AddTool(tools.ResolveSelectorTool(), handlers.ResolveSelector(client))
+		s.AddTool(tools.GetWorkloadReadinessTool(), handlers.GetWorkloadReadiness(client))
+		s.AddTool(tools.GetPodEnvTool(), handlers.GetPodEnv(client))
+		s.AddTool(tools.DiagnoseAlertTool(), handlers.DiagnoseAlert(client))
+		s.AddTool(tools.ExportNamespaceTool(), handlers.ExportNamespace(client))
+		s.AddTool(tools.GetPodOwnerTool(), handlers.GetPodOwner(client))
+		s.AddTool(tools.DryRunResourceTool(), handlers.DryRunResource(client))
+		s.AddTool(tools.GetClusterWarningsTool(), handlers.GetClusterWarnings(client))
+		s.AddTool(tools.GetMergedLogsTool(), handlers.GetMergedLogs(client))
+		s.AddTool(tools.GetTerminationDetailsTool(), handlers.GetTerminationDetails(client))
+		s.AddTool(tools.GetResourceBundleTool(), handlers.GetResourceBundle(client))
+		s.AddTool(tools.GetRBACTool(), handlers.GetRBAC(client))
+		s.AddTool(tools.ListStuckResourcesTool(), handlers.ListStuckResources(client))
+		s.AddTool(tools.GetConfigMapConsumersTool(), handlers.GetConfigMapConsumers(client))
+		s.AddTool(tools.PlanReconcileTool(), handlers.PlanReconcile(client))
+		s.AddTool(tools.RecommendResourcesTool(), handlers.RecommendResources(client))
+		s.AddTool(tools.ListOOMKillsTool(), handlers.ListOOMKills(client))
+		s.AddTool(tools.ListImagePullErrorsTool(), handlers.ListImagePullErrors(client))
+		s.AddTool(tools.WatchEventsTool(), handlers.WatchEvents(client))
+		s.AddTool(tools.ListWebhooksTool(), handlers.ListWebhooks(client))
+		s.AddTool(tools.SemanticDiffTool(), handlers.SemanticDiff(client))
+		s.AddTool(tools.RolloutProgressTool(), handlers.GetRolloutProgress(client))
+		s.AddTool(tools.AnalyzeDeletionImpactTool(), handlers.AnalyzeDeletionImpact(client))
+		s.AddTool(tools.ClusterOverviewTool(), handlers.GetClusterOverview(client))
+		s.AddTool(tools.DiagnoseSchedulingTool(), handlers.CheckSchedulability(client))
+		s.AddTool(tools.SnapshotResourceTool(), handlers.SnapshotResource(client))
+		s.AddTool(tools.DiffSnapshotTool(), handlers.DiffSnapshot(client))
+		s.AddTool(tools.GetImageDetailsTool(), handlers.GetImageDetails(client))
+		s.AddTool(tools.DiagnosePodTool(), handlers.DiagnosePod(client))
+		s.AddTool(tools.CheckCertificatesTool(), handlers.CheckCertificates(client))
+		s.AddTool(tools.FindConsumersTool(), handlers.FindConsumers(client))
+		s.AddTool(tools.GetStateMetricsTool(), handlers.GetStateMetrics(client))
+		s.AddTool(tools.NodeDiagnosticsTool(), handlers.NodeDiagnostics(client))
+		s.AddTool(tools.CollectIncidentLogsTool(), handlers.CollectIncidentLogs(client))
 
 		// Register write operations only if not in read-only mode
 		if !readOnly {
@@ -97,6 +323,24 @@ func main() {
 			s.AddTool(tools.CreateOrUpdateResourceYAMLTool(), handlers.CreateOrUpdateResourceYAML(client))
 			s.AddTool(tools.DeleteResourceTool(), handlers.DeleteResource(client))
 			s.AddTool(tools.RolloutRestartTool(), handlers.RolloutRestart(client))
+			s.AddTool(tools.RunJobAndWaitTool(), handlers.RunJobAndWait(client))
+			s.AddTool(tools.EvictPodTool(), handlers.EvictPod(client))
+			s.AddTool(tools.ScaleResourceTool(), handlers.ScaleResource(client))
+			s.AddTool(tools.ApplyAndPruneTool(), handlers.ApplyAndPrune(client))
+			s.AddTool(tools.CreateFromTemplateTool(), handlers.CreateFromTemplate(client))
+			s.AddTool(tools.CloneResourceTool(), handlers.CloneResource(client))
+			s.AddTool(tools.WatchRolloutTool(), handlers.WatchRollout(client))
+			s.AddTool(tools.RestartNamespaceTool(), handlers.RestartNamespace(client))
+			s.AddTool(tools.TestDNSTool(), handlers.TestDNS(client))
+			s.AddTool(tools.TestConnectivityTool(), handlers.TestConnectivity(client))
+			s.AddTool(tools.RemoveFinalizersTool(), handlers.RemoveFinalizers(client))
+			s.AddTool(tools.AddFinalizerTool(), handlers.AddFinalizer(client))
+			s.AddTool(tools.RemoveFinalizerTool(), handlers.RemoveFinalizer(client))
+			s.AddTool(tools.UpdateConfigAndRestartTool(), handlers.UpdateConfigAndRestart(client))
+			s.AddTool(tools.DeployAndVerifyTool(), handlers.DeployAndVerify(client))
+			s.AddTool(tools.SuspendResourceTool(), handlers.SuspendResource(client))
+			s.AddTool(tools.ResumeResourceTool(), handlers.ResumeResource(client))
+			s.AddTool(tools.RollbackImageTool(), handlers.RollbackImage(client))
 		}
 	}
 
@@ -106,6 +350,13 @@ func main() {
 		s.AddTool(tools.HelmGetTool(), handlers.HelmGet(helmClient))
 		s.AddTool(tools.HelmHistoryTool(), handlers.HelmHistory(helmClient))
 		s.AddTool(tools.HelmRepoListTool(), handlers.HelmRepoList(helmClient))
+		s.AddTool(tools.HelmReleaseStatusTool(), handlers.HelmReleaseStatus(helmClient))
+		s.AddTool(tools.HelmValidateValuesTool(), handlers.HelmValidateValues(helmClient))
+		s.AddTool(tools.HelmListFailedReleasesTool(), handlers.HelmListFailedReleases(helmClient))
+		s.AddTool(tools.HelmGetOverridesTool(), handlers.HelmGetOverrides(helmClient))
+		s.AddTool(tools.HelmReleaseObjectsTool(), handlers.HelmReleaseObjects(helmClient))
+		s.AddTool(tools.HelmGetValuesTool(), handlers.HelmGetValues(helmClient))
+		s.AddTool(tools.DetectDriftTool(), handlers.HelmDetectDrift(helmClient))
 
 		// Register write operations only if not in read-only mode
 		if !readOnly {
@@ -114,28 +365,134 @@ func main() {
 			s.AddTool(tools.HelmUninstallTool(), handlers.HelmUninstall(helmClient))
 			s.AddTool(tools.HelmRollbackTool(), handlers.HelmRollback(helmClient))
 			s.AddTool(tools.HelmRepoAddTool(), handlers.HelmRepoAdd(helmClient))
+			s.AddTool(tools.HelmRecoverTool(), handlers.HelmRecover(helmClient))
+			s.AddTool(tools.HelmBatchTool(), handlers.HelmBatch(helmClient))
+		}
+	}
+
+	// Register observability tools for the backends that are configured
+	if prometheusURL != "" {
+		prometheusClient, err := observability.NewPrometheusClient(observability.Endpoint{URL: prometheusURL, Token: prometheusToken})
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		s.AddTool(tools.QueryMetricsTool(), handlers.QueryMetrics(prometheusClient))
+	}
+	if lokiURL != "" {
+		lokiClient, err := observability.NewLokiClient(observability.Endpoint{URL: lokiURL, Token: lokiToken, OrgID: lokiOrgID})
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		s.AddTool(tools.QueryLogsTool(), handlers.QueryLogs(lokiClient))
+	}
+	if alertmanagerURL != "" {
+		alertmanagerClient, err := observability.NewAlertmanagerClient(observability.Endpoint{URL: alertmanagerURL, Token: alertmanagerToken})
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		s.AddTool(tools.GetAlertsTool(), handlers.GetAlerts(alertmanagerClient))
+	}
+
+	// Advertise the per-call escape hatch from truncation on every tool
+	if maxResponseBytes > 0 {
+		for _, tool := range s.ListTools() {
+			s.AddTool(tools.WithFullOutputOption(tool.Tool), tool.Handler)
 		}
 	}
 
+	// Advertise deduplication on every mutating tool
+	if idempotencyTTL > 0 {
+		for _, tool := range s.ListTools() {
+			if hint := tool.Tool.Annotations.ReadOnlyHint; hint == nil || !*hint {
+				s.AddTool(tools.WithIdempotencyKeyOption(tool.Tool), tool.Handler)
+			}
+		}
+	}
+
+	// On SIGTERM or SIGINT, stop accepting tool calls, give those in flight up to
+	// drainTimeout to finish, and then stop the transport
+	signalCtx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+	shutdownOnSignal := func(shutdown func(ctx context.Context) error) {
+		go func() {
+			<-signalCtx.Done()
+			// A second signal stops the server immediately
+			stop()
+			fmt.Printf("Draining: no longer accepting tool calls, waiting up to %s for those in flight\n", drainTimeout)
+			drainer.Drain()
+			ctx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+			defer cancel()
+			if running, err := drainer.Wait(ctx); err != nil {
+				fmt.Printf("Drain timed out with %d tool calls still running\n", running)
+			}
+			if err := shutdown(ctx); err != nil {
+				fmt.Printf("Failed to shut down server: %v\n", err)
+			}
+		}()
+	}
+
 	// Start server based on mode
 	switch mode {
 	case "stdio":
-		if err := server.ServeStdio(s); err != nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		shutdownOnSignal(func(context.Context) error {
+			cancel()
+			return nil
+		})
+		if err := server.NewStdioServer(s).Listen(ctx, os.Stdin, protocolOut); err != nil && !errors.Is(err, context.Canceled) {
 			fmt.Printf("Failed to start stdio server: %v\n", err)
 			return
 		}
 	case "sse":
 		fmt.Printf("Starting server in SSE mode on port %s...\n", port)
-		sse := server.NewSSEServer(s)
-		if err := sse.Start(":" + port); err != nil {
+		sseOptions := []server.SSEOption{
+			server.WithSSEEndpoint(sseEndpoint),
+			server.WithMessageEndpoint(sseMessageEndpoint),
+		}
+		if sseBasePath != "" {
+			sseOptions = append(sseOptions, server.WithStaticBasePath(sseBasePath))
+		}
+		if sseBaseURL != "" {
+			sseOptions = append(sseOptions, server.WithBaseURL(sseBaseURL))
+		}
+		if tokenStore != nil {
+			sseOptions = append(sseOptions, server.WithSSEContextFunc(tokenStore.ContextFunc))
+		}
+		mux := newHTTPMux(drainer, drainEndpoint, tokenStore)
+		sseOptions = append(sseOptions, server.WithHTTPServer(&http.Server{Handler: mux}))
+		sse := server.NewSSEServer(s, sseOptions...)
+		mux.Handle("/", sse)
+		shutdownOnSignal(sse.Shutdown)
+		fmt.Printf("SSE endpoint: %s, message endpoint: %s\n", sse.CompleteSsePath(), sse.CompleteMessagePath())
+		if err := sse.Start(":" + port); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			fmt.Printf("Failed to start SSE server: %v\n", err)
 			return
 		}
 		fmt.Printf("SSE server started on port %s\n", port)
 	case "streamable-http":
 		fmt.Printf("Starting server in streamable-http mode on port %s...\n", port)
-		streamableHTTP := server.NewStreamableHTTPServer(s, server.WithStateLess(true))
-		if err := streamableHTTP.Start(":" + port); err != nil {
+		var httpOptions []server.StreamableHTTPOption
+		if stateful {
+			// Keep per-session state so notifications from watches and subscriptions
+			// reach the client; the heartbeat keeps the listening stream open behind proxies.
+			fmt.Println("Stateful sessions enabled (use sticky sessions when running multiple replicas)")
+			httpOptions = append(httpOptions, server.WithStateful(true), server.WithHeartbeatInterval(30*time.Second))
+		} else {
+			httpOptions = append(httpOptions, server.WithStateLess(true))
+		}
+		if tokenStore != nil {
+			httpOptions = append(httpOptions, server.WithHTTPContextFunc(tokenStore.ContextFunc))
+		}
+		mux := newHTTPMux(drainer, drainEndpoint, tokenStore)
+		httpOptions = append(httpOptions, server.WithStreamableHTTPServer(&http.Server{Handler: mux}))
+		streamableHTTP := server.NewStreamableHTTPServer(s, httpOptions...)
+		mux.Handle("/mcp", streamableHTTP)
+		shutdownOnSignal(streamableHTTP.Shutdown)
+		if err := streamableHTTP.Start(":" + port); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			fmt.Printf("Failed to start streamable-http server: %v\n", err)
 			return
 		}
@@ -146,6 +503,18 @@ func main() {
 	}
 }
 
+// newHTTPMux returns a mux serving the /readyz readiness probe, which fails once the
+// server is draining, and, if drainEndpoint is set, the /drain endpoint, authenticated
+// with tokens if set. The transport is added to it.
+func newHTTPMux(drainer *handlers.Drainer, drainEndpoint bool, tokens auth.TokenStore) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/readyz", drainer.ReadyzHandler)
+	if drainEndpoint {
+		mux.HandleFunc("/drain", drainer.DrainHandler(tokens))
+	}
+	return mux
+}
+
 // getEnvOrDefault returns the value of the environment variable or the default value if not set
 func getEnvOrDefault(key, defaultValue string) string {
 	if value, exists := os.LookupEnv(key); exists {
@@ -153,3 +522,23 @@ func getEnvOrDefault(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// getEnvIntOrDefault returns the integer value of an environment variable or a
+// default value if it is unset or not a valid integer.
+func getEnvIntOrDefault(key string, defaultValue int) int {
+	if value, exists := os.LookupEnv(key); exists {
+		if i, err := strconv.Atoi(value); err == nil {
+			return i
+		}
+	}
+	return defaultValue
+}
+
+func getEnvDurationOrDefault(key string, defaultValue time.Duration) time.Duration {
+	if value, exists := os.LookupEnv(key); exists {
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+	}
+	return defaultValue
+}
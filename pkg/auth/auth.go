@@ -0,0 +1,128 @@
+// Package auth provides bearer-token authentication with per-token scopes for the
+// HTTP transports, allowing a single server to serve read-only and read-write clients.
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Scope is the level of access granted to a token.
+type Scope string
+
+const (
+	// ScopeRead only allows tools annotated as read-only.
+	ScopeRead Scope = "read"
+	// ScopeWrite allows all registered tools.
+	ScopeWrite Scope = "write"
+)
+
+type scopeContextKey struct{}
+
+type principalContextKey struct{}
+
+// TokenStore maps bearer tokens to the scope they grant.
+type TokenStore map[string]Scope
+
+// ParseTokens parses a comma-separated list of token:scope pairs,
+// e.g. "reader-token:read,admin-token:write".
+func ParseTokens(spec string) (TokenStore, error) {
+	store := TokenStore{}
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		idx := strings.LastIndex(entry, ":")
+		if idx <= 0 {
+			return nil, fmt.Errorf("invalid token entry %q: expected token:scope", entry)
+		}
+		token, scope := entry[:idx], Scope(entry[idx+1:])
+		if scope != ScopeRead && scope != ScopeWrite {
+			return nil, fmt.Errorf("invalid scope %q: expected %q or %q", scope, ScopeRead, ScopeWrite)
+		}
+		store[token] = scope
+	}
+	if len(store) == 0 {
+		return nil, fmt.Errorf("no tokens configured")
+	}
+	return store, nil
+}
+
+// ContextFunc resolves the bearer token of an HTTP request to its scope and stores
+// it in the request context, together with a principal identifying the token. It can
+// be used as both an SSE and streamable-http context function.
+func (t TokenStore) ContextFunc(ctx context.Context, r *http.Request) context.Context {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if scope, ok := t[token]; ok && token != "" {
+		sum := sha256.Sum256([]byte(token))
+		ctx = context.WithValue(ctx, principalContextKey{}, hex.EncodeToString(sum[:8]))
+		return context.WithValue(ctx, scopeContextKey{}, scope)
+	}
+	return ctx
+}
+
+// ScopeFromContext returns the scope stored in ctx, if any.
+func ScopeFromContext(ctx context.Context) (Scope, bool) {
+	scope, ok := ctx.Value(scopeContextKey{}).(Scope)
+	return scope, ok
+}
+
+// PrincipalFromContext returns an identifier of the token that authenticated the
+// request, derived from the token without revealing it, if any.
+func PrincipalFromContext(ctx context.Context) (string, bool) {
+	principal, ok := ctx.Value(principalContextKey{}).(string)
+	return principal, ok
+}
+
+// isReadOnlyTool reports whether a tool is annotated as read-only.
+func isReadOnlyTool(tool mcp.Tool) bool {
+	return tool.Annotations.ReadOnlyHint != nil && *tool.Annotations.ReadOnlyHint
+}
+
+// ToolMiddleware rejects tool calls without a valid token, and rejects calls to
+// tools that are not read-only unless the token grants the write scope.
+// lookup resolves a tool name to its definition.
+func ToolMiddleware(lookup func(name string) (mcp.Tool, bool)) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			scope, ok := ScopeFromContext(ctx)
+			if !ok {
+				return nil, fmt.Errorf("unauthorized: a valid bearer token is required")
+			}
+			if scope != ScopeWrite {
+				tool, found := lookup(request.Params.Name)
+				if !found || !isReadOnlyTool(tool) {
+					return nil, fmt.Errorf("forbidden: tool '%s' requires the %q scope", request.Params.Name, ScopeWrite)
+				}
+			}
+			return next(ctx, request)
+		}
+	}
+}
+
+// ToolFilter hides tools the caller's token is not allowed to call from tool listings.
+func ToolFilter(ctx context.Context, tools []mcp.Tool) []mcp.Tool {
+	scope, ok := ScopeFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	if scope == ScopeWrite {
+		return tools
+	}
+
+	var allowed []mcp.Tool
+	for _, tool := range tools {
+		if isReadOnlyTool(tool) {
+			allowed = append(allowed, tool)
+		}
+	}
+	return allowed
+}
@@ -0,0 +1,42 @@
+package helm
+
+import (
+	"fmt"
+	"os"
+)
+
+// writeChartArchive writes a packaged chart (.tgz) to a temporary file so that it can
+// be loaded like a chart on disk. The returned cleanup function removes the file and
+// must be called once the chart has been loaded.
+func writeChartArchive(data []byte) (string, func(), error) {
+	file, err := os.CreateTemp("", "mcp-chart-*.tgz")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create temporary chart file: %w", err)
+	}
+	cleanup := func() { os.Remove(file.Name()) }
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		cleanup()
+		return "", nil, fmt.Errorf("failed to write temporary chart file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("failed to write temporary chart file: %w", err)
+	}
+	return file.Name(), cleanup, nil
+}
+
+// resolveChartPath returns the path to load a chart from: a temporary file holding
+// chartData when it is given, or otherwise the chart located by name through locate.
+// The returned cleanup function must be called once the chart has been loaded.
+func resolveChartPath(chartName string, chartData []byte, locate func(name string) (string, error)) (string, func(), error) {
+	if len(chartData) > 0 {
+		return writeChartArchive(chartData)
+	}
+	path, err := locate(chartName)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to locate chart: %w", err)
+	}
+	return path, func() {}, nil
+}
@@ -0,0 +1,181 @@
+package helm
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/registry"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// maxBatchParallelism bounds how many releases BatchHelmOperation operates on at once.
+const maxBatchParallelism = 10
+
+// ReleaseRef identifies a release by namespace and name.
+type ReleaseRef struct {
+	Namespace string
+	Name      string
+}
+
+// BatchOperation describes the operation BatchHelmOperation applies to each release.
+type BatchOperation struct {
+	// Operation is "upgrade", "rollback", or "uninstall".
+	Operation string
+	// ChartName or ChartData is the chart every release is upgraded to.
+	ChartName string
+	ChartData []byte
+	// Values are the values every release is upgraded with.
+	Values map[string]interface{}
+	// Revision is the revision to roll back to, or 0 for the previous one.
+	Revision int
+	// Parallelism is how many releases are operated on at once.
+	Parallelism int
+}
+
+// BatchHelmOperation applies the same upgrade, rollback, or uninstall to each of the
+// given releases, up to op.Parallelism at a time. A failure on one release does not
+// stop the others. For an upgrade, the chart is located once and every release is
+// upgraded to it with the same values. Each release's result is passed to onResult,
+// if set, as soon as it finishes.
+// Returns a map with the per-release results, in the order given, and how many
+// succeeded and failed, or an error if the operation could not be started.
+func (c *Client) BatchHelmOperation(ctx context.Context, op BatchOperation, releases []ReleaseRef, onResult func(result map[string]interface{})) (map[string]interface{}, error) {
+	if len(releases) == 0 {
+		return nil, fmt.Errorf("at least one release is required")
+	}
+	seen := map[ReleaseRef]bool{}
+	for _, ref := range releases {
+		if ref.Name == "" || ref.Namespace == "" {
+			return nil, fmt.Errorf("every release needs a namespace and a name")
+		}
+		if seen[ref] {
+			return nil, fmt.Errorf("release '%s/%s' is listed more than once", ref.Namespace, ref.Name)
+		}
+		seen[ref] = true
+	}
+
+	var run func(ctx context.Context, ref ReleaseRef) (map[string]interface{}, error)
+	switch op.Operation {
+	case "upgrade":
+		chartPath, cleanup, err := c.locateBatchChart(op)
+		if err != nil {
+			return nil, err
+		}
+		defer cleanup()
+		run = func(ctx context.Context, ref ReleaseRef) (map[string]interface{}, error) {
+			return c.upgradeFromPath(ctx, ref, chartPath, op.Values)
+		}
+	case "rollback":
+		run = func(ctx context.Context, ref ReleaseRef) (map[string]interface{}, error) {
+			if err := c.RollbackRelease(ctx, ref.Namespace, ref.Name, op.Revision); err != nil {
+				return nil, err
+			}
+			return map[string]interface{}{"revision": op.Revision}, nil
+		}
+	case "uninstall":
+		run = func(ctx context.Context, ref ReleaseRef) (map[string]interface{}, error) {
+			return map[string]interface{}{}, c.UninstallChart(ctx, ref.Namespace, ref.Name)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported batch operation '%s': expected upgrade, rollback, or uninstall", op.Operation)
+	}
+
+	parallelism := min(max(op.Parallelism, 1), maxBatchParallelism)
+	results := make([]map[string]interface{}, len(releases))
+	slots := make(chan struct{}, parallelism)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for i, ref := range releases {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			result := map[string]interface{}{}
+			if err := ctx.Err(); err != nil {
+				result["status"] = "skipped"
+				result["error"] = err.Error()
+			} else if details, err := run(ctx, ref); err != nil {
+				result["status"] = "failed"
+				result["error"] = err.Error()
+			} else {
+				result = details
+				result["status"] = "succeeded"
+			}
+			result["namespace"] = ref.Namespace
+			result["releaseName"] = ref.Name
+			results[i] = result
+
+			if onResult != nil {
+				mu.Lock()
+				onResult(result)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	counts := map[string]int{}
+	for _, result := range results {
+		counts[result["status"].(string)]++
+	}
+	return map[string]interface{}{
+		"operation": op.Operation,
+		"total":     len(releases),
+		"succeeded": counts["succeeded"],
+		"failed":    counts["failed"],
+		"skipped":   counts["skipped"],
+		"results":   results,
+	}, nil
+}
+
+// locateBatchChart resolves the chart of a batch upgrade to a path on disk once, so
+// that it is not downloaded again for every release. The returned cleanup function
+// must be called once every release has been upgraded.
+func (c *Client) locateBatchChart(op BatchOperation) (string, func(), error) {
+	regClient, err := c.newRegistryClient(registry.ClientOptEnableCache(false))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to initialize registry client: %w", err)
+	}
+	locator := action.NewUpgrade(&action.Configuration{RegistryClient: regClient})
+	return resolveChartPath(op.ChartName, op.ChartData, func(name string) (string, error) {
+		return locator.LocateChart(name, c.settings)
+	})
+}
+
+// upgradeFromPath upgrades a release to the chart at chartPath. The chart is loaded
+// and the values copied for each release, since an upgrade modifies both in place.
+func (c *Client) upgradeFromPath(ctx context.Context, ref ReleaseRef, chartPath string, values map[string]interface{}) (map[string]interface{}, error) {
+	actionConfig := &action.Configuration{}
+	if err := actionConfig.Init(c.restClientGetter(), ref.Namespace, os.Getenv("HELM_DRIVER"), actionLog(ctx)); err != nil {
+		return nil, fmt.Errorf("failed to initialize action config: %w", err)
+	}
+
+	chart, err := loader.Load(chartPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load chart: %w", err)
+	}
+
+	releaseValues := map[string]interface{}{}
+	if values != nil {
+		releaseValues = runtime.DeepCopyJSON(values)
+	}
+
+	client := action.NewUpgrade(actionConfig)
+	client.Namespace = ref.Namespace
+	rel, err := client.RunWithContext(ctx, ref.Name, chart, releaseValues)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upgrade chart: %w", err)
+	}
+
+	result := map[string]interface{}{"revision": rel.Version}
+	if rel.Chart != nil && rel.Chart.Metadata != nil {
+		result["chart"] = rel.Chart.Metadata.Name + "-" + rel.Chart.Metadata.Version
+	}
+	return result, nil
+}
@@ -16,29 +16,54 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/restmapper"
-	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/clientcmd/api"
 	"log"
 	"os"
 	"path/filepath"
+	"sync"
+	"sync/atomic"
 
 	"github.com/reza-gholizade/k8s-mcp-server/pkg/k8s"
 )
 
 // Client wraps Helm operations
 type Client struct {
-	settings         *cli.EnvSettings
+	settings *cli.EnvSettings
+	// conns is replaced as a whole by Reload; read it through the accessors below
+	conns atomic.Pointer[connections]
+}
+
+// connections are the Kubernetes configuration and clients Helm actions run with.
+// They are never modified once built, so that Reload can swap them while actions are
+// reading them.
+type connections struct {
 	restConfig       *rest.Config
 	k8sClient        kubernetes.Interface
-	restClientGetter genericclioptions.RESTClientGetter
+	restClientGetter *customRESTClientGetter
+}
+
+// restConfig returns the REST config of the current connections.
+func (c *Client) restConfig() *rest.Config {
+	return c.conns.Load().restConfig
+}
+
+// restClientGetter returns the RESTClientGetter of the current connections.
+func (c *Client) restClientGetter() *customRESTClientGetter {
+	return c.conns.Load().restClientGetter
 }
 
 // customRESTClientGetter is a custom RESTClientGetter that uses a pre-built rest.Config
 // instead of reading from kubeconfig files. This ensures Helm uses the same authentication
 // method that was used to build the restConfig (KUBECONFIG_DATA, KUBERNETES_SERVER/TOKEN, etc.)
+// The discovery client and REST mapper are created once and reused by every action,
+// unless they are shared with the Kubernetes client through ShareDiscovery.
 type customRESTClientGetter struct {
 	restConfig *rest.Config
+
+	mu              sync.Mutex
+	discoveryClient discovery.CachedDiscoveryInterface
+	restMapper      meta.RESTMapper
 }
 
 // ToRESTConfig returns the pre-built REST config
@@ -51,24 +76,41 @@ func (g *customRESTClientGetter) ToRawKubeConfigLoader() clientcmd.ClientConfig
 	return &customClientConfig{restConfig: g.restConfig}
 }
 
-// ToDiscoveryClient returns a discovery client using the pre-built REST config
+// ToDiscoveryClient returns the cached discovery client, creating it from the
+// pre-built REST config on first use
 func (g *customRESTClientGetter) ToDiscoveryClient() (discovery.CachedDiscoveryInterface, error) {
-	discoveryClient, err := discovery.NewDiscoveryClientForConfig(g.restConfig)
-	if err != nil {
-		return nil, err
-	}
-	return memory.NewMemCacheClient(discoveryClient), nil
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.discoveryClientLocked()
+}
+
+// discoveryClientLocked returns the cached discovery client, creating it if needed.
+// The caller must hold g.mu.
+func (g *customRESTClientGetter) discoveryClientLocked() (discovery.CachedDiscoveryInterface, error) {
+	if g.discoveryClient == nil {
+		discoveryClient, err := discovery.NewDiscoveryClientForConfig(g.restConfig)
+		if err != nil {
+			return nil, err
+		}
+		g.discoveryClient = memory.NewMemCacheClient(discoveryClient)
+	}
+	return g.discoveryClient, nil
 }
 
-// ToRESTMapper returns a REST mapper using the discovery client
+// ToRESTMapper returns the REST mapper backed by the discovery client, creating it on
+// first use
 func (g *customRESTClientGetter) ToRESTMapper() (meta.RESTMapper, error) {
-	discoveryClient, err := g.ToDiscoveryClient()
-	if err != nil {
-		return nil, err
-	}
-	mapper := restmapper.NewDeferredDiscoveryRESTMapper(discoveryClient)
-	expander := restmapper.NewShortcutExpander(mapper, discoveryClient, nil)
-	return expander, nil
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.restMapper == nil {
+		discoveryClient, err := g.discoveryClientLocked()
+		if err != nil {
+			return nil, err
+		}
+		mapper := restmapper.NewDeferredDiscoveryRESTMapper(discoveryClient)
+		g.restMapper = restmapper.NewShortcutExpander(mapper, discoveryClient, nil)
+	}
+	return g.restMapper, nil
 }
 
 // customClientConfig implements clientcmd.ClientConfig interface
@@ -102,11 +144,17 @@ func (c *customClientConfig) ConfigAccess() clientcmd.ConfigAccess {
 // 2. API server URL and token from KUBERNETES_SERVER and KUBERNETES_TOKEN environment variables
 // 3. In-cluster authentication (service account token)
 // 4. Kubeconfig file path (provided or default ~/.kube/config)
-func NewClient(kubeconfig string) (*Client, error) {
+// authMethod forces one of these, as for k8s.BuildKubernetesConfig.
+// registryConfig is the OCI registry credentials file; when empty, Helm's default
+// (HELM_REGISTRY_CONFIG or its standard location) is used.
+func NewClient(kubeconfig string, authMethod k8s.AuthMethod, registryConfig string) (*Client, error) {
 	settings := cli.New()
+	if registryConfig != "" {
+		settings.RegistryConfig = registryConfig
+	}
 
 	// Get Kubernetes REST config using the shared config builder
-	restConfig, err := k8s.BuildKubernetesConfig(kubeconfig)
+	restConfig, err := k8s.BuildKubernetesConfig(kubeconfig, authMethod)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get Kubernetes config: %w", err)
 	}
@@ -131,17 +179,65 @@ func NewClient(kubeconfig string) (*Client, error) {
 		return nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
 	}
 
-	return &Client{
-		settings:         settings,
+	client := &Client{settings: settings}
+	client.conns.Store(&connections{
 		restConfig:       restConfig,
 		k8sClient:        k8sClient,
 		restClientGetter: restClientGetter,
-	}, nil
+	})
+	return client, nil
 }
 
-func (c *Client) InstallChart(ctx context.Context, namespace, releaseName, chartName, repoURL string, values map[string]interface{}) (*release.Release, error) {
+// Reload rebuilds the client's Kubernetes configuration, e.g. after the kubeconfig file
+// was rewritten with rotated credentials or a new current context. Actions already in
+// progress finish with the configuration they started with. The client is left
+// unchanged if the new configuration cannot be loaded.
+func (c *Client) Reload(kubeconfig string, authMethod k8s.AuthMethod) error {
+	apply, err := c.PrepareReload(kubeconfig, authMethod)
+	if err != nil {
+		return err
+	}
+	apply()
+	return nil
+}
+
+// PrepareReload builds the configuration Reload would switch to and returns a
+// function that switches to it, without changing the client until it is called, as
+// k8s.Client.PrepareReload does.
+func (c *Client) PrepareReload(kubeconfig string, authMethod k8s.AuthMethod) (func(), error) {
+	reloaded, err := NewClient(kubeconfig, authMethod, c.settings.RegistryConfig)
+	if err != nil {
+		return nil, err
+	}
+	return func() { c.conns.Store(reloaded.conns.Load()) }, nil
+}
+
+// ShareDiscovery makes Helm actions use the given discovery client and REST mapper,
+// typically those of the Kubernetes client, instead of their own, so that the API is
+// discovered once for the whole server and Helm resolves kinds to the same resources
+// as the Kubernetes tools. Reload discards them, so they must be shared again after it.
+func (c *Client) ShareDiscovery(discoveryClient discovery.CachedDiscoveryInterface, restMapper meta.RESTMapper) {
+	getter := c.restClientGetter()
+	getter.mu.Lock()
+	defer getter.mu.Unlock()
+	getter.discoveryClient = discoveryClient
+	getter.restMapper = restMapper
+}
+
+// newRegistryClient creates an OCI registry client that authenticates with the
+// credentials in the configured registry config file, so that registries the user
+// has already logged in to work without a separate login.
+func (c *Client) newRegistryClient(opts ...registry.ClientOption) (*registry.Client, error) {
+	opts = append([]registry.ClientOption{registry.ClientOptCredentialsFile(c.settings.RegistryConfig)}, opts...)
+	return registry.NewClient(opts...)
+}
+
+// InstallChart installs a chart as a new release. The chart is given by name (resolved
+// through repoURL or the configured repositories, or an OCI reference) or, when
+// chartData is set, as a packaged chart archive.
+func (c *Client) InstallChart(ctx context.Context, namespace, releaseName, chartName, repoURL string, chartData []byte, values map[string]interface{}) (*release.Release, error) {
 	actionConfig := &action.Configuration{}
-	if err := actionConfig.Init(c.restClientGetter, namespace, os.Getenv("HELM_DRIVER"), log.Printf); err != nil {
+	if err := actionConfig.Init(c.restClientGetter(), namespace, os.Getenv("HELM_DRIVER"), actionLog(ctx)); err != nil {
 		return nil, fmt.Errorf("failed to initialize action config: %w", err)
 	}
 
@@ -149,15 +245,14 @@ func (c *Client) InstallChart(ctx context.Context, namespace, releaseName, chart
 	client.Namespace = namespace
 	client.ReleaseName = releaseName
 	client.CreateNamespace = true
-	cln, err := registry.NewClient(
+	cln, err := c.newRegistryClient(
 		registry.ClientOptDebug(true),
-		registry.ClientOptCredentialsFile(""),
 		registry.ClientOptEnableCache(false))
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize registry: %w", err)
 	}
-	fmt.Println("Registry client created successfully:", cln)
+	log.Printf("Registry client created successfully: %v", cln)
 
 	if values == nil {
 		values = make(map[string]interface{})
@@ -168,11 +263,14 @@ func (c *Client) InstallChart(ctx context.Context, namespace, releaseName, chart
 		client.RepoURL = repoURL
 	}
 
-	// Locate the chart (resolves repo/chart or OCI)
-	chartPath, err := client.LocateChart(chartName, c.settings)
+	// Locate the chart (resolves repo/chart or OCI), or write the uploaded archive to disk
+	chartPath, cleanup, err := resolveChartPath(chartName, chartData, func(name string) (string, error) {
+		return client.LocateChart(name, c.settings)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to locate chart: %w", err)
+		return nil, err
 	}
+	defer cleanup()
 
 	// Load the chart from the resolved path (can be a URL or OCI reference)
 	chart, err := loader.Load(chartPath)
@@ -189,21 +287,23 @@ func (c *Client) InstallChart(ctx context.Context, namespace, releaseName, chart
 	return release, nil
 }
 
-func (c *Client) UpgradeChart(ctx context.Context, namespace, releaseName, chartName string, values map[string]interface{}) (*release.Release, error) {
+// UpgradeChart upgrades a release to a chart given by name or, when chartData is set,
+// as a packaged chart archive.
+func (c *Client) UpgradeChart(ctx context.Context, namespace, releaseName, chartName string, chartData []byte, values map[string]interface{}) (*release.Release, error) {
 	actionConfig := &action.Configuration{}
-	if err := actionConfig.Init(c.restClientGetter, namespace, os.Getenv("HELM_DRIVER"), log.Printf); err != nil {
+	if err := actionConfig.Init(c.restClientGetter(), namespace, os.Getenv("HELM_DRIVER"), actionLog(ctx)); err != nil {
 		return nil, fmt.Errorf("failed to initialize action config: %w", err)
 	}
 
 	// Create and assign registry client
-	regClient, err := registry.NewClient(
+	regClient, err := c.newRegistryClient(
 		registry.ClientOptDebug(true),
 		registry.ClientOptEnableCache(false),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize registry client: %w", err)
 	}
-	fmt.Println("Registry client created successfully:", regClient)
+	log.Printf("Registry client created successfully: %v", regClient)
 
 	client := action.NewUpgrade(actionConfig)
 	client.Namespace = namespace
@@ -212,11 +312,14 @@ func (c *Client) UpgradeChart(ctx context.Context, namespace, releaseName, chart
 		values = make(map[string]interface{})
 	}
 
-	// Locate the chart (for both OCI and regular charts)
-	chartPath, err := client.LocateChart(chartName, c.settings)
+	// Locate the chart (for both OCI and regular charts), or write the uploaded archive to disk
+	chartPath, cleanup, err := resolveChartPath(chartName, chartData, func(name string) (string, error) {
+		return client.LocateChart(name, c.settings)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to locate chart: %w", err)
+		return nil, err
 	}
+	defer cleanup()
 
 	chart, err := loader.Load(chartPath)
 	if err != nil {
@@ -234,7 +337,7 @@ func (c *Client) UpgradeChart(ctx context.Context, namespace, releaseName, chart
 // UninstallChart uninstalls a Helm release
 func (c *Client) UninstallChart(ctx context.Context, namespace, releaseName string) error {
 	actionConfig := &action.Configuration{}
-	if err := actionConfig.Init(c.restClientGetter, namespace, os.Getenv("HELM_DRIVER"), log.Printf); err != nil {
+	if err := actionConfig.Init(c.restClientGetter(), namespace, os.Getenv("HELM_DRIVER"), actionLog(ctx)); err != nil {
 		return fmt.Errorf("failed to initialize action config: %w", err)
 	}
 
@@ -247,14 +350,23 @@ func (c *Client) UninstallChart(ctx context.Context, namespace, releaseName stri
 	return nil
 }
 
-func (c *Client) ListReleases(ctx context.Context, namespace string) ([]*release.Release, error) {
+func (c *Client) ListReleases(ctx context.Context, namespace, selector string) ([]*release.Release, error) {
+	return c.listReleases(namespace, selector, action.ListDeployed|action.ListFailed)
+}
+
+// listReleases lists the latest revision of each release in the namespace (or in
+// all namespaces when empty) whose status matches stateMask and whose release labels
+// match the selector, if one is given.
+func (c *Client) listReleases(namespace, selector string, stateMask action.ListStates) ([]*release.Release, error) {
 	actionConfig := &action.Configuration{}
-	if err := actionConfig.Init(c.restClientGetter, namespace, os.Getenv("HELM_DRIVER"), log.Printf); err != nil {
+	if err := actionConfig.Init(c.restClientGetter(), namespace, os.Getenv("HELM_DRIVER"), log.Printf); err != nil {
 		return nil, fmt.Errorf("failed to initialize action config: %w", err)
 	}
 
 	client := action.NewList(actionConfig)
 	client.AllNamespaces = namespace == ""
+	client.StateMask = stateMask
+	client.Selector = selector
 
 	releases, err := client.Run()
 	if err != nil {
@@ -277,7 +389,7 @@ func (c *Client) ListReleases(ctx context.Context, namespace string) ([]*release
 
 func (c *Client) GetRelease(ctx context.Context, namespace, releaseName string) (*release.Release, error) {
 	actionConfig := &action.Configuration{}
-	if err := actionConfig.Init(c.restClientGetter, namespace, os.Getenv("HELM_DRIVER"), log.Printf); err != nil {
+	if err := actionConfig.Init(c.restClientGetter(), namespace, os.Getenv("HELM_DRIVER"), log.Printf); err != nil {
 		return nil, fmt.Errorf("failed to initialize action config: %w", err)
 	}
 
@@ -292,7 +404,7 @@ func (c *Client) GetRelease(ctx context.Context, namespace, releaseName string)
 
 func (c *Client) GetReleaseHistory(ctx context.Context, namespace, releaseName string) ([]*release.Release, error) {
 	actionConfig := &action.Configuration{}
-	if err := actionConfig.Init(c.restClientGetter, namespace, os.Getenv("HELM_DRIVER"), log.Printf); err != nil {
+	if err := actionConfig.Init(c.restClientGetter(), namespace, os.Getenv("HELM_DRIVER"), log.Printf); err != nil {
 		return nil, fmt.Errorf("failed to initialize action config: %w", err)
 	}
 
@@ -308,7 +420,7 @@ func (c *Client) GetReleaseHistory(ctx context.Context, namespace, releaseName s
 // RollbackRelease rolls back a Helm release
 func (c *Client) RollbackRelease(ctx context.Context, namespace, releaseName string, revision int) error {
 	actionConfig := &action.Configuration{}
-	if err := actionConfig.Init(c.restClientGetter, namespace, os.Getenv("HELM_DRIVER"), log.Printf); err != nil {
+	if err := actionConfig.Init(c.restClientGetter(), namespace, os.Getenv("HELM_DRIVER"), actionLog(ctx)); err != nil {
 		return fmt.Errorf("failed to initialize action config: %w", err)
 	}
 
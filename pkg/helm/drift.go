@@ -0,0 +1,176 @@
+package helm
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"sync"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/release"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/reza-gholizade/k8s-mcp-server/pkg/k8s"
+)
+
+// maxDriftParallelism bounds how many releases DetectReleaseDrift checks at once.
+const maxDriftParallelism = 5
+
+// Drift states of the objects of a release reported by DetectReleaseDrift.
+const (
+	DriftInSync  = "inSync"
+	DriftDrifted = "drifted"
+	DriftMissing = "missing"
+	DriftError   = "error"
+)
+
+// DetectReleaseDrift checks every deployed release in a namespace, or in all namespaces
+// if namespace is empty, for objects modified or deleted outside Helm: each object of
+// the release's stored manifest is compared with its live state, field by field, the
+// way semanticDiff does, so only the fields the chart sets are compared and fields the
+// server populates or defaults are not drift. Changes made by controllers, such as
+// replicas set by a HorizontalPodAutoscaler or containers injected by a webhook, are
+// reported as drift as well. Only the paths of drifted fields are reported, never their
+// values. A release that cannot be checked is reported with its error and does not
+// stop the others.
+// Returns a map with the releases, drifted ones first, and a summary, or an error.
+func (c *Client) DetectReleaseDrift(ctx context.Context, namespace string) (map[string]interface{}, error) {
+	releases, err := c.listReleases(namespace, "", action.ListDeployed)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]map[string]interface{}, len(releases))
+	slots := make(chan struct{}, maxDriftParallelism)
+	var wg sync.WaitGroup
+	for i, rel := range releases {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			result, err := c.releaseDrift(ctx, rel.Namespace, rel.Name)
+			if err != nil {
+				result = map[string]interface{}{
+					"release":   rel.Name,
+					"namespace": rel.Namespace,
+					"revision":  rel.Version,
+					"error":     err.Error(),
+				}
+			}
+			results[i] = result
+		}()
+	}
+	wg.Wait()
+
+	drifted, failed := 0, 0
+	for _, result := range results {
+		if _, ok := result["error"]; ok {
+			failed++
+		} else if result["drifted"].(bool) {
+			drifted++
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		iDrifted, _ := results[i]["drifted"].(bool)
+		jDrifted, _ := results[j]["drifted"].(bool)
+		if iDrifted != jDrifted {
+			return iDrifted
+		}
+		return results[i]["namespace"].(string)+"/"+results[i]["release"].(string) < results[j]["namespace"].(string)+"/"+results[j]["release"].(string)
+	})
+
+	return map[string]interface{}{
+		"summary": map[string]interface{}{
+			"releases": len(results),
+			"drifted":  drifted,
+			"inSync":   len(results) - drifted - failed,
+			"failed":   failed,
+		},
+		"releases": results,
+	}, nil
+}
+
+// releaseDrift compares the objects of a release's stored manifest with their live
+// state. Only objects that are not in sync are listed.
+func (c *Client) releaseDrift(ctx context.Context, namespace, releaseName string) (map[string]interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	actionConfig := &action.Configuration{}
+	if err := actionConfig.Init(c.restClientGetter(), namespace, os.Getenv("HELM_DRIVER"), log.Printf); err != nil {
+		return nil, fmt.Errorf("failed to initialize action config: %w", err)
+	}
+	rel, err := action.NewGet(actionConfig).Run(releaseName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get release: %w", err)
+	}
+	infos, err := actionConfig.KubeClient.Build(bytes.NewBufferString(rel.Manifest), false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse release manifest: %w", err)
+	}
+
+	counts := map[string]int{}
+	objects := []map[string]interface{}{}
+	for _, info := range infos {
+		entry := map[string]interface{}{
+			"kind":      info.Mapping.GroupVersionKind.Kind,
+			"name":      info.Name,
+			"namespace": info.Namespace,
+		}
+		state := DriftInSync
+
+		// Get replaces the manifest object with the live one
+		desired, ok := info.Object.(*unstructured.Unstructured)
+		if !ok {
+			state = DriftError
+			entry["error"] = "manifest object is not unstructured"
+		} else if err := info.Get(); err != nil {
+			if apierrors.IsNotFound(err) {
+				state = DriftMissing
+			} else {
+				state = DriftError
+				entry["error"] = err.Error()
+			}
+		} else if live, ok := info.Object.(*unstructured.Unstructured); ok {
+			adds, removes, changes := k8s.CompareFields(desired.Object, live.Object)
+			if len(adds)+len(removes)+len(changes) > 0 {
+				state = DriftDrifted
+				entry["changed"] = changes
+				entry["added"] = adds
+				entry["removed"] = removes
+			}
+		}
+
+		counts[state]++
+		if state != DriftInSync {
+			entry["state"] = state
+			objects = append(objects, entry)
+		}
+	}
+
+	return map[string]interface{}{
+		"release":   rel.Name,
+		"namespace": rel.Namespace,
+		"revision":  rel.Version,
+		"chart":     releaseChart(rel),
+		"drifted":   counts[DriftDrifted]+counts[DriftMissing] > 0,
+		"objects":   len(infos),
+		"counts":    counts,
+		"drift":     objects,
+	}, nil
+}
+
+// releaseChart returns the chart of a release as name-version, or "" if unknown.
+func releaseChart(rel *release.Release) string {
+	if rel.Chart == nil || rel.Chart.Metadata == nil {
+		return ""
+	}
+	return rel.Chart.Metadata.Name + "-" + rel.Chart.Metadata.Version
+}
@@ -0,0 +1,49 @@
+package helm
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"helm.sh/helm/v3/pkg/action"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// ListFailedReleases lists releases whose latest revision is failed or stuck in a
+// pending state, across all namespaces when namespace is empty. Each entry includes
+// the revision's description, which for failed operations carries Helm's error.
+// Returns the matching releases, most recently updated first, or an error.
+func (c *Client) ListFailedReleases(ctx context.Context, namespace string) ([]map[string]interface{}, error) {
+	releases, err := c.listReleases(namespace, "", action.ListFailed|action.ListPendingInstall|action.ListPendingUpgrade|action.ListPendingRollback)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(releases, func(i, j int) bool {
+		return releases[i].Info.LastDeployed.After(releases[j].Info.LastDeployed)
+	})
+
+	result := make([]map[string]interface{}, 0, len(releases))
+	for _, rel := range releases {
+		entry := map[string]interface{}{
+			"name":      rel.Name,
+			"namespace": rel.Namespace,
+			"revision":  rel.Version,
+			"status":    rel.Info.Status.String(),
+			"pending":   rel.Info.Status.IsPending(),
+		}
+		if rel.Chart != nil && rel.Chart.Metadata != nil {
+			entry["chart"] = rel.Chart.Metadata.Name + "-" + rel.Chart.Metadata.Version
+			entry["appVersion"] = rel.Chart.Metadata.AppVersion
+		}
+		if !rel.Info.LastDeployed.IsZero() {
+			entry["updated"] = rel.Info.LastDeployed.Time
+			entry["age"] = duration.HumanDuration(time.Since(rel.Info.LastDeployed.Time))
+		}
+		if rel.Info.Description != "" {
+			entry["lastError"] = rel.Info.Description
+		}
+		result = append(result, entry)
+	}
+	return result, nil
+}
@@ -0,0 +1,32 @@
+package helm
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// logSinkKey is the context key under which a per-request log sink is stored.
+type logSinkKey struct{}
+
+// WithLogSink returns a context that routes the Helm action log for operations run
+// with it to sink, in addition to the server log. Each call receives one formatted
+// line. It lets callers stream the progress of long installs and upgrades, such as the
+// resources being created and the waits for them to become ready.
+func WithLogSink(ctx context.Context, sink func(line string)) context.Context {
+	return context.WithValue(ctx, logSinkKey{}, sink)
+}
+
+// actionLog returns the log function to pass to action.Configuration.Init for an
+// operation run with ctx: the server log, plus the context's log sink if it has one.
+func actionLog(ctx context.Context) func(format string, v ...interface{}) {
+	sink, ok := ctx.Value(logSinkKey{}).(func(line string))
+	if !ok || sink == nil {
+		return log.Printf
+	}
+	return func(format string, v ...interface{}) {
+		log.Printf(format, v...)
+		sink(strings.TrimSpace(fmt.Sprintf(format, v...)))
+	}
+}
@@ -0,0 +1,179 @@
+package helm
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/action"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+)
+
+// Labels and annotations Helm sets on the objects of a release.
+const (
+	helmManagedBySelector          = "app.kubernetes.io/managed-by=Helm"
+	helmInstanceLabel              = "app.kubernetes.io/instance"
+	helmReleaseNameAnnotation      = "meta.helm.sh/release-name"
+	helmReleaseNamespaceAnnotation = "meta.helm.sh/release-namespace"
+)
+
+// ListReleaseObjectsByLabel finds the live objects that belong to a release by their
+// Helm labels rather than by the stored manifest: every object labeled
+// app.kubernetes.io/managed-by=Helm whose meta.helm.sh/release-name and
+// release-namespace annotations name the release, or, lacking those annotations, whose
+// app.kubernetes.io/instance label does. Every listable kind is searched, namespaced
+// kinds in the release namespace (or in all namespaces when allNamespaces is set) and
+// cluster-scoped kinds; objects created by a controller, such as a Deployment's pods,
+// are left out since they inherit the labels of their owner. Each object is compared
+// with the release manifest, so objects added or left behind outside the release are
+// reported as not in the manifest, and manifest objects not found by label are listed
+// separately. The release itself need not exist any more.
+// Returns a map with the objects found, the manifest comparison, and any kinds that
+// could not be listed, or an error.
+func (c *Client) ListReleaseObjectsByLabel(ctx context.Context, namespace, releaseName string, allNamespaces bool) (map[string]interface{}, error) {
+	conns := c.conns.Load()
+	dynamicClient, err := dynamic.NewForConfig(conns.restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+	discoveryClient, err := conns.restClientGetter.ToDiscoveryClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create discovery client: %w", err)
+	}
+
+	result := map[string]interface{}{
+		"release":   releaseName,
+		"namespace": namespace,
+	}
+	var errs []string
+
+	// Objects declared by the current manifest, keyed by kind/namespace/name
+	manifest := map[string]bool{}
+	hasManifest := false
+	actionConfig := &action.Configuration{}
+	if err := actionConfig.Init(conns.restClientGetter, namespace, os.Getenv("HELM_DRIVER"), log.Printf); err != nil {
+		return nil, fmt.Errorf("failed to initialize action config: %w", err)
+	}
+	if rel, err := action.NewGet(actionConfig).Run(releaseName); err != nil {
+		errs = append(errs, fmt.Sprintf("release manifest unavailable, objects cannot be compared with it: %v", err))
+	} else {
+		infos, err := actionConfig.KubeClient.Build(bytes.NewBufferString(rel.Manifest), false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse release manifest: %w", err)
+		}
+		for _, info := range infos {
+			manifest[info.Mapping.GroupVersionKind.Kind+"/"+info.Namespace+"/"+info.Name] = true
+		}
+		hasManifest = true
+		result["revision"] = rel.Version
+		result["releaseStatus"] = rel.Info.Status.String()
+	}
+
+	resourceLists, err := discoveryClient.ServerPreferredResources()
+	if err != nil {
+		if !discovery.IsGroupDiscoveryFailedError(err) {
+			return nil, fmt.Errorf("failed to retrieve API resources: %w", err)
+		}
+		errs = append(errs, err.Error())
+	}
+
+	objects := []map[string]interface{}{}
+	found := map[string]bool{}
+	notInManifest := 0
+	for _, list := range resourceLists {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			continue
+		}
+		for _, r := range list.APIResources {
+			if strings.Contains(r.Name, "/") || r.Kind == "Event" || !slices.Contains(r.Verbs, "list") {
+				continue
+			}
+			resource := dynamicClient.Resource(gv.WithResource(r.Name))
+			listNamespace := ""
+			if r.Namespaced && !allNamespaces {
+				listNamespace = namespace
+			}
+			items, err := resource.Namespace(listNamespace).List(ctx, metav1.ListOptions{LabelSelector: helmManagedBySelector})
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %v", r.Kind, err))
+				continue
+			}
+
+			for _, item := range items.Items {
+				if metav1.GetControllerOfNoCopy(&item) != nil {
+					continue
+				}
+				annotations := item.GetAnnotations()
+				matchedBy := ""
+				switch {
+				case annotations[helmReleaseNameAnnotation] != "":
+					if annotations[helmReleaseNameAnnotation] == releaseName && annotations[helmReleaseNamespaceAnnotation] == namespace {
+						matchedBy = "annotations"
+					}
+				case item.GetLabels()[helmInstanceLabel] == releaseName:
+					matchedBy = "instanceLabel"
+				}
+				if matchedBy == "" {
+					continue
+				}
+
+				key := r.Kind + "/" + item.GetNamespace() + "/" + item.GetName()
+				found[key] = true
+				entry := map[string]interface{}{
+					"kind":       r.Kind,
+					"apiVersion": list.GroupVersion,
+					"name":       item.GetName(),
+					"namespace":  item.GetNamespace(),
+					"matchedBy":  matchedBy,
+				}
+				if chart := item.GetLabels()["helm.sh/chart"]; chart != "" {
+					entry["chart"] = chart
+				}
+				if hasManifest {
+					entry["inManifest"] = manifest[key]
+					if !manifest[key] {
+						notInManifest++
+					}
+				}
+				objects = append(objects, entry)
+			}
+		}
+	}
+
+	sort.SliceStable(objects, func(i, j int) bool {
+		if objects[i]["kind"] != objects[j]["kind"] {
+			return objects[i]["kind"].(string) < objects[j]["kind"].(string)
+		}
+		if objects[i]["namespace"] != objects[j]["namespace"] {
+			return objects[i]["namespace"].(string) < objects[j]["namespace"].(string)
+		}
+		return objects[i]["name"].(string) < objects[j]["name"].(string)
+	})
+	result["objects"] = objects
+
+	if hasManifest {
+		// Declared objects that were deleted, or whose labels were changed or never set
+		manifestOnly := []string{}
+		for key := range manifest {
+			if !found[key] {
+				manifestOnly = append(manifestOnly, key)
+			}
+		}
+		sort.Strings(manifestOnly)
+		result["notInManifest"] = notInManifest
+		result["manifestOnly"] = manifestOnly
+	}
+	if len(errs) > 0 {
+		result["errors"] = errs
+	}
+	return result, nil
+}
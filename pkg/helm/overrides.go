@@ -0,0 +1,164 @@
+package helm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chartutil"
+)
+
+// Views of a release's values returned by GetValues.
+const (
+	// ValuesViewUser selects the values supplied by the user, as with 'helm get values'.
+	ValuesViewUser = "user"
+	// ValuesViewComputed selects the chart defaults coalesced with the user-supplied
+	// values, as with 'helm get values --all'.
+	ValuesViewComputed = "computed"
+	// ValuesViewBoth selects both, side by side.
+	ValuesViewBoth = "both"
+)
+
+// GetValues returns the values of a deployed release at the given revision, or at the
+// current one if revision is 0, in the given view: the user-supplied values, the
+// computed values Helm rendered the chart with, or both side by side, which shows
+// what was set against what took effect after merging the chart defaults.
+// Returns a map with the release, its chart, and the selected values, or an error.
+func (c *Client) GetValues(ctx context.Context, namespace, releaseName string, revision int, view string) (map[string]interface{}, error) {
+	if view == "" {
+		view = ValuesViewUser
+	}
+	if view != ValuesViewUser && view != ValuesViewComputed && view != ValuesViewBoth {
+		return nil, fmt.Errorf("invalid view '%s': expected %s, %s, or %s", view, ValuesViewUser, ValuesViewComputed, ValuesViewBoth)
+	}
+
+	actionConfig := &action.Configuration{}
+	if err := actionConfig.Init(c.restClientGetter(), namespace, os.Getenv("HELM_DRIVER"), log.Printf); err != nil {
+		return nil, fmt.Errorf("failed to initialize action config: %w", err)
+	}
+
+	get := action.NewGet(actionConfig)
+	get.Version = revision
+	rel, err := get.Run(releaseName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get release: %w", err)
+	}
+
+	result := map[string]interface{}{
+		"release":   rel.Name,
+		"namespace": rel.Namespace,
+		"revision":  rel.Version,
+		"view":      view,
+	}
+	if rel.Chart.Metadata != nil {
+		result["chart"] = rel.Chart.Metadata.Name
+		result["chartVersion"] = rel.Chart.Metadata.Version
+	}
+	if view != ValuesViewComputed {
+		userValues := rel.Config
+		if userValues == nil {
+			userValues = map[string]interface{}{}
+		}
+		result["userValues"] = userValues
+	}
+	if view != ValuesViewUser {
+		// The same coalescing 'helm get values --all' does
+		computed, err := chartutil.CoalesceValues(rel.Chart, rel.Config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute values: %w", err)
+		}
+		result["computedValues"] = computed.AsMap()
+	}
+	return result, nil
+}
+
+// GetValueOverrides computes which values of a deployed release were customized: the
+// user-supplied values, flattened to dotted paths, that differ from the chart's
+// defaults. Each override reports the value set and the chart default it replaces, if
+// any. User-supplied values that merely repeat the default are listed separately as
+// redundant, since they pin the value without changing it.
+// Returns a map with the release, its chart, the overrides, and the redundant paths, or an error.
+func (c *Client) GetValueOverrides(ctx context.Context, namespace, releaseName string) (map[string]interface{}, error) {
+	actionConfig := &action.Configuration{}
+	if err := actionConfig.Init(c.restClientGetter(), namespace, os.Getenv("HELM_DRIVER"), log.Printf); err != nil {
+		return nil, fmt.Errorf("failed to initialize action config: %w", err)
+	}
+
+	rel, err := action.NewGet(actionConfig).Run(releaseName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get release: %w", err)
+	}
+
+	defaults := map[string]interface{}{}
+	flattenValues("", rel.Chart.Values, defaults)
+	user := map[string]interface{}{}
+	flattenValues("", rel.Config, user)
+
+	paths := make([]string, 0, len(user))
+	for path := range user {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	overrides := []map[string]interface{}{}
+	redundant := []string{}
+	for _, path := range paths {
+		value := user[path]
+		entry := map[string]interface{}{
+			"path":  path,
+			"value": value,
+		}
+		if def, ok := defaults[path]; ok {
+			if valuesEqual(value, def) {
+				redundant = append(redundant, path)
+				continue
+			}
+			entry["default"] = def
+		} else {
+			entry["default"] = nil
+			entry["newKey"] = true
+		}
+		overrides = append(overrides, entry)
+	}
+
+	result := map[string]interface{}{
+		"release":   rel.Name,
+		"namespace": rel.Namespace,
+		"revision":  rel.Version,
+		"overrides": overrides,
+		"redundant": redundant,
+	}
+	if rel.Chart.Metadata != nil {
+		result["chart"] = rel.Chart.Metadata.Name
+		result["chartVersion"] = rel.Chart.Metadata.Version
+	}
+	return result, nil
+}
+
+// flattenValues flattens nested values into out, keyed by dotted path. Lists and empty
+// maps are leaves, so a list is reported as overridden as a whole.
+func flattenValues(prefix string, values map[string]interface{}, out map[string]interface{}) {
+	for key, value := range values {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]interface{}); ok && len(nested) > 0 {
+			flattenValues(path, nested, out)
+			continue
+		}
+		out[path] = value
+	}
+}
+
+// valuesEqual compares two values by their JSON encoding, so that numbers decoded as
+// different Go types still compare equal.
+func valuesEqual(a, b interface{}) bool {
+	aJSON, errA := json.Marshal(a)
+	bJSON, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(aJSON) == string(bJSON)
+}
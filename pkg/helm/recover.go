@@ -0,0 +1,113 @@
+package helm
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+// Recovery strategies accepted by RecoverRelease.
+const (
+	RecoverAuto         = "auto"
+	RecoverRollback     = "rollback"
+	RecoverMarkFailed   = "mark-failed"
+	RecoverMarkDeployed = "mark-deployed"
+)
+
+// RecoverRelease unblocks a release stuck in pending-install, pending-upgrade, or
+// pending-rollback after an interrupted operation. The strategy selects how:
+// rollback returns to the last successfully deployed revision, mark-failed records the
+// pending revision as failed, and mark-deployed accepts it as deployed. The auto
+// strategy rolls back when an earlier deployed revision exists and otherwise marks
+// the release failed so that a new install or upgrade can proceed.
+// Returns a map describing the action taken, or an error.
+func (c *Client) RecoverRelease(ctx context.Context, namespace, releaseName, strategy string) (map[string]interface{}, error) {
+	actionConfig := &action.Configuration{}
+	if err := actionConfig.Init(c.restClientGetter(), namespace, os.Getenv("HELM_DRIVER"), actionLog(ctx)); err != nil {
+		return nil, fmt.Errorf("failed to initialize action config: %w", err)
+	}
+
+	rel, err := actionConfig.Releases.Last(releaseName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get release: %w", err)
+	}
+	if !rel.Info.Status.IsPending() {
+		return nil, fmt.Errorf("release '%s' is not in a pending state (status: %s)", releaseName, rel.Info.Status)
+	}
+	previousStatus := rel.Info.Status
+
+	// Find the most recent earlier revision that was deployed successfully
+	history, err := actionConfig.Releases.History(releaseName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get release history: %w", err)
+	}
+	rollbackTarget := 0
+	for _, r := range history {
+		if r.Version < rel.Version && r.Version > rollbackTarget &&
+			(r.Info.Status == release.StatusDeployed || r.Info.Status == release.StatusSuperseded) {
+			rollbackTarget = r.Version
+		}
+	}
+
+	if strategy == "" || strategy == RecoverAuto {
+		strategy = RecoverMarkFailed
+		if rollbackTarget > 0 {
+			strategy = RecoverRollback
+		}
+	}
+
+	result := map[string]interface{}{
+		"release":         releaseName,
+		"namespace":       namespace,
+		"revision":        rel.Version,
+		"previousStatus":  previousStatus.String(),
+		"strategyApplied": strategy,
+	}
+
+	switch strategy {
+	case RecoverMarkFailed:
+		rel.SetStatus(release.StatusFailed, fmt.Sprintf("Marked failed after interrupted %s", previousStatus))
+		if err := actionConfig.Releases.Update(rel); err != nil {
+			return nil, fmt.Errorf("failed to update release status: %w", err)
+		}
+		result["status"] = release.StatusFailed.String()
+	case RecoverMarkDeployed:
+		deployed, err := actionConfig.Releases.DeployedAll(releaseName)
+		if err == nil {
+			for _, r := range deployed {
+				r.SetStatus(release.StatusSuperseded, "Superseded by recovered revision")
+				if err := actionConfig.Releases.Update(r); err != nil {
+					return nil, fmt.Errorf("failed to supersede revision %d: %w", r.Version, err)
+				}
+			}
+		}
+		rel.SetStatus(release.StatusDeployed, fmt.Sprintf("Marked deployed after interrupted %s", previousStatus))
+		if err := actionConfig.Releases.Update(rel); err != nil {
+			return nil, fmt.Errorf("failed to update release status: %w", err)
+		}
+		result["status"] = release.StatusDeployed.String()
+	case RecoverRollback:
+		if rollbackTarget == 0 {
+			return nil, fmt.Errorf("release '%s' has no earlier deployed revision to roll back to", releaseName)
+		}
+		// Record the interrupted revision as failed first so the history stays consistent
+		rel.SetStatus(release.StatusFailed, fmt.Sprintf("Interrupted %s, rolled back to revision %d", previousStatus, rollbackTarget))
+		if err := actionConfig.Releases.Update(rel); err != nil {
+			return nil, fmt.Errorf("failed to update release status: %w", err)
+		}
+		rollback := action.NewRollback(actionConfig)
+		rollback.Version = rollbackTarget
+		if err := rollback.Run(releaseName); err != nil {
+			return nil, fmt.Errorf("failed to rollback release: %w", err)
+		}
+		result["status"] = release.StatusDeployed.String()
+		result["rolledBackTo"] = rollbackTarget
+	default:
+		return nil, fmt.Errorf("invalid strategy '%s': expected one of %s, %s, %s, %s", strategy, RecoverAuto, RecoverRollback, RecoverMarkFailed, RecoverMarkDeployed)
+	}
+
+	return result, nil
+}
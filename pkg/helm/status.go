@@ -0,0 +1,148 @@
+package helm
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"helm.sh/helm/v3/pkg/action"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Resource health states reported by GetReleaseResources.
+const (
+	HealthHealthy  = "healthy"
+	HealthDegraded = "degraded"
+	HealthMissing  = "missing"
+	HealthUnknown  = "unknown"
+)
+
+// GetReleaseResources parses the manifest of a release and looks up the live state of
+// every object it declares, reporting each as healthy, degraded, or missing.
+// Workloads are degraded when their ready replicas fall short of the desired count;
+// other kinds are judged by their phase or conditions where they have one.
+// Returns a map with the release status, a per-object report, and a summary, or an error.
+func (c *Client) GetReleaseResources(ctx context.Context, namespace, releaseName string) (map[string]interface{}, error) {
+	actionConfig := &action.Configuration{}
+	if err := actionConfig.Init(c.restClientGetter(), namespace, os.Getenv("HELM_DRIVER"), log.Printf); err != nil {
+		return nil, fmt.Errorf("failed to initialize action config: %w", err)
+	}
+
+	rel, err := action.NewGet(actionConfig).Run(releaseName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get release: %w", err)
+	}
+
+	infos, err := actionConfig.KubeClient.Build(bytes.NewBufferString(rel.Manifest), false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse release manifest: %w", err)
+	}
+
+	summary := map[string]int{HealthHealthy: 0, HealthDegraded: 0, HealthMissing: 0, HealthUnknown: 0}
+	var resources []map[string]interface{}
+	for _, info := range infos {
+		entry := map[string]interface{}{
+			"kind":      info.Mapping.GroupVersionKind.Kind,
+			"name":      info.Name,
+			"namespace": info.Namespace,
+		}
+
+		health, message := HealthUnknown, ""
+		if err := info.Get(); err != nil {
+			if apierrors.IsNotFound(err) {
+				health, message = HealthMissing, "object not found in the cluster"
+			} else {
+				message = err.Error()
+			}
+		} else if obj, ok := info.Object.(*unstructured.Unstructured); ok {
+			health, message = objectHealth(obj)
+		}
+
+		entry["health"] = health
+		if message != "" {
+			entry["message"] = message
+		}
+		summary[health]++
+		resources = append(resources, entry)
+	}
+
+	return map[string]interface{}{
+		"release":       rel.Name,
+		"namespace":     rel.Namespace,
+		"revision":      rel.Version,
+		"releaseStatus": rel.Info.Status.String(),
+		"resources":     resources,
+		"summary":       summary,
+	}, nil
+}
+
+// objectHealth evaluates the health of a live object from its status.
+func objectHealth(obj *unstructured.Unstructured) (string, string) {
+	switch obj.GetKind() {
+	case "Deployment", "StatefulSet", "ReplicaSet":
+		desired, found, _ := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+		if !found {
+			desired = 1
+		}
+		ready, _, _ := unstructured.NestedInt64(obj.Object, "status", "readyReplicas")
+		if ready < desired {
+			return HealthDegraded, fmt.Sprintf("%d/%d replicas ready", ready, desired)
+		}
+		return HealthHealthy, ""
+	case "DaemonSet":
+		desired, _, _ := unstructured.NestedInt64(obj.Object, "status", "desiredNumberScheduled")
+		ready, _, _ := unstructured.NestedInt64(obj.Object, "status", "numberReady")
+		if ready < desired {
+			return HealthDegraded, fmt.Sprintf("%d/%d pods ready", ready, desired)
+		}
+		return HealthHealthy, ""
+	case "Pod":
+		phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+		if phase != "Running" && phase != "Succeeded" {
+			return HealthDegraded, "pod phase is " + phase
+		}
+		return HealthHealthy, ""
+	case "PersistentVolumeClaim":
+		phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+		if phase != "Bound" {
+			return HealthDegraded, "claim phase is " + phase
+		}
+		return HealthHealthy, ""
+	case "Job":
+		if hasCondition(obj, "Failed") {
+			return HealthDegraded, "job failed"
+		}
+		return HealthHealthy, ""
+	}
+
+	// Fall back to a Ready or Available condition when the kind reports one
+	for _, conditionType := range []string{"Ready", "Available"} {
+		conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+		for _, c := range conditions {
+			condition, ok := c.(map[string]interface{})
+			if !ok || condition["type"] != conditionType {
+				continue
+			}
+			if condition["status"] != "True" {
+				message, _ := condition["message"].(string)
+				return HealthDegraded, message
+			}
+			return HealthHealthy, ""
+		}
+	}
+	return HealthHealthy, ""
+}
+
+// hasCondition reports whether the object has a condition of the given type with status True.
+func hasCondition(obj *unstructured.Unstructured, conditionType string) bool {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		if condition, ok := c.(map[string]interface{}); ok && condition["type"] == conditionType && condition["status"] == "True" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package helm
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/registry"
+)
+
+// loadChart locates a chart by name (repo/chart, OCI reference, local path, or a
+// name in repoURL) at the given version, downloading it if necessary, and loads it.
+func (c *Client) loadChart(namespace, chartName, repoURL, version string) (*chart.Chart, error) {
+	actionConfig := &action.Configuration{}
+	if err := actionConfig.Init(c.restClientGetter(), namespace, os.Getenv("HELM_DRIVER"), log.Printf); err != nil {
+		return nil, fmt.Errorf("failed to initialize action config: %w", err)
+	}
+
+	regClient, err := c.newRegistryClient(registry.ClientOptEnableCache(false))
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize registry client: %w", err)
+	}
+
+	client := action.NewInstall(actionConfig)
+	client.SetRegistryClient(regClient)
+	client.RepoURL = repoURL
+	client.Version = version
+
+	chartPath, err := client.LocateChart(chartName, c.settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate chart: %w", err)
+	}
+
+	chrt, err := loader.Load(chartPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load chart: %w", err)
+	}
+	return chrt, nil
+}
+
+// ValidateValues checks proposed values against a chart's values.schema.json (and
+// the schemas of its subcharts) without installing anything. The values are merged
+// with the chart's defaults first, as they would be during an install.
+// Returns a map reporting whether the values are valid and any schema violations, or an error.
+func (c *Client) ValidateValues(ctx context.Context, chartName, repoURL, version string, values map[string]interface{}) (map[string]interface{}, error) {
+	chrt, err := c.loadChart("default", chartName, repoURL, version)
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]interface{}{
+		"chart":     chrt.Metadata.Name,
+		"version":   chrt.Metadata.Version,
+		"hasSchema": len(chrt.Schema) > 0,
+	}
+
+	if values == nil {
+		values = map[string]interface{}{}
+	}
+	merged, err := chartutil.CoalesceValues(chrt, values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge values with chart defaults: %w", err)
+	}
+
+	violations := []string{}
+	if err := chartutil.ValidateAgainstSchema(chrt, merged); err != nil {
+		for _, line := range strings.Split(err.Error(), "\n") {
+			line = strings.TrimSpace(line)
+			if line != "" {
+				violations = append(violations, line)
+			}
+		}
+	}
+	result["valid"] = len(violations) == 0
+	result["violations"] = violations
+	return result, nil
+}
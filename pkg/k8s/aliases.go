@@ -0,0 +1,71 @@
+package k8s
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// LoadKindAliases reads a YAML or JSON file mapping shorthand names to the kinds they
+// stand for, e.g. "deploy: Deployment" or "vs: VirtualService". Aliases are matched
+// case-insensitively and are returned lowercased.
+func LoadKindAliases(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read kind aliases file: %w", err)
+	}
+	var entries map[string]string
+	if err := yaml.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse kind aliases file '%s': %w", path, err)
+	}
+
+	aliases := make(map[string]string, len(entries))
+	for alias, kind := range entries {
+		alias, kind = strings.TrimSpace(alias), strings.TrimSpace(kind)
+		if alias == "" || kind == "" {
+			return nil, fmt.Errorf("invalid kind alias '%s: %s': expected 'alias: Kind'", alias, kind)
+		}
+		key := strings.ToLower(alias)
+		if existing, ok := aliases[key]; ok && existing != kind {
+			return nil, fmt.Errorf("kind alias '%s' is defined for both %s and %s", alias, existing, kind)
+		}
+		aliases[key] = kind
+	}
+	return aliases, nil
+}
+
+// SetKindAliases configures the shorthand names accepted for kinds, as returned by
+// LoadKindAliases. Every method that takes a kind resolves it with resolveKind.
+func (c *Client) SetKindAliases(aliases map[string]string) {
+	c.kindAliases = aliases
+}
+
+// kindAlias returns the kind an alias stands for, or "" if kind is not an alias.
+func (c *Client) kindAlias(kind string) string {
+	return c.kindAliases[strings.ToLower(kind)]
+}
+
+// resolveKind returns the kind a kind name given by a caller refers to: the kind an
+// alias stands for, or the name itself. Methods that treat kinds differently resolve
+// their kind with it first, so that aliases take the same paths as the kinds they
+// stand for. A name that is both an alias and a kind the cluster serves is the kind,
+// as in getCachedGVR; if discovery fails, the alias is trusted.
+func (c *Client) resolveKind(kind string) string {
+	aliased := c.kindAlias(kind)
+	if aliased == "" {
+		return kind
+	}
+	if _, err := c.getCachedGVR(kind); err != nil {
+		return aliased
+	}
+	c.cacheLock.RLock()
+	defer c.cacheLock.RUnlock()
+	// A reload may have cleared the cache since the lookup; the kind is resolved again
+	// by the call it is passed to
+	if resolved, ok := c.kindCache[kind]; ok {
+		return resolved
+	}
+	return kind
+}
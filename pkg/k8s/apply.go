@@ -0,0 +1,188 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// decodeManifests splits a multi-document YAML (or JSON) bundle into unstructured objects.
+// Empty documents are skipped.
+func decodeManifests(manifests string) ([]*unstructured.Unstructured, error) {
+	decoder := utilyaml.NewYAMLOrJSONDecoder(strings.NewReader(manifests), 4096)
+
+	var objects []*unstructured.Unstructured
+	for {
+		obj := &unstructured.Unstructured{}
+		if err := decoder.Decode(&obj.Object); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("failed to parse manifest document %d: %w", len(objects)+1, err)
+		}
+		if len(obj.Object) == 0 {
+			continue
+		}
+		if obj.GetKind() == "" || obj.GetName() == "" {
+			return nil, fmt.Errorf("manifest document %d must include kind and metadata.name", len(objects)+1)
+		}
+		objects = append(objects, obj)
+	}
+	return objects, nil
+}
+
+// objectKey returns a stable identity for an object used to compare sets of resources.
+func objectKey(kind, namespace, name string) string {
+	return kind + "/" + namespace + "/" + name
+}
+
+// applyObject creates or updates a single object. Namespaced objects without a
+// namespace are placed in the given namespace; the resolved namespace is set on obj.
+func (c *Client) applyObject(ctx context.Context, namespace string, obj *unstructured.Unstructured) error {
+	kind := obj.GetKind()
+	namespaced, err := c.isNamespaced(kind)
+	if err != nil {
+		return err
+	}
+
+	objNamespace := ""
+	if namespaced {
+		objNamespace = obj.GetNamespace()
+		if objNamespace == "" {
+			objNamespace = namespace
+		}
+	}
+	obj.SetNamespace(objNamespace)
+
+	manifestJSON, err := json.Marshal(obj.Object)
+	if err != nil {
+		return err
+	}
+	_, err = c.CreateOrUpdateResourceYAML(ctx, objNamespace, string(manifestJSON), kind, "")
+	return err
+}
+
+// ApplyAndPrune applies a bundle of manifests and deletes previously applied objects that
+// are no longer part of the bundle, similar to `kubectl apply --prune`.
+// Every applied object is labeled with the equality-based pruneSelector so that it can be
+// found on the next run. Objects matching the selector whose kind appears in the bundle or
+// in pruneKinds, but which are not in the bundle, are deleted.
+// Namespaced objects without a namespace are placed in the given namespace.
+// Returns a map listing applied and pruned objects along with any per-object errors.
+func (c *Client) ApplyAndPrune(ctx context.Context, namespace, manifests, pruneSelector string, pruneKinds []string) (map[string]interface{}, error) {
+	if pruneSelector == "" {
+		return nil, fmt.Errorf("a prune label selector is required")
+	}
+	selector, err := labels.Parse(pruneSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid prune selector: %w", err)
+	}
+	pruneLabels, err := labels.ConvertSelectorToLabelsMap(pruneSelector)
+	if err != nil {
+		return nil, fmt.Errorf("prune selector must only use equality requirements: %w", err)
+	}
+
+	objects, err := decodeManifests(manifests)
+	if err != nil {
+		return nil, err
+	}
+	if len(objects) == 0 {
+		return nil, fmt.Errorf("no manifests to apply")
+	}
+
+	applied := map[string]bool{}
+	kinds := map[string]bool{}
+	for _, kind := range pruneKinds {
+		kinds[c.resolveKind(kind)] = true
+	}
+
+	var appliedObjects []string
+	var errs []string
+	for _, obj := range objects {
+		kind := obj.GetKind()
+
+		objLabels := obj.GetLabels()
+		if objLabels == nil {
+			objLabels = map[string]string{}
+		}
+		for key, value := range pruneLabels {
+			objLabels[key] = value
+		}
+		obj.SetLabels(objLabels)
+
+		if err := c.applyObject(ctx, namespace, obj); err != nil {
+			errs = append(errs, fmt.Sprintf("%s/%s: %v", kind, obj.GetName(), err))
+			continue
+		}
+
+		key := objectKey(kind, obj.GetNamespace(), obj.GetName())
+		applied[key] = true
+		kinds[kind] = true
+		appliedObjects = append(appliedObjects, key)
+	}
+
+	// Don't prune anything if applying failed: a partially applied bundle would
+	// otherwise delete objects that were meant to be kept.
+	var prunedObjects []string
+	if len(errs) == 0 {
+		for kind := range kinds {
+			pruned, err := c.pruneKind(ctx, kind, namespace, selector, applied, false)
+			prunedObjects = append(prunedObjects, pruned...)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("prune %s: %v", kind, err))
+			}
+		}
+	}
+
+	return map[string]interface{}{
+		"applied": appliedObjects,
+		"pruned":  prunedObjects,
+		"errors":  errs,
+	}, nil
+}
+
+// pruneKind deletes objects of a kind matching selector that are not in the keep set.
+// Namespaced kinds are only pruned within the given namespace. When dryRun is true
+// nothing is deleted and the objects that would be are returned.
+func (c *Client) pruneKind(ctx context.Context, kind, namespace string, selector labels.Selector, keep map[string]bool, dryRun bool) ([]string, error) {
+	gvr, err := c.getCachedGVR(kind)
+	if err != nil {
+		return nil, err
+	}
+	namespaced, err := c.isNamespaced(kind)
+	if err != nil {
+		return nil, err
+	}
+	if !namespaced {
+		namespace = ""
+	}
+
+	list, err := c.dynamicClient().Resource(*gvr).Namespace(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", kind, err)
+	}
+
+	var pruned []string
+	for _, item := range list.Items {
+		key := objectKey(kind, item.GetNamespace(), item.GetName())
+		if keep[key] {
+			continue
+		}
+		if dryRun {
+			pruned = append(pruned, key)
+			continue
+		}
+		if err := c.dynamicClient().Resource(*gvr).Namespace(item.GetNamespace()).Delete(ctx, item.GetName(), metav1.DeleteOptions{}); err != nil {
+			return pruned, fmt.Errorf("failed to delete %s: %w", key, err)
+		}
+		pruned = append(pruned, key)
+	}
+	return pruned, nil
+}
@@ -0,0 +1,307 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// GetResourceBundle returns a resource together with the objects most relevant to
+// investigating it, resolved through owner references and label selectors:
+//   - Deployment: its ReplicaSets, their pods, and the Services selecting those pods
+//   - StatefulSet, DaemonSet, ReplicaSet, Job: their pods and the Services selecting them
+//   - Service: the pods it selects
+//   - Pod: its controlling owner and the Services selecting it
+//
+// Recent events for the resource and every related object are included, newest first.
+// Other kinds are returned with their events only. Failures to resolve individual
+// relationships are reported in an errors list. Sensitive fields are masked.
+// Returns a map containing the resource, its related objects, and events, or an error.
+func (c *Client) GetResourceBundle(ctx context.Context, kind, name, namespace string) (map[string]interface{}, error) {
+	kind = c.resolveKind(kind)
+	resource, err := c.GetResource(ctx, kind, name, namespace, ConsistencyStrong)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := map[string]interface{}{
+		"resource": c.MaskSensitiveFields(resource),
+	}
+	var errs []string
+
+	// uids collects every object in the bundle so that events can be matched to them
+	uids := map[types.UID]bool{}
+	if metadata, ok := resource["metadata"].(map[string]interface{}); ok {
+		if uid, ok := metadata["uid"].(string); ok {
+			uids[types.UID(uid)] = true
+		}
+	}
+
+	var podLabels labels.Set
+	var pods []corev1.Pod
+	switch kind {
+	case "Deployment":
+		deployment, err := c.clientset().AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get deployment '%s': %w", name, err)
+		}
+		podLabels = deployment.Spec.Template.Labels
+
+		replicaSets, err := c.ownedReplicaSets(ctx, deployment)
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+		owners := map[types.UID]bool{}
+		var summaries []map[string]interface{}
+		for _, rs := range replicaSets {
+			owners[rs.UID] = true
+			uids[rs.UID] = true
+			summaries = append(summaries, replicaSetSummary(&rs))
+		}
+		bundle["replicaSets"] = summaries
+
+		pods, err = c.ownedPods(ctx, namespace, deployment.Spec.Selector, owners)
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+	case "StatefulSet", "DaemonSet", "ReplicaSet", "Job":
+		selector, uid, templateLabels, err := c.workloadSelector(ctx, kind, name, namespace)
+		if err != nil {
+			return nil, err
+		}
+		podLabels = templateLabels
+		pods, err = c.ownedPods(ctx, namespace, selector, map[types.UID]bool{uid: true})
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+	case "Service":
+		service, err := c.clientset().CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get service '%s': %w", name, err)
+		}
+		if len(service.Spec.Selector) > 0 {
+			podList, err := c.clientset().CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+				LabelSelector: labels.SelectorFromSet(service.Spec.Selector).String(),
+			})
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("failed to list pods for service '%s': %v", name, err))
+			} else {
+				pods = podList.Items
+			}
+		}
+	case "Pod":
+		pod, err := c.clientset().CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pod '%s': %w", name, err)
+		}
+		podLabels = pod.Labels
+		if owner := metav1.GetControllerOf(pod); owner != nil {
+			bundle["owner"] = map[string]interface{}{"kind": owner.Kind, "name": owner.Name}
+			uids[owner.UID] = true
+		}
+	}
+
+	if len(pods) > 0 {
+		var summaries []map[string]interface{}
+		for i := range pods {
+			uids[pods[i].UID] = true
+			summaries = append(summaries, podSummary(&pods[i]))
+		}
+		bundle["pods"] = summaries
+	}
+
+	if len(podLabels) > 0 {
+		services, err := c.selectingServices(ctx, namespace, podLabels)
+		if err != nil {
+			errs = append(errs, err.Error())
+		} else {
+			bundle["services"] = services
+		}
+	}
+
+	events, err := c.eventsForUIDs(ctx, namespace, uids)
+	if err != nil {
+		errs = append(errs, err.Error())
+	} else {
+		bundle["events"] = events
+	}
+
+	if len(errs) > 0 {
+		bundle["errors"] = errs
+	}
+	return bundle, nil
+}
+
+// ownedReplicaSets returns the ReplicaSets controlled by a Deployment, newest first.
+func (c *Client) ownedReplicaSets(ctx context.Context, deployment *appsv1.Deployment) ([]appsv1.ReplicaSet, error) {
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector on deployment '%s': %w", deployment.Name, err)
+	}
+	list, err := c.clientset().AppsV1().ReplicaSets(deployment.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list replicasets for deployment '%s': %w", deployment.Name, err)
+	}
+
+	var owned []appsv1.ReplicaSet
+	for _, rs := range list.Items {
+		if owner := metav1.GetControllerOf(&rs); owner != nil && owner.UID == deployment.UID {
+			owned = append(owned, rs)
+		}
+	}
+	sort.Slice(owned, func(i, j int) bool {
+		return owned[i].CreationTimestamp.After(owned[j].CreationTimestamp.Time)
+	})
+	return owned, nil
+}
+
+// ownedPods returns the pods matching selector whose controller is one of owners.
+func (c *Client) ownedPods(ctx context.Context, namespace string, labelSelector *metav1.LabelSelector, owners map[types.UID]bool) ([]corev1.Pod, error) {
+	if labelSelector == nil || len(owners) == 0 {
+		return nil, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pod selector: %w", err)
+	}
+	list, err := c.clientset().CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	var owned []corev1.Pod
+	for _, pod := range list.Items {
+		if owner := metav1.GetControllerOf(&pod); owner != nil && owners[owner.UID] {
+			owned = append(owned, pod)
+		}
+	}
+	return owned, nil
+}
+
+// workloadSelector returns the pod selector, UID, and pod template labels of a
+// StatefulSet, DaemonSet, ReplicaSet, or Job.
+func (c *Client) workloadSelector(ctx context.Context, kind, name, namespace string) (*metav1.LabelSelector, types.UID, labels.Set, error) {
+	switch kind {
+	case "StatefulSet":
+		s, err := c.clientset().AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, "", nil, fmt.Errorf("failed to get statefulset '%s': %w", name, err)
+		}
+		return s.Spec.Selector, s.UID, s.Spec.Template.Labels, nil
+	case "DaemonSet":
+		d, err := c.clientset().AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, "", nil, fmt.Errorf("failed to get daemonset '%s': %w", name, err)
+		}
+		return d.Spec.Selector, d.UID, d.Spec.Template.Labels, nil
+	case "ReplicaSet":
+		rs, err := c.clientset().AppsV1().ReplicaSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, "", nil, fmt.Errorf("failed to get replicaset '%s': %w", name, err)
+		}
+		return rs.Spec.Selector, rs.UID, rs.Spec.Template.Labels, nil
+	case "Job":
+		j, err := c.clientset().BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, "", nil, fmt.Errorf("failed to get job '%s': %w", name, err)
+		}
+		return j.Spec.Selector, j.UID, j.Spec.Template.Labels, nil
+	}
+	return nil, "", nil, fmt.Errorf("kind '%s' has no pod selector", kind)
+}
+
+// selectingServices returns summaries of the Services in a namespace whose selector
+// matches the given pod labels.
+func (c *Client) selectingServices(ctx context.Context, namespace string, podLabels labels.Set) ([]map[string]interface{}, error) {
+	list, err := c.clientset().CoreV1().Services(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services: %w", err)
+	}
+
+	services := []map[string]interface{}{}
+	for _, service := range list.Items {
+		if len(service.Spec.Selector) == 0 || !labels.SelectorFromSet(service.Spec.Selector).Matches(podLabels) {
+			continue
+		}
+		var ports []string
+		for _, port := range service.Spec.Ports {
+			ports = append(ports, fmt.Sprintf("%d->%s/%s", port.Port, port.TargetPort.String(), port.Protocol))
+		}
+		services = append(services, map[string]interface{}{
+			"name":      service.Name,
+			"type":      service.Spec.Type,
+			"clusterIP": service.Spec.ClusterIP,
+			"ports":     ports,
+		})
+	}
+	return services, nil
+}
+
+// eventsForUIDs returns the events in a namespace involving any of the given objects,
+// newest first.
+func (c *Client) eventsForUIDs(ctx context.Context, namespace string, uids map[types.UID]bool) ([]map[string]interface{}, error) {
+	eventList, err := c.clientset().CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve events: %w", err)
+	}
+
+	var matching []corev1.Event
+	for _, event := range eventList.Items {
+		if uids[event.InvolvedObject.UID] {
+			matching = append(matching, event)
+		}
+	}
+	sort.SliceStable(matching, func(i, j int) bool {
+		return eventLastSeen(matching[i]).After(eventLastSeen(matching[j]))
+	})
+
+	events := []map[string]interface{}{}
+	for _, event := range matching {
+		events = append(events, map[string]interface{}{
+			"type":     event.Type,
+			"reason":   event.Reason,
+			"object":   event.InvolvedObject.Kind + "/" + event.InvolvedObject.Name,
+			"message":  event.Message,
+			"count":    event.Count,
+			"lastSeen": eventLastSeen(event),
+		})
+	}
+	return events, nil
+}
+
+// replicaSetSummary describes a ReplicaSet in a bundle.
+func replicaSetSummary(rs *appsv1.ReplicaSet) map[string]interface{} {
+	desired := int32(0)
+	if rs.Spec.Replicas != nil {
+		desired = *rs.Spec.Replicas
+	}
+	return map[string]interface{}{
+		"name":     rs.Name,
+		"revision": rs.Annotations[deploymentRevisionAnnotation],
+		"desired":  desired,
+		"ready":    rs.Status.ReadyReplicas,
+		"created":  rs.CreationTimestamp.Time,
+	}
+}
+
+// podSummary describes a pod in a bundle.
+func podSummary(pod *corev1.Pod) map[string]interface{} {
+	restarts := int32(0)
+	for _, status := range pod.Status.ContainerStatuses {
+		restarts += status.RestartCount
+	}
+	return map[string]interface{}{
+		"name":     pod.Name,
+		"phase":    pod.Status.Phase,
+		"ready":    isPodReady(pod),
+		"restarts": restarts,
+		"node":     pod.Spec.NodeName,
+		"podIP":    pod.Status.PodIP,
+	}
+}
@@ -0,0 +1,153 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetClusterCapacity summarizes allocatable CPU and memory across schedulable nodes and
+// compares it with the requests and limits of the non-terminated pods running on them.
+// Pods on cordoned nodes are left out with their nodes, and the requests of pods not
+// yet scheduled to any node are reported separately, as demand the cluster has not
+// placed.
+// Returns a map containing cluster totals, utilization percentages, and overcommit
+// ratios, or an error.
+func (c *Client) GetClusterCapacity(ctx context.Context) (map[string]interface{}, error) {
+	nodes, err := c.clientset().CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	pods, err := c.clientset().CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	allocatableCPU := resource.NewMilliQuantity(0, resource.DecimalSI)
+	allocatableMemory := resource.NewQuantity(0, resource.BinarySI)
+	schedulable := map[string]bool{}
+	for _, node := range nodes.Items {
+		if node.Spec.Unschedulable {
+			continue
+		}
+		schedulable[node.Name] = true
+		allocatableCPU.Add(*node.Status.Allocatable.Cpu())
+		allocatableMemory.Add(*node.Status.Allocatable.Memory())
+	}
+
+	requestedCPU := resource.NewMilliQuantity(0, resource.DecimalSI)
+	requestedMemory := resource.NewQuantity(0, resource.BinarySI)
+	limitCPU := resource.NewMilliQuantity(0, resource.DecimalSI)
+	limitMemory := resource.NewQuantity(0, resource.BinarySI)
+	unscheduledCPU := resource.NewMilliQuantity(0, resource.DecimalSI)
+	unscheduledMemory := resource.NewQuantity(0, resource.BinarySI)
+	activePods, unscheduledPods := 0, 0
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		requests, limits := podRequestsAndLimits(pod)
+		if pod.Spec.NodeName == "" {
+			unscheduledPods++
+			unscheduledCPU.Add(*requests.Cpu())
+			unscheduledMemory.Add(*requests.Memory())
+			continue
+		}
+		if !schedulable[pod.Spec.NodeName] {
+			continue
+		}
+		activePods++
+		requestedCPU.Add(*requests.Cpu())
+		requestedMemory.Add(*requests.Memory())
+		limitCPU.Add(*limits.Cpu())
+		limitMemory.Add(*limits.Memory())
+	}
+
+	return map[string]interface{}{
+		"nodes":            len(nodes.Items),
+		"schedulableNodes": len(schedulable),
+		"activePods":       activePods,
+		"unscheduled": map[string]interface{}{
+			"pods":           unscheduledPods,
+			"cpuRequests":    unscheduledCPU.String(),
+			"memoryRequests": unscheduledMemory.String(),
+		},
+		"cpu": map[string]interface{}{
+			"allocatable":         allocatableCPU.String(),
+			"requests":            requestedCPU.String(),
+			"limits":              limitCPU.String(),
+			"requestsPercent":     percentOf(requestedCPU.MilliValue(), allocatableCPU.MilliValue()),
+			"limitsPercent":       percentOf(limitCPU.MilliValue(), allocatableCPU.MilliValue()),
+			"limitsOvercommitted": limitCPU.Cmp(*allocatableCPU) > 0,
+		},
+		"memory": map[string]interface{}{
+			"allocatable":         allocatableMemory.String(),
+			"requests":            requestedMemory.String(),
+			"limits":              limitMemory.String(),
+			"requestsPercent":     percentOf(requestedMemory.Value(), allocatableMemory.Value()),
+			"limitsPercent":       percentOf(limitMemory.Value(), allocatableMemory.Value()),
+			"limitsOvercommitted": limitMemory.Cmp(*allocatableMemory) > 0,
+		},
+	}, nil
+}
+
+// podRequestsAndLimits computes the effective resource requests and limits of a pod
+// the same way the scheduler does: the larger of the sum of all app containers and
+// the largest init container, plus any pod overhead.
+func podRequestsAndLimits(pod *corev1.Pod) (corev1.ResourceList, corev1.ResourceList) {
+	requests := corev1.ResourceList{}
+	limits := corev1.ResourceList{}
+
+	for _, container := range pod.Spec.Containers {
+		addResourceList(requests, container.Resources.Requests)
+		addResourceList(limits, container.Resources.Limits)
+	}
+
+	for _, container := range pod.Spec.InitContainers {
+		maxResourceList(requests, container.Resources.Requests)
+		maxResourceList(limits, container.Resources.Limits)
+	}
+
+	if pod.Spec.Overhead != nil {
+		addResourceList(requests, pod.Spec.Overhead)
+		addResourceList(limits, pod.Spec.Overhead)
+	}
+
+	return requests, limits
+}
+
+// addResourceList adds every quantity in newList to list.
+func addResourceList(list, newList corev1.ResourceList) {
+	for name, quantity := range newList {
+		if value, ok := list[name]; ok {
+			value.Add(quantity)
+			list[name] = value
+		} else {
+			list[name] = quantity.DeepCopy()
+		}
+	}
+}
+
+// maxResourceList sets every quantity in list to the greater of its current value
+// and the value in newList.
+func maxResourceList(list, newList corev1.ResourceList) {
+	for name, quantity := range newList {
+		if value, ok := list[name]; !ok || quantity.Cmp(value) > 0 {
+			list[name] = quantity.DeepCopy()
+		}
+	}
+}
+
+// percentOf returns part as a percentage of total, rounded to two decimals.
+// A zero total yields zero.
+func percentOf(part, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(int64(float64(part)/float64(total)*10000)) / 100
+}
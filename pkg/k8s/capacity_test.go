@@ -0,0 +1,73 @@
+package k8s_test
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/reza-gholizade/k8s-mcp-server/pkg/k8s/k8stest"
+)
+
+// capacityPod returns a pod on a node, or pending if nodeName is empty, requesting cpu.
+func capacityPod(name, nodeName, cpu string, phase corev1.PodPhase) *corev1.Pod {
+	return &corev1.Pod{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: k8stest.Namespace},
+		Spec: corev1.PodSpec{
+			NodeName: nodeName,
+			Containers: []corev1.Container{{
+				Name:      "main",
+				Image:     "busybox",
+				Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)}},
+			}},
+		},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+}
+
+func TestGetClusterCapacity(t *testing.T) {
+	objects := k8stest.Objects()
+	cordoned := seeded[*corev1.Node](t, objects).DeepCopy()
+	cordoned.Name = "node-2"
+	cordoned.Spec.Unschedulable = true
+	objects = append(objects,
+		cordoned,
+		// Left out with its cordoned node
+		capacityPod("on-cordoned", "node-2", "1", corev1.PodRunning),
+		// Reported separately as demand not yet placed
+		capacityPod("pending", "", "500m", corev1.PodPending),
+		// Terminated pods hold no resources
+		capacityPod("done", k8stest.NodeName, "2", corev1.PodSucceeded),
+	)
+
+	result, err := k8stest.NewFakeClient(objects...).GetClusterCapacity(context.Background())
+	if err != nil {
+		t.Fatalf("GetClusterCapacity: %v", err)
+	}
+
+	if result["nodes"] != 2 || result["schedulableNodes"] != 1 {
+		t.Errorf("nodes: got %v (%v schedulable), want 2 (1 schedulable)", result["nodes"], result["schedulableNodes"])
+	}
+	if result["activePods"] != len(k8stest.PodNames) {
+		t.Errorf("activePods: got %v, want %d", result["activePods"], len(k8stest.PodNames))
+	}
+
+	// The seeded pods request 100m CPU and 128Mi memory each, on a node with 3800m
+	// and 15Gi allocatable
+	cpu := result["cpu"].(map[string]interface{})
+	if cpu["allocatable"] != "3800m" || cpu["requests"] != "200m" {
+		t.Errorf("cpu: got %v", cpu)
+	}
+	memory := result["memory"].(map[string]interface{})
+	if memory["allocatable"] != "15Gi" || memory["requests"] != "256Mi" || memory["limits"] != "512Mi" || memory["limitsOvercommitted"] != false {
+		t.Errorf("memory: got %v", memory)
+	}
+
+	unscheduled := result["unscheduled"].(map[string]interface{})
+	if unscheduled["pods"] != 1 || unscheduled["cpuRequests"] != "500m" {
+		t.Errorf("unscheduled: got %v, want 1 pod requesting 500m", unscheduled)
+	}
+}
@@ -0,0 +1,220 @@
+package k8s
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// DefaultCertificateWarnDays is how many days before expiry CheckCertificates reports
+// a certificate as expiring soon, unless told otherwise.
+const DefaultCertificateWarnDays = 30
+
+// CheckCertificates reports the expiry of the TLS certificates in a namespace, or in
+// all namespaces if namespace is empty: those in Secrets of type kubernetes.io/tls,
+// and those referenced by the tls section of Ingresses, with the Ingresses and hosts
+// each one serves. Every certificate of a Secret's chain is parsed; the leaf decides
+// the Secret's status, which is expired, expiringSoon (within warnDays), notYetValid,
+// valid, or invalid if tls.crt holds no parsable certificate. Hosts an Ingress serves
+// with a certificate that does not cover them, and Secrets that Ingresses reference
+// but that do not exist, are reported too. Private keys are never read out.
+// Returns a map with a summary and the certificates ordered by expiry, or an error.
+func (c *Client) CheckCertificates(ctx context.Context, namespace string, warnDays int) (map[string]interface{}, error) {
+	secrets, err := c.clientset().CoreV1().Secrets(namespace).List(ctx, metav1.ListOptions{FieldSelector: "type=" + string(corev1.SecretTypeTLS)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list TLS secrets: %w", err)
+	}
+	ingresses, err := c.clientset().NetworkingV1().Ingresses(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ingresses: %w", err)
+	}
+
+	// Hosts served with each secret, by ingress
+	type ingressRef struct {
+		ingress string
+		hosts   []string
+	}
+	refs := map[types.NamespacedName][]ingressRef{}
+	for _, ingress := range ingresses.Items {
+		for _, tls := range ingress.Spec.TLS {
+			// Without a secret the ingress controller's default certificate is used
+			if tls.SecretName == "" {
+				continue
+			}
+			key := types.NamespacedName{Namespace: ingress.Namespace, Name: tls.SecretName}
+			refs[key] = append(refs[key], ingressRef{ingress: ingress.Name, hosts: tls.Hosts})
+		}
+	}
+
+	now := time.Now()
+	counts := map[string]int{}
+	certificates := []map[string]interface{}{}
+	addSecret := func(secret *corev1.Secret) {
+		key := types.NamespacedName{Namespace: secret.Namespace, Name: secret.Name}
+		entry := map[string]interface{}{
+			"secret":    secret.Name,
+			"namespace": secret.Namespace,
+		}
+		chain, parseErr := parseCertificateChain(secret.Data[corev1.TLSCertKey])
+		if parseErr != nil {
+			entry["status"] = "invalid"
+			entry["error"] = parseErr.Error()
+		} else {
+			leaf := chain[0]
+			entry["status"] = certificateStatus(leaf, now, warnDays)
+			entry["notAfter"] = leaf.NotAfter
+			entry["daysRemaining"] = int(math.Floor(leaf.NotAfter.Sub(now).Hours() / 24))
+			var details []map[string]interface{}
+			for _, cert := range chain {
+				details = append(details, certificateDetails(cert, now, warnDays))
+			}
+			entry["chain"] = details
+		}
+
+		var ingressEntries []map[string]interface{}
+		for _, ref := range refs[key] {
+			ingressEntry := map[string]interface{}{"name": ref.ingress, "hosts": ref.hosts}
+			if parseErr == nil {
+				var uncovered []string
+				for _, host := range ref.hosts {
+					if chain[0].VerifyHostname(host) != nil {
+						uncovered = append(uncovered, host)
+					}
+				}
+				if len(uncovered) > 0 {
+					ingressEntry["hostsNotCovered"] = uncovered
+				}
+			}
+			ingressEntries = append(ingressEntries, ingressEntry)
+		}
+		if len(ingressEntries) > 0 {
+			entry["ingresses"] = ingressEntries
+		}
+		delete(refs, key)
+
+		counts[entry["status"].(string)]++
+		certificates = append(certificates, entry)
+	}
+	for i := range secrets.Items {
+		addSecret(&secrets.Items[i])
+	}
+
+	// Secrets referenced by ingresses that are not of type kubernetes.io/tls, or missing
+	missing := []map[string]interface{}{}
+	for key, ingressRefs := range refs {
+		secret, err := c.clientset().CoreV1().Secrets(key.Namespace).Get(ctx, key.Name, metav1.GetOptions{})
+		if err == nil {
+			addSecret(secret)
+			continue
+		}
+		var ingressNames []string
+		for _, ref := range ingressRefs {
+			ingressNames = append(ingressNames, ref.ingress)
+		}
+		entry := map[string]interface{}{"secret": key.Name, "namespace": key.Namespace, "ingresses": ingressNames}
+		if errors.IsNotFound(err) {
+			entry["error"] = "secret not found"
+		} else {
+			entry["error"] = err.Error()
+		}
+		missing = append(missing, entry)
+	}
+	sort.Slice(missing, func(i, j int) bool {
+		return missing[i]["namespace"].(string)+"/"+missing[i]["secret"].(string) < missing[j]["namespace"].(string)+"/"+missing[j]["secret"].(string)
+	})
+
+	// Soonest expiry first, certificates that could not be parsed last
+	sort.SliceStable(certificates, func(i, j int) bool {
+		a, aOK := certificates[i]["notAfter"].(time.Time)
+		b, bOK := certificates[j]["notAfter"].(time.Time)
+		if aOK != bOK {
+			return aOK
+		}
+		return aOK && a.Before(b)
+	})
+
+	return map[string]interface{}{
+		"namespace": namespace,
+		"warnDays":  warnDays,
+		"summary": map[string]interface{}{
+			"total":          len(certificates),
+			"expired":        counts["expired"],
+			"expiringSoon":   counts["expiringSoon"],
+			"notYetValid":    counts["notYetValid"],
+			"valid":          counts["valid"],
+			"invalid":        counts["invalid"],
+			"missingSecrets": len(missing),
+		},
+		"certificates":   certificates,
+		"missingSecrets": missing,
+	}, nil
+}
+
+// parseCertificateChain parses the PEM-encoded certificates in data, leaf first.
+// Blocks other than certificates are skipped.
+func parseCertificateChain(data []byte) ([]*x509.Certificate, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("secret has no %s", corev1.TLSCertKey)
+	}
+	var chain []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate: %w", err)
+		}
+		chain = append(chain, cert)
+	}
+	if len(chain) == 0 {
+		return nil, fmt.Errorf("%s holds no PEM-encoded certificate", corev1.TLSCertKey)
+	}
+	return chain, nil
+}
+
+// certificateStatus classifies a certificate's validity period at now.
+func certificateStatus(cert *x509.Certificate, now time.Time, warnDays int) string {
+	switch {
+	case now.After(cert.NotAfter):
+		return "expired"
+	case now.Before(cert.NotBefore):
+		return "notYetValid"
+	case cert.NotAfter.Before(now.AddDate(0, 0, warnDays)):
+		return "expiringSoon"
+	default:
+		return "valid"
+	}
+}
+
+// certificateDetails summarizes a certificate: its subject, issuer, names, and
+// validity period.
+func certificateDetails(cert *x509.Certificate, now time.Time, warnDays int) map[string]interface{} {
+	details := map[string]interface{}{
+		"subject":      cert.Subject.String(),
+		"issuer":       cert.Issuer.String(),
+		"serialNumber": cert.SerialNumber.String(),
+		"notBefore":    cert.NotBefore,
+		"notAfter":     cert.NotAfter,
+		"isCA":         cert.IsCA,
+		"status":       certificateStatus(cert, now, warnDays),
+	}
+	if len(cert.DNSNames) > 0 {
+		details["dnsNames"] = cert.DNSNames
+	}
+	return details
+}
@@ -7,13 +7,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log"
+	"math"
 	"os"
-	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/yaml"
 
@@ -23,102 +27,229 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/metadata"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
-	"k8s.io/client-go/util/homedir"
 	metricsclientset "k8s.io/metrics/pkg/client/clientset/versioned"
 )
 
-// Client encapsulates Kubernetes client functionality including dynamic,
+// Client encapsulates Kubernetes client functionality including dynamic, metadata,
 // discovery, and metrics clients.
 // It also caches API resource information for performance.
 type Client struct {
-	clientset        *kubernetes.Clientset
-	dynamicClient    dynamic.Interface
-	discoveryClient  *discovery.DiscoveryClient
-	metricsClientset *metricsclientset.Clientset // Add metrics client
-	restConfig       *rest.Config
+	// conns is replaced as a whole by Reload; read it through the accessors below
+	conns            atomic.Pointer[connections]
 	apiResourceCache map[string]*schema.GroupVersionResource
+	namespacedCache  map[string]bool
+	kindCache        map[string]string
 	cacheLock        sync.RWMutex
+	maskRules        []MaskRule
+	// suspendAnnotations override DefaultSuspendAnnotations when set
+	suspendAnnotations []SuspendAnnotation
+	// kindAliases map lowercased shorthand names to kinds, see SetKindAliases
+	kindAliases map[string]string
+	// snapshots hold the objects captured by SnapshotResource, oldest first
+	snapshots    []*resourceSnapshot
+	snapshotSeq  int
+	snapshotLock sync.Mutex
 }
 
-// BuildKubernetesConfig builds a Kubernetes REST config using multiple authentication methods.
-// It supports the following methods in order of priority:
+// connections are the clients a Client reaches the cluster through. They are never
+// modified once built, so that Reload can swap them while calls are reading them.
+type connections struct {
+	clientset        kubernetes.Interface
+	dynamicClient    dynamic.Interface
+	metadataClient   metadata.Interface
+	discoveryClient  discovery.CachedDiscoveryInterface
+	restMapper       meta.RESTMapper
+	metricsClientset metricsclientset.Interface
+	restConfig       *rest.Config
+}
+
+// ReadConsistency selects how fresh the data returned by read operations must be.
+type ReadConsistency string
+
+const (
+	// ConsistencyStrong reads the latest state from etcd (the default).
+	ConsistencyStrong ReadConsistency = "strong"
+	// ConsistencyCached allows the API server to answer from its watch cache
+	// (ResourceVersion "0"), which is cheaper for large lists but may be slightly stale.
+	ConsistencyCached ReadConsistency = "cached"
+)
+
+// ParseReadConsistency validates a consistency name. An empty value selects strong reads.
+func ParseReadConsistency(value string) (ReadConsistency, error) {
+	switch ReadConsistency(value) {
+	case "", ConsistencyStrong:
+		return ConsistencyStrong, nil
+	case ConsistencyCached:
+		return ConsistencyCached, nil
+	}
+	return "", fmt.Errorf("invalid consistency '%s': expected '%s' or '%s'", value, ConsistencyStrong, ConsistencyCached)
+}
+
+// ParseTimeBound parses a point in time given either as an RFC3339 timestamp or
+// as a duration relative to now (e.g. "1h" means one hour ago).
+// An empty value returns the zero time, meaning no bound.
+func ParseTimeBound(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time '%s': expected an RFC3339 timestamp or a duration such as '1h'", value)
+	}
+	return now.Add(-d), nil
+}
+
+// AuthMethod selects how BuildKubernetesConfig authenticates to the cluster.
+type AuthMethod string
+
+// Authentication methods accepted by BuildKubernetesConfig.
+const (
+	// AuthMethodAuto tries each method in order of priority and uses the first available.
+	AuthMethodAuto AuthMethod = "auto"
+	// AuthMethodKubeconfigData uses kubeconfig content from the KUBECONFIG_DATA environment variable.
+	AuthMethodKubeconfigData AuthMethod = "kubeconfig-data"
+	// AuthMethodServerToken uses the KUBERNETES_SERVER and KUBERNETES_TOKEN environment variables.
+	AuthMethodServerToken AuthMethod = "server-token"
+	// AuthMethodInCluster uses the pod's service account.
+	AuthMethodInCluster AuthMethod = "in-cluster"
+	// AuthMethodKubeconfigFile uses a kubeconfig file (provided, KUBECONFIG, or ~/.kube/config).
+	AuthMethodKubeconfigFile AuthMethod = "kubeconfig-file"
+)
+
+// ParseAuthMethod validates an authentication method name. An empty name means AuthMethodAuto.
+func ParseAuthMethod(name string) (AuthMethod, error) {
+	switch method := AuthMethod(name); method {
+	case "":
+		return AuthMethodAuto, nil
+	case AuthMethodAuto, AuthMethodKubeconfigData, AuthMethodServerToken, AuthMethodInCluster, AuthMethodKubeconfigFile:
+		return method, nil
+	}
+	return "", fmt.Errorf("invalid auth method '%s': expected one of auto, kubeconfig-data, server-token, in-cluster, kubeconfig-file", name)
+}
+
+// serviceAccountTokenPath is where the in-cluster service account token is mounted.
+const serviceAccountTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+// BuildKubernetesConfig builds a Kubernetes REST config using the given authentication
+// method. With AuthMethodAuto (or an empty method), it uses the first available of the
+// following, in order of priority:
 // 1. Kubeconfig content from KUBECONFIG_DATA environment variable
 // 2. API server URL and token from KUBERNETES_SERVER and KUBERNETES_TOKEN environment variables
 // 3. In-cluster authentication (service account token from /var/run/secrets/kubernetes.io/serviceaccount/token)
 // 4. Kubeconfig file path (provided or default ~/.kube/config)
-func BuildKubernetesConfig(kubeconfigPath string) (*rest.Config, error) {
+// Any other method forces that method alone, which is useful when more than one is
+// available, e.g. when running in a pod but targeting another cluster through KUBECONFIG.
+func BuildKubernetesConfig(kubeconfigPath string, method AuthMethod) (*rest.Config, error) {
+	switch method {
+	case AuthMethodKubeconfigData:
+		return configFromKubeconfigData()
+	case AuthMethodServerToken:
+		return configFromServerToken()
+	case AuthMethodInCluster:
+		return inClusterConfig()
+	case AuthMethodKubeconfigFile:
+		return configFromKubeconfigFile(kubeconfigPath)
+	case AuthMethodAuto, "":
+	default:
+		return nil, fmt.Errorf("unsupported auth method '%s'", method)
+	}
+
 	// Method 1: Kubeconfig content from environment variable
-	if kubeconfigData := os.Getenv("KUBECONFIG_DATA"); kubeconfigData != "" {
-		// Load kubeconfig from bytes
-		configObj, err := clientcmd.Load([]byte(kubeconfigData))
-		if err != nil {
-			return nil, fmt.Errorf("failed to load kubeconfig from KUBECONFIG_DATA: %w", err)
-		}
-		// Build REST config from the loaded config
-		clientConfig := clientcmd.NewDefaultClientConfig(*configObj, &clientcmd.ConfigOverrides{})
-		config, err := clientConfig.ClientConfig()
-		if err != nil {
-			return nil, fmt.Errorf("failed to build REST config from KUBECONFIG_DATA: %w", err)
-		}
-		return config, nil
+	if os.Getenv("KUBECONFIG_DATA") != "" {
+		return configFromKubeconfigData()
 	}
 
 	// Method 2: API server URL and token from environment variables
-	if serverURL := os.Getenv("KUBERNETES_SERVER"); serverURL != "" {
-		token := os.Getenv("KUBERNETES_TOKEN")
-		if token == "" {
-			return nil, fmt.Errorf("KUBERNETES_TOKEN environment variable is required when KUBERNETES_SERVER is set")
-		}
-
-		config := &rest.Config{
-			Host:        serverURL,
-			BearerToken: token,
-			TLSClientConfig: rest.TLSClientConfig{
-				Insecure: os.Getenv("KUBERNETES_INSECURE") == "true",
-			},
-		}
-
-		// Set CA certificate if provided
-		if caCert := os.Getenv("KUBERNETES_CA_CERT"); caCert != "" {
-			config.TLSClientConfig.CAData = []byte(caCert)
-		} else if caCertPath := os.Getenv("KUBERNETES_CA_CERT_PATH"); caCertPath != "" {
-			caCertData, err := os.ReadFile(caCertPath)
-			if err != nil {
-				return nil, fmt.Errorf("failed to read CA certificate from %s: %w", caCertPath, err)
-			}
-			config.TLSClientConfig.CAData = caCertData
-		}
-
-		return config, nil
+	if os.Getenv("KUBERNETES_SERVER") != "" {
+		return configFromServerToken()
 	}
 
 	// Method 3: In-cluster authentication (service account token)
 	// Check if we're running inside a Kubernetes cluster
-	serviceAccountTokenPath := "/var/run/secrets/kubernetes.io/serviceaccount/token"
 	if _, err := os.Stat(serviceAccountTokenPath); err == nil {
-		// We're in a cluster, use in-cluster config
-		config, err := rest.InClusterConfig()
+		return inClusterConfig()
+	}
+
+	// Method 4: Kubeconfig file path (provided or default)
+	return configFromKubeconfigFile(kubeconfigPath)
+}
+
+// configFromKubeconfigData builds a REST config from the kubeconfig content in KUBECONFIG_DATA.
+func configFromKubeconfigData() (*rest.Config, error) {
+	kubeconfigData := os.Getenv("KUBECONFIG_DATA")
+	if kubeconfigData == "" {
+		return nil, fmt.Errorf("KUBECONFIG_DATA environment variable is not set")
+	}
+	// Load kubeconfig from bytes
+	configObj, err := clientcmd.Load([]byte(kubeconfigData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig from KUBECONFIG_DATA: %w", err)
+	}
+	// Build REST config from the loaded config
+	clientConfig := clientcmd.NewDefaultClientConfig(*configObj, &clientcmd.ConfigOverrides{})
+	config, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build REST config from KUBECONFIG_DATA: %w", err)
+	}
+	return config, nil
+}
+
+// configFromServerToken builds a REST config from KUBERNETES_SERVER and KUBERNETES_TOKEN,
+// with an optional CA certificate.
+func configFromServerToken() (*rest.Config, error) {
+	serverURL := os.Getenv("KUBERNETES_SERVER")
+	if serverURL == "" {
+		return nil, fmt.Errorf("KUBERNETES_SERVER environment variable is not set")
+	}
+	token := os.Getenv("KUBERNETES_TOKEN")
+	if token == "" {
+		return nil, fmt.Errorf("KUBERNETES_TOKEN environment variable is required when KUBERNETES_SERVER is set")
+	}
+
+	config := &rest.Config{
+		Host:        serverURL,
+		BearerToken: token,
+		TLSClientConfig: rest.TLSClientConfig{
+			Insecure: os.Getenv("KUBERNETES_INSECURE") == "true",
+		},
+	}
+
+	// Set CA certificate if provided
+	if caCert := os.Getenv("KUBERNETES_CA_CERT"); caCert != "" {
+		config.TLSClientConfig.CAData = []byte(caCert)
+	} else if caCertPath := os.Getenv("KUBERNETES_CA_CERT_PATH"); caCertPath != "" {
+		caCertData, err := os.ReadFile(caCertPath)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create in-cluster config: %w", err)
+			return nil, fmt.Errorf("failed to read CA certificate from %s: %w", caCertPath, err)
 		}
-		return config, nil
+		config.TLSClientConfig.CAData = caCertData
 	}
 
-	// Method 4: Kubeconfig file path (provided or default)
-	var kubeconfig string
-	if kubeconfigPath != "" {
-		kubeconfig = kubeconfigPath
-	} else if kubeconfigEnv := os.Getenv("KUBECONFIG"); kubeconfigEnv != "" {
-		kubeconfig = kubeconfigEnv
-	} else if home := homedir.HomeDir(); home != "" {
-		kubeconfig = filepath.Join(home, ".kube", "config")
+	return config, nil
+}
+
+// inClusterConfig builds a REST config from the pod's service account.
+func inClusterConfig() (*rest.Config, error) {
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create in-cluster config: %w", err)
 	}
+	return config, nil
+}
 
-	config, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+// configFromKubeconfigFile builds a REST config from a kubeconfig file: the given path,
+// or KUBECONFIG, or ~/.kube/config.
+func configFromKubeconfigFile(kubeconfigPath string) (*rest.Config, error) {
+	config, err := clientcmd.BuildConfigFromFlags("", ResolveKubeconfigPath(kubeconfigPath))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Kubernetes configuration: %w", err)
 	}
@@ -133,12 +264,14 @@ func BuildKubernetesConfig(kubeconfigPath string) (*rest.Config, error) {
 // 2. API server URL and token from KUBERNETES_SERVER and KUBERNETES_TOKEN environment variables
 // 3. In-cluster authentication (service account token)
 // 4. Kubeconfig file path (provided or default ~/.kube/config)
-// If kubeconfigPath is empty, it will try to auto-detect the authentication method.
-func NewClient(kubeconfigPath string) (*Client, error) {
-	config, err := BuildKubernetesConfig(kubeconfigPath)
+// If method is AuthMethodAuto, the first available method is used; any other method
+// is used exclusively.
+func NewClient(kubeconfigPath string, method AuthMethod) (*Client, error) {
+	config, err := BuildKubernetesConfig(kubeconfigPath, method)
 	if err != nil {
 		return nil, err
 	}
+	config.WarningHandlerWithContext = contextWarningHandler{}
 
 	clientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
@@ -150,6 +283,11 @@ func NewClient(kubeconfigPath string) (*Client, error) {
 		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
 	}
 
+	metadataClient, err := metadata.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create metadata client: %w", err)
+	}
+
 	discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create discovery client: %w", err)
@@ -161,14 +299,67 @@ func NewClient(kubeconfigPath string) (*Client, error) {
 		return nil, fmt.Errorf("failed to create metrics client: %w", err)
 	}
 
-	return &Client{
+	conns := newConnections(clientset, dynamicClient, metadataClient, memory.NewMemCacheClient(discoveryClient), metricsClient)
+	conns.restConfig = config
+	return newClient(conns), nil
+}
+
+// NewClientFromInterfaces creates a Client from already constructed typed, dynamic,
+// metadata, discovery, and metrics clients. It lets callers, such as tests, supply
+// fakes from k8s.io/client-go/kubernetes/fake, k8s.io/client-go/dynamic/fake, and
+// k8s.io/client-go/metadata/fake instead of connecting to a cluster. The resulting
+// client has no REST config.
+// A discovery client that does not cache is wrapped in an in-memory cache.
+func NewClientFromInterfaces(clientset kubernetes.Interface, dynamicClient dynamic.Interface, metadataClient metadata.Interface, discoveryClient discovery.DiscoveryInterface, metricsClient metricsclientset.Interface) *Client {
+	return newClient(newConnections(clientset, dynamicClient, metadataClient, discoveryClient, metricsClient))
+}
+
+// newConnections bundles the given clients with a REST mapper backed by the discovery
+// client, which is wrapped in an in-memory cache if it does not cache.
+func newConnections(clientset kubernetes.Interface, dynamicClient dynamic.Interface, metadataClient metadata.Interface, discoveryClient discovery.DiscoveryInterface, metricsClient metricsclientset.Interface) *connections {
+	cachedDiscovery, ok := discoveryClient.(discovery.CachedDiscoveryInterface)
+	if !ok {
+		cachedDiscovery = memory.NewMemCacheClient(discoveryClient)
+	}
+	return &connections{
 		clientset:        clientset,
 		dynamicClient:    dynamicClient,
-		discoveryClient:  discoveryClient,
-		metricsClientset: metricsClient, // Assign metrics client
-		restConfig:       config,
+		metadataClient:   metadataClient,
+		discoveryClient:  cachedDiscovery,
+		restMapper:       newRESTMapper(cachedDiscovery),
+		metricsClientset: metricsClient,
+	}
+}
+
+// newClient creates a Client using the given connections, with empty caches.
+func newClient(conns *connections) *Client {
+	client := &Client{
 		apiResourceCache: make(map[string]*schema.GroupVersionResource),
-	}, nil
+		namespacedCache:  make(map[string]bool),
+		kindCache:        make(map[string]string),
+	}
+	client.conns.Store(conns)
+	return client
+}
+
+// clientset returns the typed client of the current connections.
+func (c *Client) clientset() kubernetes.Interface {
+	return c.conns.Load().clientset
+}
+
+// dynamicClient returns the dynamic client of the current connections.
+func (c *Client) dynamicClient() dynamic.Interface {
+	return c.conns.Load().dynamicClient
+}
+
+// metadataClient returns the metadata client of the current connections.
+func (c *Client) metadataClient() metadata.Interface {
+	return c.conns.Load().metadataClient
+}
+
+// metricsClientset returns the metrics client of the current connections.
+func (c *Client) metricsClientset() metricsclientset.Interface {
+	return c.conns.Load().metricsClientset
 }
 
 // GetAPIResources retrieves all API resource types in the cluster.
@@ -176,9 +367,9 @@ func NewClient(kubeconfigPath string) (*Client, error) {
 // Filters resources based on includeNamespaceScoped and includeClusterScoped flags.
 // Returns a slice of maps, each representing an API resource, or an error.
 func (c *Client) GetAPIResources(ctx context.Context, includeNamespaceScoped, includeClusterScoped bool) ([]map[string]interface{}, error) {
-	resourceLists, err := c.discoveryClient.ServerPreferredResources()
-	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
-		return nil, fmt.Errorf("failed to retrieve API resources: %w", err)
+	resourceLists, _, err := c.preferredResources()
+	if err != nil {
+		return nil, err
 	}
 
 	var resources []map[string]interface{}
@@ -203,19 +394,30 @@ func (c *Client) GetAPIResources(ctx context.Context, includeNamespaceScoped, in
 
 // GetResource retrieves detailed information about a specific resource.
 // It uses the dynamic client to fetch the resource by kind, name, and namespace.
+// The namespace must be given for namespaced kinds and omitted for cluster-scoped ones.
+// With ConsistencyCached the API server may serve the object from its watch cache.
 // It utilizes a cached GroupVersionResource (GVR) for efficiency.
 // Returns the unstructured content of the resource as a map, or an error.
-func (c *Client) GetResource(ctx context.Context, kind, name, namespace string) (map[string]interface{}, error) {
+func (c *Client) GetResource(ctx context.Context, kind, name, namespace string, consistency ReadConsistency) (map[string]interface{}, error) {
+	kind = c.resolveKind(kind)
 	gvr, err := c.getCachedGVR(kind)
 	if err != nil {
 		return nil, err
 	}
+	if err := c.checkNamespaceScope(kind, namespace); err != nil {
+		return nil, err
+	}
+
+	options := metav1.GetOptions{}
+	if consistency == ConsistencyCached {
+		options.ResourceVersion = "0"
+	}
 
 	var obj *unstructured.Unstructured
 	if namespace != "" {
-		obj, err = c.dynamicClient.Resource(*gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+		obj, err = c.dynamicClient().Resource(*gvr).Namespace(namespace).Get(ctx, name, options)
 	} else {
-		obj, err = c.dynamicClient.Resource(*gvr).Get(ctx, name, metav1.GetOptions{})
+		obj, err = c.dynamicClient().Resource(*gvr).Get(ctx, name, options)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve resource: %w", err)
@@ -224,43 +426,188 @@ func (c *Client) GetResource(ctx context.Context, kind, name, namespace string)
 	return obj.UnstructuredContent(), nil
 }
 
+// ListPaging requests that a list be fetched in pages of PageSize items using the
+// API's limit/continue mechanism. OnPage, if set, is called with the converted items
+// of each page as it arrives; returning an error stops the listing.
+type ListPaging struct {
+	PageSize int64
+	OnPage   func(page []map[string]interface{}) error
+}
+
+// ListOptions filters and shapes the items ListResources returns. The zero value
+// returns a summary of every item, fetched in a single strongly consistent request.
+type ListOptions struct {
+	LabelSelector string
+	FieldSelector string
+	// CreatedAfter and CreatedBefore, when non-zero, restrict the items to those whose
+	// creationTimestamp falls within that window
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	// Fields, when non-empty, projects each item to those fields instead of the
+	// default name/kind/namespace/labels summary
+	Fields [][]string
+	// Columns adds the values of those printer columns to each summary
+	Columns []PrinterColumn
+	// Consistency ConsistencyCached serves the list from the API server's watch
+	// cache, which significantly reduces load for large cluster-wide lists
+	Consistency ReadConsistency
+	// Paging, when set, fetches the list page by page, stopping early if ctx is
+	// cancelled
+	Paging *ListPaging
+}
+
 // ListResources lists all instances of a specific resource type.
-// It uses the dynamic client and supports filtering by namespace, labelSelector,
-// and fieldSelector.
+// It uses the dynamic client and filters and shapes the items as opts asks. An empty
+// namespace lists across all namespaces; the namespace is ignored for cluster-scoped
+// kinds.
 // It utilizes a cached GroupVersionResource (GVR) for efficiency.
 // Returns a slice of maps, each representing a resource instance, or an error.
-func (c *Client) ListResources(ctx context.Context, kind, namespace, labelSelector, fieldSelector string) ([]map[string]interface{}, error) {
+func (c *Client) ListResources(ctx context.Context, kind, namespace string, opts ListOptions) ([]map[string]interface{}, error) {
+	kind = c.resolveKind(kind)
 	gvr, err := c.getCachedGVR(kind)
 	if err != nil {
 		return nil, err
 	}
 
+	namespaced, err := c.isNamespaced(kind)
+	if err != nil {
+		return nil, err
+	}
+	if !namespaced {
+		namespace = ""
+	}
+
 	options := metav1.ListOptions{
-		LabelSelector: labelSelector,
-		FieldSelector: fieldSelector,
+		LabelSelector: opts.LabelSelector,
+		FieldSelector: opts.FieldSelector,
+	}
+	if opts.Consistency == ConsistencyCached {
+		options.ResourceVersion = "0"
+		options.ResourceVersionMatch = metav1.ResourceVersionMatchNotOlderThan
+	}
+	paging := opts.Paging
+	if paging != nil {
+		options.Limit = paging.PageSize
 	}
 
-	var list *unstructured.UnstructuredList
-	if namespace != "" {
-		list, err = c.dynamicClient.Resource(*gvr).Namespace(namespace).List(ctx, options)
-	} else {
-		list, err = c.dynamicClient.Resource(*gvr).List(ctx, options)
+	var resources []map[string]interface{}
+	for {
+		if err := ctx.Err(); err != nil {
+			return resources, err
+		}
+
+		var list *unstructured.UnstructuredList
+		if namespace != "" {
+			list, err = c.dynamicClient().Resource(*gvr).Namespace(namespace).List(ctx, options)
+		} else {
+			list, err = c.dynamicClient().Resource(*gvr).List(ctx, options)
+		}
+		if err != nil {
+			return resources, fmt.Errorf("failed to list resources: %w", err)
+		}
+
+		var page []map[string]interface{}
+		for _, item := range list.Items {
+			created := item.GetCreationTimestamp().Time
+			if !opts.CreatedAfter.IsZero() && created.Before(opts.CreatedAfter) {
+				continue
+			}
+			if !opts.CreatedBefore.IsZero() && created.After(opts.CreatedBefore) {
+				continue
+			}
+			if len(opts.Fields) > 0 {
+				page = append(page, ProjectFields(item.Object, opts.Fields))
+				continue
+			}
+			metadata := item.GetLabels()
+			summary := map[string]interface{}{
+				"name":      item.GetName(),
+				"kind":      item.GetKind(),
+				"namespace": item.GetNamespace(),
+				"labels":    metadata,
+			}
+			if len(opts.Columns) > 0 {
+				summary["columns"] = printerColumnValues(item.Object, opts.Columns)
+			}
+			page = append(page, summary)
+		}
+		resources = append(resources, page...)
+
+		if paging != nil && paging.OnPage != nil {
+			if err := paging.OnPage(page); err != nil {
+				return resources, err
+			}
+		}
+		if paging == nil || list.GetContinue() == "" {
+			break
+		}
+		// A continue token carries the resource version of the first page; the API
+		// server rejects it together with ResourceVersion or ResourceVersionMatch
+		options.Continue = list.GetContinue()
+		options.ResourceVersion = ""
+		options.ResourceVersionMatch = ""
 	}
+
+	return resources, nil
+}
+
+// maxNamespaceListParallelism bounds how many namespaces ListResourcesInNamespaces
+// lists at the same time.
+const maxNamespaceListParallelism = 10
+
+// ListResourcesInNamespaces lists a resource type in each of the given namespaces,
+// fetching up to maxNamespaceListParallelism namespaces concurrently, and returns the
+// items of all of them in the order the namespaces are given. It takes the same
+// options as ListResources; with Fields, metadata.namespace is always included so that
+// every item still shows the namespace it came from. For cluster-scoped kinds the
+// namespaces are ignored and the resources are listed once. With paging, OnPage is
+// never called concurrently.
+// Returns the merged items, or an error naming the first namespace that failed.
+func (c *Client) ListResourcesInNamespaces(ctx context.Context, kind string, namespaces []string, opts ListOptions) ([]map[string]interface{}, error) {
+	kind = c.resolveKind(kind)
+	namespaced, err := c.isNamespaced(kind)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list resources: %w", err)
+		return nil, err
+	}
+	if !namespaced {
+		return c.ListResources(ctx, kind, "", opts)
 	}
 
-	var resources []map[string]interface{}
-	for _, item := range list.Items {
-		metadata := item.GetLabels()
-		resources = append(resources, map[string]interface{}{
-			"name":      item.GetName(),
-			"kind":      item.GetKind(),
-			"namespace": item.GetNamespace(),
-			"labels":    metadata,
-		})
+	if len(opts.Fields) > 0 && !slices.ContainsFunc(opts.Fields, func(path []string) bool { return slices.Equal(path, []string{"metadata", "namespace"}) }) {
+		opts.Fields = append(slices.Clone(opts.Fields), []string{"metadata", "namespace"})
+	}
+	if paging := opts.Paging; paging != nil && paging.OnPage != nil {
+		onPage := paging.OnPage
+		var mu sync.Mutex
+		opts.Paging = &ListPaging{PageSize: paging.PageSize, OnPage: func(page []map[string]interface{}) error {
+			mu.Lock()
+			defer mu.Unlock()
+			return onPage(page)
+		}}
+	}
+
+	results := make([][]map[string]interface{}, len(namespaces))
+	errs := make([]error, len(namespaces))
+	slots := make(chan struct{}, maxNamespaceListParallelism)
+	var wg sync.WaitGroup
+	for i, namespace := range namespaces {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			results[i], errs[i] = c.ListResources(ctx, kind, namespace, opts)
+		}()
 	}
+	wg.Wait()
 
+	var resources []map[string]interface{}
+	for i, namespace := range namespaces {
+		if errs[i] != nil {
+			return nil, fmt.Errorf("namespace '%s': %w", namespace, errs[i])
+		}
+		resources = append(resources, results[i]...)
+	}
 	return resources, nil
 }
 
@@ -269,8 +616,11 @@ func (c *Client) ListResources(ctx context.Context, kind, namespace, labelSelect
 // It uses the dynamic client to first attempt an update, and if that fails
 // (e.g., resource not found), it attempts to create the resource.
 // Requires the resource manifest to include a name.
+// fieldValidation sets how the API server treats unknown or duplicate fields (see
+// ParseFieldValidation); with Strict a typo such as "replcas" rejects the request.
 // Returns the unstructured content of the created/updated resource, or an error.
-func (c *Client) CreateOrUpdateResourceJSON(ctx context.Context, namespace, manifestJSON, kind string) (map[string]interface{}, error) {
+func (c *Client) CreateOrUpdateResourceJSON(ctx context.Context, namespace, manifestJSON, kind, fieldValidation string) (map[string]interface{}, error) {
+	kind = c.resolveKind(kind)
 	// Decode JSON into unstructured object directly (no YAML conversion)
 
 	obj := &unstructured.Unstructured{}
@@ -285,13 +635,13 @@ func (c *Client) CreateOrUpdateResourceJSON(ctx context.Context, namespace, mani
 	}
 
 	// Check if ns exists
-	_, err = c.clientset.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+	_, err = c.clientset().CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
 	if err == nil {
-		fmt.Printf("Namespace %s exists\n", namespace)
+		log.Printf("Namespace %s exists", namespace)
 	}
 	if errors.IsNotFound(err) {
-		fmt.Printf("Namespace %s does not exist, creating one\n", namespace)
-		_, err = c.clientset.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
+		log.Printf("Namespace %s does not exist, creating one", namespace)
+		_, err = c.clientset().CoreV1().Namespaces().Create(ctx, &corev1.Namespace{
 			ObjectMeta: metav1.ObjectMeta{
 				Labels: map[string]string{
 					"kubernetes.io/metadata.name": namespace,
@@ -317,7 +667,7 @@ func (c *Client) CreateOrUpdateResourceJSON(ctx context.Context, namespace, mani
 		return nil, fmt.Errorf("resource name is required")
 	}
 
-	resource := c.dynamicClient.Resource(*gvr).Namespace(obj.GetNamespace())
+	resource := c.dynamicClient().Resource(*gvr).Namespace(obj.GetNamespace())
 
 	// Try to patch; if not found, create
 	rawJSON := []byte(manifestJSON) // manifestJSON is already JSON
@@ -326,10 +676,10 @@ func (c *Client) CreateOrUpdateResourceJSON(ctx context.Context, namespace, mani
 		obj.GetName(),
 		types.MergePatchType,
 		rawJSON,
-		metav1.PatchOptions{},
+		metav1.PatchOptions{FieldValidation: fieldValidation},
 	)
 	if errors.IsNotFound(err) {
-		result, err = resource.Create(ctx, obj, metav1.CreateOptions{})
+		result, err = resource.Create(ctx, obj, metav1.CreateOptions{FieldValidation: fieldValidation})
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to create or patch resource: %w", err)
@@ -350,6 +700,7 @@ func (c *Client) CreateOrUpdateResourceJSON(ctx context.Context, namespace, mani
 //   - namespace: Target namespace for the resource (overrides manifest namespace if provided)
 //   - yamlManifest: YAML manifest string of the Kubernetes resource
 //   - kind: Resource kind (optional, will be inferred from manifest if empty)
+//   - fieldValidation: Ignore, Warn, or Strict handling of unknown or duplicate fields (optional, server default if empty)
 //
 // Example YAML manifest:
 //
@@ -362,7 +713,8 @@ func (c *Client) CreateOrUpdateResourceJSON(ctx context.Context, namespace, mani
 //	  containers:
 //	  - name: nginx
 //	    image: nginx:latest
-func (c *Client) CreateOrUpdateResourceYAML(ctx context.Context, namespace, yamlManifest, kind string) (map[string]interface{}, error) {
+func (c *Client) CreateOrUpdateResourceYAML(ctx context.Context, namespace, yamlManifest, kind, fieldValidation string) (map[string]interface{}, error) {
+	kind = c.resolveKind(kind)
 	// Convert YAML to JSON
 	jsonData, err := yaml.YAMLToJSON([]byte(yamlManifest))
 	if err != nil {
@@ -399,7 +751,7 @@ func (c *Client) CreateOrUpdateResourceYAML(ctx context.Context, namespace, yaml
 		return nil, fmt.Errorf("resource name is required in YAML manifest")
 	}
 
-	resource := c.dynamicClient.Resource(*gvr).Namespace(obj.GetNamespace())
+	resource := c.dynamicClient().Resource(*gvr).Namespace(obj.GetNamespace())
 
 	// Try to patch; if not found, create
 	result, err := resource.Patch(
@@ -407,10 +759,10 @@ func (c *Client) CreateOrUpdateResourceYAML(ctx context.Context, namespace, yaml
 		obj.GetName(),
 		types.MergePatchType,
 		jsonData,
-		metav1.PatchOptions{},
+		metav1.PatchOptions{FieldValidation: fieldValidation},
 	)
 	if errors.IsNotFound(err) {
-		result, err = resource.Create(ctx, obj, metav1.CreateOptions{})
+		result, err = resource.Create(ctx, obj, metav1.CreateOptions{FieldValidation: fieldValidation})
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to create or patch resource from YAML manifest: %w", err)
@@ -424,6 +776,7 @@ func (c *Client) CreateOrUpdateResourceYAML(ctx context.Context, namespace, yaml
 // It utilizes a cached GroupVersionResource (GVR) for efficiency.
 // Returns an error if the deletion fails.
 func (c *Client) DeleteResource(ctx context.Context, kind, name, namespace string) error {
+	kind = c.resolveKind(kind)
 	gvr, err := c.getCachedGVR(kind)
 	if err != nil {
 		return err
@@ -431,9 +784,9 @@ func (c *Client) DeleteResource(ctx context.Context, kind, name, namespace strin
 
 	var deleteErr error
 	if namespace != "" {
-		deleteErr = c.dynamicClient.Resource(*gvr).Namespace(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+		deleteErr = c.dynamicClient().Resource(*gvr).Namespace(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 	} else {
-		deleteErr = c.dynamicClient.Resource(*gvr).Delete(ctx, name, metav1.DeleteOptions{})
+		deleteErr = c.dynamicClient().Resource(*gvr).Delete(ctx, name, metav1.DeleteOptions{})
 	}
 	if deleteErr != nil {
 		return fmt.Errorf("failed to delete resource: %w", deleteErr)
@@ -449,34 +802,106 @@ func (c *Client) getCachedGVR(kind string) (*schema.GroupVersionResource, error)
 		return gvr, nil
 	}
 	c.cacheLock.RUnlock()
+	conns := c.conns.Load()
+
+	// Cache miss; look the kind up in the cached discovery data, and refresh it once if
+	// the kind is not there, since it may have been installed since, e.g. by a new CRD
+	resource, gv, failedGroups, err := c.discoverKind(kind)
+	if err == nil && resource == nil {
+		c.invalidateDiscovery()
+		resource, gv, failedGroups, err = c.discoverKind(kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if resource == nil {
+		if len(failedGroups) > 0 {
+			return nil, fmt.Errorf("resource type %s not found; API discovery failed for %s, which may serve it", kind, strings.Join(failedGroups, ", "))
+		}
+		if aliased := c.kindAlias(kind); aliased != "" {
+			return nil, fmt.Errorf("resource type %s (alias for %s) not found", kind, aliased)
+		}
+		return nil, fmt.Errorf("resource type %s not found", kind)
+	}
+
+	gvr := &schema.GroupVersionResource{
+		Group:    gv.Group,
+		Version:  gv.Version,
+		Resource: resource.Name,
+	}
+	c.cacheLock.Lock()
+	// A lookup that raced with Reload must not fill the new caches with the old cluster's kinds
+	if c.conns.Load() == conns {
+		c.apiResourceCache[kind] = gvr
+		c.namespacedCache[kind] = resource.Namespaced
+		c.kindCache[kind] = resource.Kind
+	}
+	c.cacheLock.Unlock()
+	return gvr, nil
+}
 
-	// Cache miss; fetch from discovery client
-	resourceLists, err := c.discoveryClient.ServerPreferredResources()
-	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
-		return nil, fmt.Errorf("failed to retrieve API resources: %w", err)
+// discoverKind finds the preferred API resource serving kind in the discovery data, or
+// if no group serves a kind of that name and kind is a configured alias, the resource
+// serving the kind it stands for, so that aliases never shadow real kinds.
+// Returns the resource and its group version, or a nil resource if no group serves the
+// kind, together with the group versions whose discovery failed, or an error.
+func (c *Client) discoverKind(kind string) (*metav1.APIResource, schema.GroupVersion, []string, error) {
+	resourceLists, failedGroups, err := c.preferredResources()
+	if err != nil {
+		return nil, schema.GroupVersion{}, nil, err
 	}
 
+	aliased := c.kindAlias(kind)
+	var aliasResource *metav1.APIResource
+	var aliasGV schema.GroupVersion
 	for _, resourceList := range resourceLists {
 		gv, err := schema.ParseGroupVersion(resourceList.GroupVersion)
 		if err != nil {
 			continue
 		}
-		for _, resource := range resourceList.APIResources {
-			if resource.Kind == kind {
-				gvr := &schema.GroupVersionResource{
-					Group:    gv.Group,
-					Version:  gv.Version,
-					Resource: resource.Name,
-				}
-				c.cacheLock.Lock()
-				c.apiResourceCache[kind] = gvr
-				c.cacheLock.Unlock()
-				return gvr, nil
+		for i := range resourceList.APIResources {
+			resourceKind := resourceList.APIResources[i].Kind
+			if resourceKind == kind {
+				return &resourceList.APIResources[i], gv, failedGroups, nil
+			}
+			if aliased != "" && aliasResource == nil && resourceKind == aliased {
+				aliasResource, aliasGV = &resourceList.APIResources[i], gv
 			}
 		}
 	}
+	if aliasResource != nil {
+		return aliasResource, aliasGV, failedGroups, nil
+	}
+	return nil, schema.GroupVersion{}, failedGroups, nil
+}
+
+// isNamespaced reports whether the given kind is namespace-scoped, using the
+// same cache that backs getCachedGVR.
+func (c *Client) isNamespaced(kind string) (bool, error) {
+	if _, err := c.getCachedGVR(kind); err != nil {
+		return false, err
+	}
 
-	return nil, fmt.Errorf("resource type %s not found", kind)
+	c.cacheLock.RLock()
+	defer c.cacheLock.RUnlock()
+	return c.namespacedCache[kind], nil
+}
+
+// checkNamespaceScope returns an error explaining how to call again if a namespace is
+// given for a cluster-scoped kind or missing for a namespaced one, instead of the
+// error the API server would return for the malformed request.
+func (c *Client) checkNamespaceScope(kind, namespace string) error {
+	namespaced, err := c.isNamespaced(kind)
+	if err != nil {
+		return err
+	}
+	if namespaced && namespace == "" {
+		return fmt.Errorf("%s is namespaced; a namespace is required", kind)
+	}
+	if !namespaced && namespace != "" {
+		return fmt.Errorf("%s is cluster-scoped; do not pass a namespace", kind)
+	}
+	return nil
 }
 
 // DescribeResource retrieves detailed information about a specific resource, similar to GetResource.
@@ -485,16 +910,20 @@ func (c *Client) getCachedGVR(kind string) (*schema.GroupVersionResource, error)
 // Returns the unstructured content of the resource as a map, or an error.
 // Note: This function currently has the same implementation as GetResource.
 func (c *Client) DescribeResource(ctx context.Context, kind, name, namespace string) (map[string]interface{}, error) {
+	kind = c.resolveKind(kind)
 	gvr, err := c.getCachedGVR(kind)
 	if err != nil {
 		return nil, err
 	}
+	if err := c.checkNamespaceScope(kind, namespace); err != nil {
+		return nil, err
+	}
 
 	var obj *unstructured.Unstructured
 	if namespace != "" {
-		obj, err = c.dynamicClient.Resource(*gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+		obj, err = c.dynamicClient().Resource(*gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
 	} else {
-		obj, err = c.dynamicClient.Resource(*gvr).Get(ctx, name, metav1.GetOptions{})
+		obj, err = c.dynamicClient().Resource(*gvr).Get(ctx, name, metav1.GetOptions{})
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve resource: %w", err)
@@ -503,21 +932,34 @@ func (c *Client) DescribeResource(ctx context.Context, kind, name, namespace str
 	return obj.UnstructuredContent(), nil
 }
 
+// DefaultContainerAnnotation names the container kubectl uses for logs and exec
+// when none is specified.
+const DefaultContainerAnnotation = "kubectl.kubernetes.io/default-container"
+
 // GetPodsLogs retrieves the logs for a specific pod.
 // It uses the corev1 clientset to fetch logs, limiting to the last 100 lines by default.
 // If containerName is provided, it gets logs for that specific container.
-// If containerName is empty and the pod has multiple containers, it gets logs from all containers.
+// If containerName is empty, it gets logs from the container named by the DefaultContainerAnnotation
+// when present, or otherwise from all containers. When includeInit is true, init containers and
+// ephemeral debug containers are included as well; logs of several containers are each clearly
+// labeled in the output.
+// If limitBytes is positive, the logs of each container are cut off after that many bytes, which
+// bounds the output even when single lines are huge. The limit applies to the last 100 lines, so
+// the oldest of them are kept.
 // Returns the logs as a string, or an error.
-func (c *Client) GetPodsLogs(ctx context.Context, namespace, containerName, podName string) (string, error) {
+func (c *Client) GetPodsLogs(ctx context.Context, namespace, containerName, podName string, includeInit bool, limitBytes int64) (string, error) {
 	tailLines := int64(100)
 	podLogOptions := &corev1.PodLogOptions{
 		TailLines: &tailLines,
 	}
+	if limitBytes > 0 {
+		podLogOptions.LimitBytes = &limitBytes
+	}
 
 	// If container name is provided, use it
 	if containerName != "" {
 		podLogOptions.Container = containerName
-		req := c.clientset.CoreV1().Pods(namespace).GetLogs(podName, podLogOptions)
+		req := c.clientset().CoreV1().Pods(namespace).GetLogs(podName, podLogOptions)
 		logs, err := req.Stream(ctx)
 		if err != nil {
 			return "", fmt.Errorf("failed to get logs for container '%s': %w", containerName, err)
@@ -532,14 +974,47 @@ func (c *Client) GetPodsLogs(ctx context.Context, namespace, containerName, podN
 	}
 
 	// If no container name provided, first get the pod to check its containers
-	pod, err := c.clientset.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	pod, err := c.clientset().CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
 	if err != nil {
 		return "", fmt.Errorf("failed to get pod details: %w", err)
 	}
 
+	// Like kubectl, prefer the container named by the default-container annotation
+	// (set by sidecar-injecting meshes such as Istio) over every regular container
+	containers := pod.Spec.Containers
+	if defaultContainer := pod.Annotations[DefaultContainerAnnotation]; defaultContainer != "" {
+		for _, container := range pod.Spec.Containers {
+			if container.Name == defaultContainer {
+				containers = []corev1.Container{container}
+				break
+			}
+		}
+	}
+
+	// Collect the containers to fetch logs from, labeled by container type
+	type logTarget struct {
+		name  string
+		label string
+	}
+	var targets []logTarget
+	if includeInit {
+		for _, container := range pod.Spec.InitContainers {
+			targets = append(targets, logTarget{name: container.Name, label: "init container"})
+		}
+	}
+	for _, container := range containers {
+		targets = append(targets, logTarget{name: container.Name, label: "container"})
+	}
+	if includeInit {
+		for _, container := range pod.Spec.EphemeralContainers {
+			targets = append(targets, logTarget{name: container.Name, label: "ephemeral container"})
+		}
+	}
+
 	// If the pod has only one container, get logs from that container
-	if len(pod.Spec.Containers) == 1 {
-		req := c.clientset.CoreV1().Pods(namespace).GetLogs(podName, podLogOptions)
+	if len(targets) == 1 {
+		podLogOptions.Container = targets[0].name
+		req := c.clientset().CoreV1().Pods(namespace).GetLogs(podName, podLogOptions)
 		logs, err := req.Stream(ctx)
 		if err != nil {
 			return "", fmt.Errorf("failed to get logs: %w", err)
@@ -555,18 +1030,18 @@ func (c *Client) GetPodsLogs(ctx context.Context, namespace, containerName, podN
 
 	// If the pod has multiple containers, get logs from each container
 	var allLogs strings.Builder
-	for _, container := range pod.Spec.Containers {
+	for _, target := range targets {
 		containerLogOptions := podLogOptions.DeepCopy()
-		containerLogOptions.Container = container.Name
+		containerLogOptions.Container = target.name
 
-		req := c.clientset.CoreV1().Pods(namespace).GetLogs(podName, containerLogOptions)
+		req := c.clientset().CoreV1().Pods(namespace).GetLogs(podName, containerLogOptions)
 		logs, err := req.Stream(ctx)
 		if err != nil {
-			allLogs.WriteString(fmt.Sprintf("\n--- Error getting logs for container %s: %v ---\n", container.Name, err))
+			allLogs.WriteString(fmt.Sprintf("\n--- Error getting logs for %s %s: %v ---\n", target.label, target.name, err))
 			continue
 		}
 
-		allLogs.WriteString(fmt.Sprintf("\n--- Logs for container %s ---\n", container.Name))
+		allLogs.WriteString(fmt.Sprintf("\n--- Logs for %s %s ---\n", target.label, target.name))
 		buf := new(bytes.Buffer)
 		_, err = io.Copy(buf, logs)
 		logs.Close()
@@ -583,13 +1058,27 @@ func (c *Client) GetPodsLogs(ctx context.Context, namespace, containerName, podN
 
 // GetPodMetrics retrieves CPU and Memory metrics for a specific pod.
 // It uses the metrics clientset to fetch pod metrics.
+// If includeUtilization is set, the pod is also fetched and each container's usage is
+// reported alongside its requests and limits, with the usage as a percentage of each.
 // Returns a map containing pod metadata and container metrics, or an error.
-func (c *Client) GetPodMetrics(ctx context.Context, namespace, podName string) (map[string]interface{}, error) {
-	podMetrics, err := c.metricsClientset.MetricsV1beta1().PodMetricses(namespace).Get(ctx, podName, metav1.GetOptions{})
+func (c *Client) GetPodMetrics(ctx context.Context, namespace, podName string, includeUtilization bool) (map[string]interface{}, error) {
+	podMetrics, err := c.metricsClientset().MetricsV1beta1().PodMetricses(namespace).Get(ctx, podName, metav1.GetOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get metrics for pod '%s' in namespace '%s': %w", podName, namespace, err)
 	}
 
+	var resources map[string]corev1.ResourceRequirements
+	if includeUtilization {
+		pod, err := c.clientset().CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pod '%s' in namespace '%s': %w", podName, namespace, err)
+		}
+		resources = map[string]corev1.ResourceRequirements{}
+		for _, container := range pod.Spec.Containers {
+			resources[container.Name] = container.Resources
+		}
+	}
+
 	metricsResult := map[string]interface{}{
 		"podName":    podName,
 		"namespace":  namespace,
@@ -605,6 +1094,9 @@ func (c *Client) GetPodMetrics(ctx context.Context, namespace, podName string) (
 			"cpu":    container.Usage.Cpu().String(),    // Format Quantity
 			"memory": container.Usage.Memory().String(), // Format Quantity
 		}
+		if resources != nil {
+			containerMetrics["utilization"] = containerUtilization(container.Usage, resources[container.Name])
+		}
 		containerMetricsList = append(containerMetricsList, containerMetrics)
 	}
 	metricsResult["containers"] = containerMetricsList
@@ -612,11 +1104,37 @@ func (c *Client) GetPodMetrics(ctx context.Context, namespace, podName string) (
 	return metricsResult, nil
 }
 
+// containerUtilization reports a container's usage as a percentage of its CPU and
+// memory requests and limits. Percentages are omitted for values that are not set,
+// so a missing key means the container has no such request or limit.
+func containerUtilization(usage corev1.ResourceList, resources corev1.ResourceRequirements) map[string]interface{} {
+	utilization := map[string]interface{}{
+		"requests": resourceListStrings(resources.Requests),
+		"limits":   resourceListStrings(resources.Limits),
+	}
+	percent := func(used, of int64) float64 {
+		return math.Round(float64(used)/float64(of)*1000) / 10
+	}
+	for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory} {
+		used, ok := usage[name]
+		if !ok {
+			continue
+		}
+		if request, ok := resources.Requests[name]; ok && !request.IsZero() {
+			utilization[string(name)+"RequestPercent"] = percent(used.MilliValue(), request.MilliValue())
+		}
+		if limit, ok := resources.Limits[name]; ok && !limit.IsZero() {
+			utilization[string(name)+"LimitPercent"] = percent(used.MilliValue(), limit.MilliValue())
+		}
+	}
+	return utilization
+}
+
 // GetNodeMetrics retrieves CPU and Memory metrics for a specific Node.
 // It uses the metrics clientset to fetch node metrics.
 // Returns a map containing node metadata and resource usage, or an error.
 func (c *Client) GetNodeMetrics(ctx context.Context, nodeName string) (map[string]interface{}, error) {
-	nodeMetrics, err := c.metricsClientset.MetricsV1beta1().NodeMetricses().Get(ctx, nodeName, metav1.GetOptions{})
+	nodeMetrics, err := c.metricsClientset().MetricsV1beta1().NodeMetricses().Get(ctx, nodeName, metav1.GetOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get metrics for node '%s': %w", nodeName, err)
 	}
@@ -642,9 +1160,9 @@ func (c *Client) GetEvents(ctx context.Context, namespace string) ([]map[string]
 	var err error
 
 	if namespace != "" {
-		eventList, err = c.clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+		eventList, err = c.clientset().CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
 	} else {
-		eventList, err = c.clientset.CoreV1().Events("").List(ctx, metav1.ListOptions{})
+		eventList, err = c.clientset().CoreV1().Events("").List(ctx, metav1.ListOptions{})
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve events: %w", err)
@@ -671,7 +1189,7 @@ func (c *Client) GetEvents(ctx context.Context, namespace string) ([]map[string]
 // It uses the networking.k8s.io/v1 clientset to fetch ingresses.
 // Returns a slice of maps, each representing an ingress with the requested fields, or an error.
 func (c *Client) GetIngresses(ctx context.Context, host string) ([]map[string]interface{}, error) {
-	ingresses, err := c.clientset.NetworkingV1().Ingresses("").List(ctx, metav1.ListOptions{})
+	ingresses, err := c.clientset().NetworkingV1().Ingresses("").List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve ingresses: %w", err)
 	}
@@ -724,12 +1242,13 @@ func (c *Client) GetIngresses(ctx context.Context, host string) ([]map[string]in
 // It patches the spec.template.metadata.annotations with the current timestamp.
 // Returns the patched resource content or an error if the resource doesn't support rollout restart.
 func (c *Client) RolloutRestart(ctx context.Context, kind, name, namespace string) (map[string]interface{}, error) {
+	kind = c.resolveKind(kind)
 	gvr, err := c.getCachedGVR(kind)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get GVR for kind %s: %w", kind, err)
 	}
 
-	resource := c.dynamicClient.Resource(*gvr).Namespace(namespace)
+	resource := c.dynamicClient().Resource(*gvr).Namespace(namespace)
 
 	patch := []byte(fmt.Sprintf(
 		`{"spec":{"template":{"metadata":{"annotations":{"kubectl.kubernetes.io/restartedAt":"%s"}}}}}`,
@@ -0,0 +1,145 @@
+package k8s_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/reza-gholizade/k8s-mcp-server/pkg/k8s"
+	"github.com/reza-gholizade/k8s-mcp-server/pkg/k8s/k8stest"
+)
+
+func TestListResources(t *testing.T) {
+	client := k8stest.NewFakeClient()
+
+	pods, err := client.ListResources(context.Background(), "Pod", k8stest.Namespace, k8s.ListOptions{})
+	if err != nil {
+		t.Fatalf("ListResources: %v", err)
+	}
+	if len(pods) != len(k8stest.PodNames) {
+		t.Fatalf("got %d pods, want %d", len(pods), len(k8stest.PodNames))
+	}
+	for _, pod := range pods {
+		if pod["kind"] != "Pod" || pod["namespace"] != k8stest.Namespace {
+			t.Errorf("unexpected summary %v", pod)
+		}
+	}
+
+	// The namespace is ignored for cluster-scoped kinds
+	nodes, err := client.ListResources(context.Background(), "Node", k8stest.Namespace, k8s.ListOptions{})
+	if err != nil {
+		t.Fatalf("ListResources: %v", err)
+	}
+	if len(nodes) != 1 || nodes[0]["name"] != k8stest.NodeName {
+		t.Errorf("got nodes %v, want %s", nodes, k8stest.NodeName)
+	}
+}
+
+func TestListResourcesPaging(t *testing.T) {
+	fakes := k8stest.NewFakes()
+	// Serve one pod per page, recording the options of each request
+	var requests []metav1.ListOptions
+	fakes.Dynamic.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		options := action.(k8stesting.ListActionImpl).ListOptions
+		requests = append(requests, options)
+		list := &unstructured.UnstructuredList{}
+		list.SetAPIVersion("v1")
+		list.SetKind("PodList")
+		name := k8stest.PodNames[0]
+		if options.Continue != "" {
+			name = k8stest.PodNames[1]
+		} else {
+			list.SetContinue("page-2")
+		}
+		pod := unstructured.Unstructured{}
+		pod.SetAPIVersion("v1")
+		pod.SetKind("Pod")
+		pod.SetName(name)
+		pod.SetNamespace(k8stest.Namespace)
+		list.Items = []unstructured.Unstructured{pod}
+		return true, list, nil
+	})
+
+	var pages [][]map[string]interface{}
+	pods, err := fakes.Client().ListResources(context.Background(), "Pod", k8stest.Namespace, k8s.ListOptions{
+		Consistency: k8s.ConsistencyCached,
+		Paging: &k8s.ListPaging{PageSize: 1, OnPage: func(page []map[string]interface{}) error {
+			pages = append(pages, page)
+			return nil
+		}},
+	})
+	if err != nil {
+		t.Fatalf("ListResources: %v", err)
+	}
+	if len(pods) != 2 || len(pages) != 2 {
+		t.Fatalf("got %d pods in %d pages, want 2 in 2", len(pods), len(pages))
+	}
+	for i, name := range k8stest.PodNames {
+		if pods[i]["name"] != name || pages[i][0]["name"] != name {
+			t.Errorf("item %d is %v, want %s", i, pods[i]["name"], name)
+		}
+	}
+
+	if len(requests) != 2 {
+		t.Fatalf("got %d list requests, want 2", len(requests))
+	}
+	first, second := requests[0], requests[1]
+	if first.Limit != 1 || first.ResourceVersion != "0" || first.ResourceVersionMatch != metav1.ResourceVersionMatchNotOlderThan || first.Continue != "" {
+		t.Errorf("first request: got %+v, want a cached read of one item", first)
+	}
+	// The API server rejects a continue token together with a resource version
+	if second.Limit != 1 || second.Continue != "page-2" || second.ResourceVersion != "" || second.ResourceVersionMatch != "" {
+		t.Errorf("second request: got %+v, want the continue token without a resource version", second)
+	}
+}
+
+func TestGetPodsLogsDefaultContainer(t *testing.T) {
+	objects := k8stest.Objects()
+	pod := seeded[*corev1.Pod](t, objects)
+	pod.Annotations = map[string]string{k8s.DefaultContainerAnnotation: "web"}
+	pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: "istio-proxy", Image: "istio/proxyv2"})
+	pod.Spec.InitContainers = []corev1.Container{{Name: "migrate", Image: "busybox"}}
+	client := k8stest.NewFakeClient(objects...)
+	ctx := context.Background()
+
+	// The annotation picks the regular container, whose logs are returned unlabeled
+	logs, err := client.GetPodsLogs(ctx, k8stest.Namespace, "", pod.Name, false, 0)
+	if err != nil {
+		t.Fatalf("GetPodsLogs: %v", err)
+	}
+	if strings.Contains(logs, "---") {
+		t.Errorf("expected the logs of the default container only, got %q", logs)
+	}
+
+	// Init containers are still included on request, but other regular containers are not
+	logs, err = client.GetPodsLogs(ctx, k8stest.Namespace, "", pod.Name, true, 0)
+	if err != nil {
+		t.Fatalf("GetPodsLogs: %v", err)
+	}
+	if !strings.Contains(logs, "--- Logs for init container migrate ---") || !strings.Contains(logs, "--- Logs for container web ---") {
+		t.Errorf("expected labeled logs of the init and default containers, got %q", logs)
+	}
+	if strings.Contains(logs, "istio-proxy") {
+		t.Errorf("expected no logs of the sidecar, got %q", logs)
+	}
+}
+
+// seeded returns the first of the objects of type T, so that tests can adjust the
+// objects k8stest.Objects returns before seeding them.
+func seeded[T runtime.Object](t *testing.T, objects []runtime.Object) T {
+	t.Helper()
+	for _, obj := range objects {
+		if typed, ok := obj.(T); ok {
+			return typed
+		}
+	}
+	var zero T
+	t.Fatalf("no %T among the seeded objects", zero)
+	return zero
+}
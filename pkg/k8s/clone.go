@@ -0,0 +1,79 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CloneResource copies a resource into another namespace and/or under a new name.
+// Server-populated and namespace-specific fields are stripped before the copy is
+// created, and overrides, if given, are merged into it with JSON merge patch
+// semantics (a null value removes a field). An empty targetName keeps the original
+// name; cluster-scoped kinds must be given a new name.
+// The copy is only created, never updated: cloning onto an existing object fails.
+// Returns the unstructured content of the created resource, or an error.
+func (c *Client) CloneResource(ctx context.Context, kind, name, sourceNamespace, targetNamespace, targetName string, overrides map[string]interface{}) (map[string]interface{}, error) {
+	kind = c.resolveKind(kind)
+	gvr, err := c.getCachedGVR(kind)
+	if err != nil {
+		return nil, err
+	}
+	namespaced, err := c.isNamespaced(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	if targetName == "" {
+		targetName = name
+	}
+	if !namespaced {
+		sourceNamespace, targetNamespace = "", ""
+		if targetName == name {
+			return nil, fmt.Errorf("%s is cluster-scoped: a new name is required to clone it", kind)
+		}
+	} else if targetNamespace == "" {
+		targetNamespace = sourceNamespace
+	}
+	if targetNamespace == sourceNamespace && targetName == name {
+		return nil, fmt.Errorf("the clone must differ from the source in namespace or name")
+	}
+
+	obj, err := c.dynamicClient().Resource(*gvr).Namespace(sourceNamespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s '%s': %w", kind, name, err)
+	}
+
+	sanitizeForApply(obj)
+	obj.SetNamespace(targetNamespace)
+	obj.SetName(targetName)
+	obj.SetGenerateName("")
+	if len(overrides) > 0 {
+		mergeObject(obj.Object, overrides)
+	}
+
+	created, err := c.dynamicClient().Resource(*gvr).Namespace(targetNamespace).Create(ctx, obj, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create clone: %w", err)
+	}
+	return created.UnstructuredContent(), nil
+}
+
+// mergeObject merges patch into obj following JSON merge patch (RFC 7386):
+// nested maps are merged, null values delete fields, and anything else replaces.
+func mergeObject(obj, patch map[string]interface{}) {
+	for key, value := range patch {
+		if value == nil {
+			delete(obj, key)
+			continue
+		}
+		patchMap, isMap := value.(map[string]interface{})
+		existing, existingIsMap := obj[key].(map[string]interface{})
+		if isMap && existingIsMap {
+			mergeObject(existing, patchMap)
+			continue
+		}
+		obj[key] = value
+	}
+}
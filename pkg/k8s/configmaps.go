@@ -0,0 +1,248 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetConfigMapConsumers returns a ConfigMap's data keys together with the workloads in
+// its namespace whose pod template uses it: as a volume (directly or projected), through
+// envFrom, or through individual env entries. Deployments, StatefulSets, DaemonSets, and
+// CronJobs are checked, as are Jobs and Pods that no controller owns. Each consumer
+// reports whether it needs a restart to pick up a change: environment variables are
+// only read at container start, and volumes mounted with subPath are never updated,
+// while other volume mounts are refreshed by the kubelet.
+// Returns a map with the ConfigMap's keys and its consumers, or an error.
+func (c *Client) GetConfigMapConsumers(ctx context.Context, namespace, name string) (map[string]interface{}, error) {
+	cm, err := c.clientset().CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get configmap '%s': %w", name, err)
+	}
+
+	keys := []string{}
+	for key := range cm.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	binaryKeys := []string{}
+	for key := range cm.BinaryData {
+		binaryKeys = append(binaryKeys, key)
+	}
+	sort.Strings(binaryKeys)
+
+	consumers, err := c.configConsumers(ctx, namespace, "ConfigMap", name)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"name":       cm.Name,
+		"namespace":  cm.Namespace,
+		"keys":       keys,
+		"binaryKeys": binaryKeys,
+		"immutable":  cm.Immutable != nil && *cm.Immutable,
+		"consumers":  consumers,
+	}, nil
+}
+
+// configConsumers finds the workloads in a namespace whose pod template uses the named
+// ConfigMap or Secret (kind), checking Deployments, StatefulSets, DaemonSets, and
+// CronJobs, and the Jobs and Pods that no controller owns.
+func (c *Client) configConsumers(ctx context.Context, namespace, kind, name string) ([]map[string]interface{}, error) {
+	return c.podSpecConsumers(ctx, namespace, true, func(spec *corev1.PodSpec) ([]string, bool) {
+		return configUsages(spec, kind, name)
+	})
+}
+
+// configUsages describes how a pod spec uses the named ConfigMap or Secret (kind), and
+// whether a change to it only takes effect after the pods restart.
+func configUsages(spec *corev1.PodSpec, kind, name string) ([]string, bool) {
+	var usages []string
+	restartRequired := false
+	isConfigMap := kind == "ConfigMap"
+
+	volumes := map[string]bool{}
+	for _, volume := range spec.Volumes {
+		switch {
+		case isConfigMap && volume.ConfigMap != nil && volume.ConfigMap.Name == name,
+			!isConfigMap && volume.Secret != nil && volume.Secret.SecretName == name:
+			volumes[volume.Name] = true
+			usages = append(usages, "volume/"+volume.Name)
+		case volume.Projected != nil:
+			for _, source := range volume.Projected.Sources {
+				if (isConfigMap && source.ConfigMap != nil && source.ConfigMap.Name == name) ||
+					(!isConfigMap && source.Secret != nil && source.Secret.Name == name) {
+					volumes[volume.Name] = true
+					usages = append(usages, "projectedVolume/"+volume.Name)
+					break
+				}
+			}
+		}
+	}
+
+	containers := append(append([]corev1.Container{}, spec.InitContainers...), spec.Containers...)
+	for _, container := range containers {
+		for _, mount := range container.VolumeMounts {
+			if volumes[mount.Name] && mount.SubPath != "" {
+				usages = append(usages, fmt.Sprintf("subPath/%s/%s:%s", container.Name, mount.Name, mount.SubPath))
+				restartRequired = true
+			}
+		}
+		for _, source := range container.EnvFrom {
+			if (isConfigMap && source.ConfigMapRef != nil && source.ConfigMapRef.Name == name) ||
+				(!isConfigMap && source.SecretRef != nil && source.SecretRef.Name == name) {
+				usages = append(usages, "envFrom/"+container.Name)
+				restartRequired = true
+			}
+		}
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			key := ""
+			switch {
+			case isConfigMap && env.ValueFrom.ConfigMapKeyRef != nil && env.ValueFrom.ConfigMapKeyRef.Name == name:
+				key = env.ValueFrom.ConfigMapKeyRef.Key
+			case !isConfigMap && env.ValueFrom.SecretKeyRef != nil && env.ValueFrom.SecretKeyRef.Name == name:
+				key = env.ValueFrom.SecretKeyRef.Key
+			default:
+				continue
+			}
+			usages = append(usages, fmt.Sprintf("env/%s/%s#%s", container.Name, env.Name, key))
+			restartRequired = true
+		}
+	}
+	return usages, restartRequired
+}
+
+// restartableKinds are the consumer kinds UpdateConfigAndRestart can roll out.
+// CronJobs pick up the change on their next run; bare Jobs and Pods cannot be restarted.
+var restartableKinds = map[string]bool{"Deployment": true, "StatefulSet": true, "DaemonSet": true}
+
+// UpdateConfigAndRestart sets keys of a ConfigMap or Secret (kind) and then performs a
+// rollout restart of every Deployment, StatefulSet, and DaemonSet that uses it, so the
+// change takes effect. Keys not in data are left unchanged unless replace is true, in
+// which case data becomes the object's entire contents; Secret values are given in
+// plain text. When dryRun is true nothing is changed and the keys that would change and
+// the workloads that would be restarted are reported. A failure to restart one workload
+// does not stop the others.
+// Returns a map describing the update and the result for each consumer, or an error.
+func (c *Client) UpdateConfigAndRestart(ctx context.Context, kind, namespace, name string, data map[string]string, replace, dryRun bool) (map[string]interface{}, error) {
+	kind = c.resolveKind(kind)
+	var current map[string]string
+	var update func() error
+	switch kind {
+	case "ConfigMap":
+		cm, err := c.clientset().CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get configmap '%s': %w", name, err)
+		}
+		current = cm.Data
+		update = func() error {
+			cm.Data = mergeConfigData(cm.Data, data, replace)
+			_, err := c.clientset().CoreV1().ConfigMaps(namespace).Update(ctx, cm, metav1.UpdateOptions{})
+			return err
+		}
+	case "Secret":
+		secret, err := c.clientset().CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get secret '%s': %w", name, err)
+		}
+		current = map[string]string{}
+		for key, value := range secret.Data {
+			current[key] = string(value)
+		}
+		update = func() error {
+			merged := mergeConfigData(current, data, replace)
+			secret.Data = map[string][]byte{}
+			for key, value := range merged {
+				secret.Data[key] = []byte(value)
+			}
+			_, err := c.clientset().CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{})
+			return err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported kind '%s': expected ConfigMap or Secret", kind)
+	}
+
+	// Report key names only, so Secret values never appear in the result
+	var added, changed, removed []string
+	for key, value := range data {
+		if old, ok := current[key]; !ok {
+			added = append(added, key)
+		} else if old != value {
+			changed = append(changed, key)
+		}
+	}
+	if replace {
+		for key := range current {
+			if _, ok := data[key]; !ok {
+				removed = append(removed, key)
+			}
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(changed)
+	sort.Strings(removed)
+	modified := len(added)+len(changed)+len(removed) > 0
+
+	consumers, err := c.configConsumers(ctx, namespace, kind, name)
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]interface{}{
+		"kind":      kind,
+		"name":      name,
+		"namespace": namespace,
+		"added":     added,
+		"changed":   changed,
+		"removed":   removed,
+		"updated":   false,
+		"consumers": consumers,
+	}
+	if dryRun {
+		return result, nil
+	}
+
+	if modified {
+		if err := update(); err != nil {
+			return nil, fmt.Errorf("failed to update %s '%s': %w", kind, name, err)
+		}
+		result["updated"] = true
+	}
+
+	// Restart even when nothing changed, so that a retry after a partial failure
+	// still rolls out the workloads that were missed
+	for _, consumer := range consumers {
+		consumerKind := consumer["kind"].(string)
+		consumer["restarted"] = false
+		if !restartableKinds[consumerKind] {
+			continue
+		}
+		if _, err := c.RolloutRestart(ctx, consumerKind, consumer["name"].(string), namespace); err != nil {
+			consumer["error"] = err.Error()
+		} else {
+			consumer["restarted"] = true
+		}
+	}
+	return result, nil
+}
+
+// mergeConfigData returns current with the keys in data set, or data alone when replace is true.
+func mergeConfigData(current, data map[string]string, replace bool) map[string]string {
+	merged := map[string]string{}
+	if !replace {
+		for key, value := range current {
+			merged[key] = value
+		}
+	}
+	for key, value := range data {
+		merged[key] = value
+	}
+	return merged
+}
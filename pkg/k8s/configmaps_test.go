@@ -0,0 +1,104 @@
+package k8s_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/reza-gholizade/k8s-mcp-server/pkg/k8s/k8stest"
+)
+
+func TestGetConfigMapConsumers(t *testing.T) {
+	configMapVolume := corev1.Volume{Name: "config", VolumeSource: corev1.VolumeSource{
+		ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "web-config"}},
+	}}
+	tests := []struct {
+		name            string
+		spec            func(spec *corev1.PodSpec)
+		usages          []string
+		restartRequired bool
+	}{
+		{
+			// The kubelet refreshes mounted ConfigMaps in place
+			name:   "volume",
+			spec:   func(spec *corev1.PodSpec) { spec.Volumes = []corev1.Volume{configMapVolume} },
+			usages: []string{"volume/config"},
+		},
+		{
+			name: "volume mounted with subPath",
+			spec: func(spec *corev1.PodSpec) {
+				spec.Volumes = []corev1.Volume{configMapVolume}
+				spec.Containers[0].VolumeMounts = []corev1.VolumeMount{{Name: "config", MountPath: "/etc/app.yaml", SubPath: "app.yaml"}}
+			},
+			usages:          []string{"volume/config", "subPath/web/config:app.yaml"},
+			restartRequired: true,
+		},
+		{
+			name: "projected volume",
+			spec: func(spec *corev1.PodSpec) {
+				spec.Volumes = []corev1.Volume{{Name: "bundle", VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{
+					Sources: []corev1.VolumeProjection{{ConfigMap: &corev1.ConfigMapProjection{LocalObjectReference: corev1.LocalObjectReference{Name: "web-config"}}}},
+				}}}}
+			},
+			usages: []string{"projectedVolume/bundle"},
+		},
+		{
+			name: "env key in an init container",
+			spec: func(spec *corev1.PodSpec) {
+				spec.InitContainers = []corev1.Container{{Name: "migrate", Env: []corev1.EnvVar{{Name: "MODE", ValueFrom: &corev1.EnvVarSource{
+					ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "web-config"}, Key: "mode"},
+				}}}}}
+			},
+			usages:          []string{"env/migrate/MODE#mode"},
+			restartRequired: true,
+		},
+		{
+			// A Secret of the same name is a different object
+			name: "secret of the same name",
+			spec: func(spec *corev1.PodSpec) {
+				spec.Containers[0].EnvFrom = []corev1.EnvFromSource{{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "web-config"}}}}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objects := k8stest.Objects()
+			tt.spec(&seeded[*appsv1.Deployment](t, objects).Spec.Template.Spec)
+			objects = append(objects, &corev1.ConfigMap{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+				ObjectMeta: metav1.ObjectMeta{Name: "web-config", Namespace: k8stest.Namespace},
+				Data:       map[string]string{"mode": "fast", "app.yaml": "debug: false"},
+			})
+			client := k8stest.NewFakeClient(objects...)
+
+			result, err := client.GetConfigMapConsumers(context.Background(), k8stest.Namespace, "web-config")
+			if err != nil {
+				t.Fatalf("GetConfigMapConsumers: %v", err)
+			}
+			if keys := result["keys"].([]string); !slices.Equal(keys, []string{"app.yaml", "mode"}) {
+				t.Errorf("keys: got %v, want them sorted", keys)
+			}
+			consumers := result["consumers"].([]map[string]interface{})
+			if tt.usages == nil {
+				if len(consumers) != 0 {
+					t.Errorf("got consumers %v, want none", consumers)
+				}
+				return
+			}
+			// The seeded pods are owned by the Deployment's ReplicaSet, so only the Deployment is reported
+			if len(consumers) != 1 || consumers[0]["kind"] != "Deployment" {
+				t.Fatalf("got consumers %v, want the deployment", consumers)
+			}
+			if usages := consumers[0]["usages"].([]string); !slices.Equal(usages, tt.usages) {
+				t.Errorf("usages: got %v, want %v", usages, tt.usages)
+			}
+			if consumers[0]["restartRequired"] != tt.restartRequired {
+				t.Errorf("restartRequired: got %v, want %v", consumers[0]["restartRequired"], tt.restartRequired)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FindResourceConsumers finds the workloads in a namespace that depend on a ConfigMap,
+// Secret, PersistentVolumeClaim, or ServiceAccount (kind), so that the fan-out of a
+// change or deletion is known up front. Pod templates of Deployments, StatefulSets,
+// DaemonSets, and CronJobs are scanned, as are Jobs and Pods that no controller owns,
+// for every kind of reference: volumes (directly or projected), envFrom, env
+// valueFrom, and imagePullSecrets for ConfigMaps and Secrets; volumes for
+// PersistentVolumeClaims, including those a StatefulSet's volumeClaimTemplates
+// create; and serviceAccountName, which defaults to "default", for ServiceAccounts.
+// For ConfigMaps and Secrets each consumer also reports whether it needs a restart to
+// pick up a change. The object itself need not exist, so dangling references can be
+// found too.
+// Returns a map with whether the object exists and its consumers, or an error.
+func (c *Client) FindResourceConsumers(ctx context.Context, namespace, kind, name string) (map[string]interface{}, error) {
+	kind = c.resolveKind(kind)
+	var getErr error
+	var usages func(spec *corev1.PodSpec) ([]string, bool)
+	reportRestart := false
+	switch kind {
+	case "ConfigMap":
+		_, getErr = c.clientset().CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+		usages = func(spec *corev1.PodSpec) ([]string, bool) {
+			return configUsages(spec, kind, name)
+		}
+		reportRestart = true
+	case "Secret":
+		_, getErr = c.clientset().CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+		usages = func(spec *corev1.PodSpec) ([]string, bool) {
+			found, restartRequired := configUsages(spec, kind, name)
+			for _, ref := range spec.ImagePullSecrets {
+				if ref.Name == name {
+					found = append(found, "imagePullSecret")
+				}
+			}
+			return found, restartRequired
+		}
+		reportRestart = true
+	case "PersistentVolumeClaim":
+		_, getErr = c.clientset().CoreV1().PersistentVolumeClaims(namespace).Get(ctx, name, metav1.GetOptions{})
+		usages = func(spec *corev1.PodSpec) ([]string, bool) {
+			var found []string
+			for _, volume := range spec.Volumes {
+				if volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName == name {
+					found = append(found, "volume/"+volume.Name)
+				}
+			}
+			return found, false
+		}
+	case "ServiceAccount":
+		_, getErr = c.clientset().CoreV1().ServiceAccounts(namespace).Get(ctx, name, metav1.GetOptions{})
+		usages = func(spec *corev1.PodSpec) ([]string, bool) {
+			serviceAccount := spec.ServiceAccountName
+			if serviceAccount == "" {
+				serviceAccount = "default"
+			}
+			if serviceAccount == name {
+				return []string{"serviceAccountName"}, false
+			}
+			return nil, false
+		}
+	default:
+		return nil, fmt.Errorf("unsupported kind '%s': expected ConfigMap, Secret, PersistentVolumeClaim, or ServiceAccount", kind)
+	}
+	if getErr != nil && !errors.IsNotFound(getErr) {
+		return nil, fmt.Errorf("failed to get %s '%s': %w", kind, name, getErr)
+	}
+
+	consumers, err := c.podSpecConsumers(ctx, namespace, reportRestart, usages)
+	if err != nil {
+		return nil, err
+	}
+
+	// Claims created from a StatefulSet's volumeClaimTemplates are named
+	// <template>-<statefulset>-<ordinal> and do not appear in its pod template
+	if kind == "PersistentVolumeClaim" {
+		statefulSets, err := c.clientset().AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list statefulsets: %w", err)
+		}
+		for _, sts := range statefulSets.Items {
+			for _, template := range sts.Spec.VolumeClaimTemplates {
+				ordinal, ok := strings.CutPrefix(name, template.Name+"-"+sts.Name+"-")
+				if _, err := strconv.Atoi(ordinal); ok && err == nil {
+					consumers = append(consumers, map[string]interface{}{
+						"kind":   "StatefulSet",
+						"name":   sts.Name,
+						"usages": []string{"volumeClaimTemplate/" + template.Name + "#" + ordinal},
+					})
+				}
+			}
+		}
+	}
+
+	result := map[string]interface{}{
+		"kind":      kind,
+		"name":      name,
+		"namespace": namespace,
+		"exists":    getErr == nil,
+		"count":     len(consumers),
+		"consumers": consumers,
+	}
+	if getErr != nil && len(consumers) > 0 {
+		result["warning"] = fmt.Sprintf("%s '%s' does not exist but is referenced by %d workloads", kind, name, len(consumers))
+	}
+	return result, nil
+}
+
+// podSpecConsumers finds the workloads in a namespace whose pod template uses an object,
+// as reported by usages, checking Deployments, StatefulSets, DaemonSets, and CronJobs,
+// and the Jobs and Pods that no controller owns. With reportRestart, each consumer also
+// reports whether a change to the object only takes effect after its pods restart.
+func (c *Client) podSpecConsumers(ctx context.Context, namespace string, reportRestart bool, usages func(spec *corev1.PodSpec) ([]string, bool)) ([]map[string]interface{}, error) {
+	consumers := []map[string]interface{}{}
+	add := func(workloadKind string, meta metav1.ObjectMeta, spec *corev1.PodSpec) {
+		found, restartRequired := usages(spec)
+		if len(found) == 0 {
+			return
+		}
+		consumer := map[string]interface{}{
+			"kind":   workloadKind,
+			"name":   meta.Name,
+			"usages": found,
+		}
+		if reportRestart {
+			consumer["restartRequired"] = restartRequired
+		}
+		consumers = append(consumers, consumer)
+	}
+
+	deployments, err := c.clientset().AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	for _, d := range deployments.Items {
+		add("Deployment", d.ObjectMeta, &d.Spec.Template.Spec)
+	}
+
+	statefulSets, err := c.clientset().AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list statefulsets: %w", err)
+	}
+	for _, s := range statefulSets.Items {
+		add("StatefulSet", s.ObjectMeta, &s.Spec.Template.Spec)
+	}
+
+	daemonSets, err := c.clientset().AppsV1().DaemonSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list daemonsets: %w", err)
+	}
+	for _, ds := range daemonSets.Items {
+		add("DaemonSet", ds.ObjectMeta, &ds.Spec.Template.Spec)
+	}
+
+	cronJobs, err := c.clientset().BatchV1().CronJobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cronjobs: %w", err)
+	}
+	for _, cj := range cronJobs.Items {
+		add("CronJob", cj.ObjectMeta, &cj.Spec.JobTemplate.Spec.Template.Spec)
+	}
+
+	// Jobs and Pods created by a controller are covered by their owner above
+	jobs, err := c.clientset().BatchV1().Jobs(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	for _, j := range jobs.Items {
+		if metav1.GetControllerOf(&j) == nil {
+			add("Job", j.ObjectMeta, &j.Spec.Template.Spec)
+		}
+	}
+
+	pods, err := c.clientset().CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	for _, p := range pods.Items {
+		if metav1.GetControllerOf(&p) == nil {
+			add("Pod", p.ObjectMeta, &p.Spec)
+		}
+	}
+
+	return consumers, nil
+}
@@ -0,0 +1,83 @@
+package k8s_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/reza-gholizade/k8s-mcp-server/pkg/k8s/k8stest"
+)
+
+func TestFindResourceConsumers(t *testing.T) {
+	objects := k8stest.Objects()
+	deployment := seeded[*appsv1.Deployment](t, objects)
+	deployment.Spec.Template.Spec.Containers[0].EnvFrom = []corev1.EnvFromSource{{
+		ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "web-config"}},
+	}}
+	deployment.Spec.Template.Spec.Volumes = []corev1.Volume{{
+		Name:         "tls",
+		VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "web-tls"}},
+	}}
+	objects = append(objects, &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Name: "web-config", Namespace: k8stest.Namespace},
+	})
+	client := k8stest.NewFakeClient(objects...)
+
+	tests := []struct {
+		name            string
+		kind            string
+		object          string
+		exists          bool
+		usages          []string
+		restartRequired bool
+	}{
+		{name: "env from configmap", kind: "ConfigMap", object: "web-config", exists: true, usages: []string{"envFrom/web"}, restartRequired: true},
+		// A mounted Secret is updated in place, and the reference may dangle
+		{name: "mounted missing secret", kind: "Secret", object: "web-tls", exists: false, usages: []string{"volume/tls"}},
+		{name: "default service account", kind: "ServiceAccount", object: "default", exists: false, usages: []string{"serviceAccountName"}},
+		{name: "unused configmap", kind: "ConfigMap", object: "other", exists: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := client.FindResourceConsumers(context.Background(), k8stest.Namespace, tt.kind, tt.object)
+			if err != nil {
+				t.Fatalf("FindResourceConsumers: %v", err)
+			}
+			if result["exists"] != tt.exists {
+				t.Errorf("exists: got %v, want %v", result["exists"], tt.exists)
+			}
+			consumers := result["consumers"].([]map[string]interface{})
+			if tt.usages == nil {
+				if len(consumers) != 0 {
+					t.Errorf("got consumers %v, want none", consumers)
+				}
+				return
+			}
+			if len(consumers) != 1 {
+				t.Fatalf("got consumers %v, want the deployment", consumers)
+			}
+			consumer := consumers[0]
+			if consumer["kind"] != "Deployment" || consumer["name"] != k8stest.DeploymentName {
+				t.Errorf("got consumer %v/%v, want Deployment/%s", consumer["kind"], consumer["name"], k8stest.DeploymentName)
+			}
+			if usages := consumer["usages"].([]string); !slices.Equal(usages, tt.usages) {
+				t.Errorf("usages: got %v, want %v", usages, tt.usages)
+			}
+			if restart, ok := consumer["restartRequired"]; ok && restart != tt.restartRequired {
+				t.Errorf("restartRequired: got %v, want %v", restart, tt.restartRequired)
+			}
+			if _, warned := result["warning"]; warned != !tt.exists {
+				t.Errorf("warning: got %v, want one only for a missing object", result["warning"])
+			}
+		})
+	}
+
+	if _, err := client.FindResourceConsumers(context.Background(), k8stest.Namespace, "Service", "web"); err == nil {
+		t.Error("expected an error for an unsupported kind")
+	}
+}
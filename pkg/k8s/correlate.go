@@ -0,0 +1,201 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// maxCorrelatedPods bounds how many pods of a workload CorrelateAlert collects
+// logs and metrics for, so that a large Deployment doesn't flood the response.
+const maxCorrelatedPods = 3
+
+// alertTargetLabels maps the labels commonly attached to Kubernetes alerts
+// (by kube-state-metrics, cAdvisor, and the kube-prometheus rules) to the kind
+// they identify, in order of precedence.
+var alertTargetLabels = []struct {
+	label string
+	kind  string
+}{
+	{"pod", "Pod"},
+	{"deployment", "Deployment"},
+	{"statefulset", "StatefulSet"},
+	{"daemonset", "DaemonSet"},
+	{"job_name", "Job"},
+	{"persistentvolumeclaim", "PersistentVolumeClaim"},
+	{"node", "Node"},
+}
+
+// CorrelateAlert resolves the Kubernetes object an alert refers to from its labels
+// and gathers diagnostic context for it in one response: the object itself, its recent
+// events, and for pods (or the pods of a workload, unhealthy ones first) their logs
+// and current metrics. Failures to collect individual pieces are reported in an
+// errors list rather than failing the whole correlation.
+// Returns a map containing the target and the collected context, or an error.
+func (c *Client) CorrelateAlert(ctx context.Context, alertLabels map[string]string) (map[string]interface{}, error) {
+	namespace := alertLabels["namespace"]
+
+	kind, name := "", ""
+	for _, target := range alertTargetLabels {
+		if value := alertLabels[target.label]; value != "" {
+			kind, name = target.kind, value
+			break
+		}
+	}
+	if kind == "" {
+		return nil, fmt.Errorf("alert labels do not identify a Kubernetes object: expected one of pod, deployment, statefulset, daemonset, job_name, persistentvolumeclaim, or node")
+	}
+	if kind != "Node" && namespace == "" {
+		return nil, fmt.Errorf("alert labels identify %s '%s' but have no namespace label", kind, name)
+	}
+	if kind == "Node" {
+		namespace = ""
+	}
+
+	result := map[string]interface{}{
+		"target": map[string]interface{}{"kind": kind, "name": name, "namespace": namespace},
+	}
+	var errs []string
+
+	resource, err := c.GetResource(ctx, kind, name, namespace, ConsistencyStrong)
+	if err != nil {
+		errs = append(errs, err.Error())
+	} else {
+		result["resource"] = c.MaskSensitiveFields(resource)
+	}
+
+	events, err := c.objectEvents(ctx, namespace, name)
+	if err != nil {
+		errs = append(errs, err.Error())
+	} else {
+		result["events"] = events
+	}
+
+	pods, err := c.alertPods(ctx, kind, name, namespace)
+	if err != nil {
+		errs = append(errs, err.Error())
+	}
+
+	var podContext []map[string]interface{}
+	for _, pod := range pods {
+		entry := map[string]interface{}{
+			"name":  pod.Name,
+			"phase": pod.Status.Phase,
+			"ready": isPodReady(&pod),
+		}
+		if pod.Name != name {
+			if events, err := c.objectEvents(ctx, pod.Namespace, pod.Name); err == nil {
+				entry["events"] = events
+			}
+		}
+		if logs, err := c.GetPodsLogs(ctx, pod.Namespace, "", pod.Name, false, 0); err != nil {
+			errs = append(errs, err.Error())
+		} else {
+			entry["logs"] = logs
+		}
+		if metrics, err := c.GetPodMetrics(ctx, pod.Namespace, pod.Name, false); err == nil {
+			entry["metrics"] = metrics
+		}
+		podContext = append(podContext, entry)
+	}
+	if len(podContext) > 0 {
+		result["pods"] = podContext
+	}
+
+	if len(errs) > 0 {
+		result["errors"] = errs
+	}
+	return result, nil
+}
+
+// objectEvents returns the events involving the named object, newest first.
+func (c *Client) objectEvents(ctx context.Context, namespace, name string) ([]map[string]interface{}, error) {
+	eventList, err := c.clientset().CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("involvedObject.name", name).String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve events for '%s': %w", name, err)
+	}
+
+	sort.SliceStable(eventList.Items, func(i, j int) bool {
+		return eventLastSeen(eventList.Items[i]).After(eventLastSeen(eventList.Items[j]))
+	})
+
+	var events []map[string]interface{}
+	for _, event := range eventList.Items {
+		events = append(events, map[string]interface{}{
+			"type":     event.Type,
+			"reason":   event.Reason,
+			"object":   event.InvolvedObject.Kind + "/" + event.InvolvedObject.Name,
+			"message":  event.Message,
+			"count":    event.Count,
+			"lastSeen": eventLastSeen(event),
+		})
+	}
+	return events, nil
+}
+
+// alertPods returns the pods behind an alert target: the pod itself, or up to
+// maxCorrelatedPods pods selected by a workload, preferring pods that are not ready.
+func (c *Client) alertPods(ctx context.Context, kind, name, namespace string) ([]corev1.Pod, error) {
+	var selector *metav1.LabelSelector
+	switch kind {
+	case "Pod":
+		pod, err := c.clientset().CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pod '%s': %w", name, err)
+		}
+		return []corev1.Pod{*pod}, nil
+	case "Deployment":
+		deployment, err := c.clientset().AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, nil
+		}
+		selector = deployment.Spec.Selector
+	case "StatefulSet":
+		statefulSet, err := c.clientset().AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, nil
+		}
+		selector = statefulSet.Spec.Selector
+	case "DaemonSet":
+		daemonSet, err := c.clientset().AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, nil
+		}
+		selector = daemonSet.Spec.Selector
+	case "Job":
+		job, err := c.clientset().BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, nil
+		}
+		selector = job.Spec.Selector
+	default:
+		return nil, nil
+	}
+	if selector == nil {
+		return nil, nil
+	}
+
+	labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector on %s '%s': %w", kind, name, err)
+	}
+	podList, err := c.clientset().CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods for %s '%s': %w", kind, name, err)
+	}
+
+	pods := podList.Items
+	sort.SliceStable(pods, func(i, j int) bool {
+		return !isPodReady(&pods[i]) && isPodReady(&pods[j])
+	})
+	if len(pods) > maxCorrelatedPods {
+		pods = pods[:maxCorrelatedPods]
+	}
+	return pods, nil
+}
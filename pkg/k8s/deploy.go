@@ -0,0 +1,344 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
+)
+
+// deployFailureTailLines is how many log lines DeployAndVerify collects per failing container.
+const deployFailureTailLines = int64(50)
+
+// deployFailureReasons are the container waiting reasons that DeployAndVerify treats
+// as a failed deployment rather than pods that are still starting, in addition to
+// imagePullWaitingReasons.
+var deployFailureReasons = map[string]bool{
+	"CrashLoopBackOff":           true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+	"RunContainerError":          true,
+}
+
+// DeployAndVerify applies a Deployment, StatefulSet, or DaemonSet manifest and follows
+// the rollout until it completes, fails, or the timeout expires. The workload's pods
+// of the new revision are checked on every poll once the controller has observed the
+// applied spec, and as soon as one of their containers is crash looping, cannot pull
+// its image, or cannot be created, the rollout is reported as failed without waiting
+// for the timeout. For a failed or timed-out rollout, the logs (including the previous
+// run of a restarted container) and the events of every failing or unready pod are
+// collected into the report. Every status change and detected failure is passed to
+// onUpdate, if set, as it occurs.
+// Returns a map with the outcome, the rollout status, and the failure report, or an error.
+func (c *Client) DeployAndVerify(ctx context.Context, namespace, manifest, kind string, timeout time.Duration, onUpdate func(update map[string]interface{})) (map[string]interface{}, error) {
+	kind = c.resolveKind(kind)
+	jsonData, err := yaml.YAMLToJSON([]byte(manifest))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	obj := &unstructured.Unstructured{}
+	if err := json.Unmarshal(jsonData, &obj.Object); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	if kind == "" {
+		kind = obj.GetKind()
+	}
+	switch kind {
+	case "Deployment", "StatefulSet", "DaemonSet":
+	default:
+		return nil, fmt.Errorf("deploy and verify is not supported for kind '%s': expected Deployment, StatefulSet, or DaemonSet", kind)
+	}
+	name := obj.GetName()
+	if name == "" {
+		return nil, fmt.Errorf("resource name is required in manifest")
+	}
+	if namespace == "" {
+		namespace = obj.GetNamespace()
+	}
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	if _, err := c.CreateOrUpdateResourceYAML(ctx, namespace, manifest, kind, ""); err != nil {
+		return nil, err
+	}
+
+	// The report is gathered after the rollout timeout, so it uses the caller's context
+	reportCtx := ctx
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	report := func(update map[string]interface{}) {
+		if onUpdate != nil {
+			update["elapsed"] = time.Since(start).Round(time.Second).String()
+			onUpdate(update)
+		}
+	}
+
+	ticker := time.NewTicker(rolloutPollInterval)
+	defer ticker.Stop()
+
+	lastMessage := ""
+	var status RolloutStatus
+	var pods []corev1.Pod
+	var failing map[string]string
+	for {
+		// A poll cut short by the timeout keeps the last status that was read
+		current, err := c.GetRolloutStatus(ctx, kind, name, namespace)
+		if err != nil && ctx.Err() == nil {
+			return nil, err
+		}
+		if err == nil {
+			status = current
+			if status.Message != lastMessage {
+				lastMessage = status.Message
+				report(map[string]interface{}{"type": "status", "message": status.Message})
+			}
+		}
+		if status.Done || status.Failed || ctx.Err() != nil {
+			break
+		}
+
+		if current, err := c.rolloutPods(ctx, kind, name, namespace); err == nil {
+			pods = current
+			failing = failingContainers(pods)
+			if len(failing) > 0 {
+				for container, reason := range failing {
+					report(map[string]interface{}{"type": "failure", "container": container, "reason": reason})
+				}
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-ticker.C:
+		}
+	}
+
+	outcome := "succeeded"
+	switch {
+	case len(failing) > 0 || status.Failed:
+		outcome = "failed"
+	case !status.Done:
+		outcome = "timeout"
+	}
+	result := map[string]interface{}{
+		"kind":      kind,
+		"name":      name,
+		"namespace": namespace,
+		"outcome":   outcome,
+		"status":    status.Message,
+		"duration":  time.Since(start).Round(time.Second).String(),
+	}
+	if outcome == "succeeded" {
+		return result, nil
+	}
+
+	if current, err := c.rolloutPods(reportCtx, kind, name, namespace); err == nil {
+		pods = current
+	}
+	result["failures"] = c.deployFailureReport(reportCtx, namespace, pods)
+	return result, nil
+}
+
+// rolloutPods returns the pods of the revision a Deployment, StatefulSet, or DaemonSet
+// is rolling out: the pods of a Deployment's current ReplicaSet, or the pods carrying
+// the update revision of a StatefulSet or DaemonSet. Pods of earlier revisions, which
+// are replaced as the rollout proceeds, are left out. Until the controller has observed
+// the workload's latest generation the new revision is not known yet, and no pods are
+// returned.
+func (c *Client) rolloutPods(ctx context.Context, kind, name, namespace string) ([]corev1.Pod, error) {
+	switch kind {
+	case "Deployment":
+		d, err := c.clientset().AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get deployment '%s': %w", name, err)
+		}
+		if d.Generation > d.Status.ObservedGeneration {
+			return nil, nil
+		}
+		replicaSets, err := c.ownedReplicaSets(ctx, d)
+		if err != nil {
+			return nil, err
+		}
+		// The deployment controller copies the revision of the current ReplicaSet onto the Deployment
+		revision := d.Annotations[deploymentRevisionAnnotation]
+		for _, rs := range replicaSets {
+			if rs.Annotations[deploymentRevisionAnnotation] == revision {
+				return c.ownedPods(ctx, namespace, rs.Spec.Selector, map[types.UID]bool{rs.UID: true})
+			}
+		}
+		return nil, nil
+	case "StatefulSet":
+		s, err := c.clientset().AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get statefulset '%s': %w", name, err)
+		}
+		if s.Generation > s.Status.ObservedGeneration || s.Status.UpdateRevision == "" {
+			return nil, nil
+		}
+		pods, err := c.ownedPods(ctx, namespace, s.Spec.Selector, map[types.UID]bool{s.UID: true})
+		if err != nil {
+			return nil, err
+		}
+		return podsWithRevision(pods, s.Status.UpdateRevision), nil
+	case "DaemonSet":
+		d, err := c.clientset().AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get daemonset '%s': %w", name, err)
+		}
+		if d.Generation > d.Status.ObservedGeneration {
+			return nil, nil
+		}
+		hash, err := c.daemonSetUpdateRevision(ctx, d)
+		if err != nil || hash == "" {
+			return nil, err
+		}
+		pods, err := c.ownedPods(ctx, namespace, d.Spec.Selector, map[types.UID]bool{d.UID: true})
+		if err != nil {
+			return nil, err
+		}
+		return podsWithRevision(pods, hash), nil
+	default:
+		return nil, fmt.Errorf("unsupported workload kind '%s'", kind)
+	}
+}
+
+// daemonSetUpdateRevision returns the revision hash of the newest ControllerRevision
+// of a DaemonSet, which the daemonset controller labels its up-to-date pods with.
+func (c *Client) daemonSetUpdateRevision(ctx context.Context, d *appsv1.DaemonSet) (string, error) {
+	selector, err := metav1.LabelSelectorAsSelector(d.Spec.Selector)
+	if err != nil {
+		return "", fmt.Errorf("invalid selector on daemonset '%s': %w", d.Name, err)
+	}
+	list, err := c.clientset().AppsV1().ControllerRevisions(d.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return "", fmt.Errorf("failed to list controller revisions for daemonset '%s': %w", d.Name, err)
+	}
+
+	var newest *appsv1.ControllerRevision
+	for i := range list.Items {
+		revision := &list.Items[i]
+		if owner := metav1.GetControllerOf(revision); owner == nil || owner.UID != d.UID {
+			continue
+		}
+		if newest == nil || revision.Revision > newest.Revision {
+			newest = revision
+		}
+	}
+	if newest == nil {
+		return "", nil
+	}
+	return newest.Labels[appsv1.DefaultDaemonSetUniqueLabelKey], nil
+}
+
+// podsWithRevision returns the pods labelled with the given controller revision hash.
+func podsWithRevision(pods []corev1.Pod, hash string) []corev1.Pod {
+	var matching []corev1.Pod
+	for _, pod := range pods {
+		if pod.Labels[appsv1.ControllerRevisionHashLabelKey] == hash {
+			matching = append(matching, pod)
+		}
+	}
+	return matching
+}
+
+// failingContainers returns the containers of the pods that are in a waiting state
+// DeployAndVerify treats as failed, keyed by "pod/container", with the waiting reason.
+func failingContainers(pods []corev1.Pod) map[string]string {
+	failing := map[string]string{}
+	for _, pod := range pods {
+		statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+		for _, status := range statuses {
+			if waiting := status.State.Waiting; waiting != nil && (deployFailureReasons[waiting.Reason] || imagePullWaitingReasons[waiting.Reason]) {
+				failing[pod.Name+"/"+status.Name] = waiting.Reason
+			}
+		}
+	}
+	return failing
+}
+
+// deployFailureReport describes every pod that is not ready: the state of each
+// container that is not ready, with its recent logs and, if it restarted, the logs of
+// its previous run, and the pod's events.
+func (c *Client) deployFailureReport(ctx context.Context, namespace string, pods []corev1.Pod) []map[string]interface{} {
+	report := []map[string]interface{}{}
+	for i := range pods {
+		pod := &pods[i]
+		if isPodReady(pod) || pod.DeletionTimestamp != nil {
+			continue
+		}
+
+		containers := []map[string]interface{}{}
+		statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+		for _, status := range statuses {
+			if status.Ready {
+				continue
+			}
+			entry := map[string]interface{}{
+				"name":         status.Name,
+				"image":        status.Image,
+				"restartCount": status.RestartCount,
+			}
+			switch {
+			case status.State.Waiting != nil:
+				entry["state"] = "waiting"
+				entry["reason"] = status.State.Waiting.Reason
+				entry["message"] = status.State.Waiting.Message
+			case status.State.Terminated != nil:
+				entry["state"] = "terminated"
+				entry["reason"] = status.State.Terminated.Reason
+				entry["exitCode"] = status.State.Terminated.ExitCode
+			case status.State.Running != nil:
+				entry["state"] = "running"
+			}
+			if last := status.LastTerminationState.Terminated; last != nil {
+				entry["lastTermination"] = terminationDetails(last)
+			}
+			if status.State.Running != nil || status.State.Terminated != nil {
+				entry["logs"] = c.containerLogTail(ctx, namespace, pod.Name, status.Name, false, deployFailureTailLines)
+			}
+			if status.RestartCount > 0 {
+				entry["previousLogs"] = c.containerLogTail(ctx, namespace, pod.Name, status.Name, true, deployFailureTailLines)
+			}
+			containers = append(containers, entry)
+		}
+
+		podReport := map[string]interface{}{
+			"pod":        pod.Name,
+			"phase":      pod.Status.Phase,
+			"node":       pod.Spec.NodeName,
+			"containers": containers,
+		}
+		if events, err := c.objectEvents(ctx, namespace, pod.Name); err == nil {
+			podReport["events"] = events
+		} else {
+			podReport["eventsError"] = err.Error()
+		}
+		report = append(report, podReport)
+	}
+	return report
+}
+
+// containerLogTail returns the last tailLines lines of a container's logs, or of its
+// previous run, or a description of why they could not be read.
+func (c *Client) containerLogTail(ctx context.Context, namespace, podName, containerName string, previous bool, tailLines int64) string {
+	logs, err := c.clientset().CoreV1().Pods(namespace).GetLogs(podName, &corev1.PodLogOptions{
+		Container: containerName,
+		Previous:  previous,
+		TailLines: &tailLines,
+	}).DoRaw(ctx)
+	if err != nil {
+		return fmt.Sprintf("failed to get logs: %v", err)
+	}
+	return string(logs)
+}
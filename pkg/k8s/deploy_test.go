@@ -0,0 +1,104 @@
+package k8s_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/reza-gholizade/k8s-mcp-server/pkg/k8s/k8stest"
+)
+
+const deployManifest = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+`
+
+// rollingOutObjects returns the objects of a rollout to revision 2 in progress: one
+// pod of the new ReplicaSet has been created, and both pods of revision 1 are still
+// running. The container of the old pods is set to oldState and that of the new pod to
+// newState.
+func rollingOutObjects(t *testing.T, oldState, newState corev1.ContainerState) []runtime.Object {
+	objects := rolledOutObjects(t)
+	deployment := seeded[*appsv1.Deployment](t, objects)
+	deployment.Status.UpdatedReplicas = 1
+
+	var oldPod *corev1.Pod
+	for _, obj := range objects {
+		if pod, ok := obj.(*corev1.Pod); ok {
+			pod.Status.ContainerStatuses[0].State = oldState
+			pod.Status.ContainerStatuses[0].Ready = false
+			pod.Status.Conditions[0].Status = corev1.ConditionFalse
+			oldPod = pod
+		}
+	}
+
+	newReplicaSet := objects[len(objects)-1].(*appsv1.ReplicaSet)
+	newHash := "7c9d6b5f4"
+	newReplicaSet.Labels = map[string]string{"app": k8stest.DeploymentName, appsv1.DefaultDeploymentUniqueLabelKey: newHash}
+	newReplicaSet.Spec.Selector.MatchLabels = newReplicaSet.Labels
+	newReplicaSet.Spec.Template.Labels = newReplicaSet.Labels
+
+	newPod := oldPod.DeepCopy()
+	newPod.Name = newReplicaSet.Name + "-klmno"
+	newPod.UID = "fake-pod-uid-" + types.UID(newPod.Name)
+	newPod.Labels = newReplicaSet.Labels
+	newPod.OwnerReferences[0].Name = newReplicaSet.Name
+	newPod.OwnerReferences[0].UID = newReplicaSet.UID
+	newPod.Status.ContainerStatuses[0].Image = "nginx:1.28"
+	newPod.Status.ContainerStatuses[0].State = newState
+	return append(objects, newPod)
+}
+
+func TestDeployAndVerifyChecksNewRevisionPods(t *testing.T) {
+	crashing := corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}
+	starting := corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}}
+	tests := []struct {
+		name        string
+		old, new    corev1.ContainerState
+		wantOutcome string
+		wantFailing string
+	}{
+		// Pods of the previous revision are replaced by the rollout, so their failures do not fail it
+		{name: "old revision crashing", old: crashing, new: starting, wantOutcome: "timeout"},
+		{name: "new revision crashing", old: starting, new: crashing, wantOutcome: "failed", wantFailing: "web-7c9d6b5f4-klmno"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := k8stest.NewFakeClient(rollingOutObjects(t, tt.old, tt.new)...)
+			var failures []string
+			result, err := client.DeployAndVerify(context.Background(), k8stest.Namespace, deployManifest, "", 100*time.Millisecond, func(update map[string]interface{}) {
+				if update["type"] == "failure" {
+					failures = append(failures, update["container"].(string))
+				}
+			})
+			if err != nil {
+				t.Fatalf("DeployAndVerify: %v", err)
+			}
+			if result["outcome"] != tt.wantOutcome {
+				t.Errorf("outcome: got %v, want %s", result["outcome"], tt.wantOutcome)
+			}
+			if result["status"] != "1 out of 2 new replicas have been updated" {
+				t.Errorf("status: got %q, want the last rollout status", result["status"])
+			}
+			if tt.wantFailing == "" {
+				if len(failures) > 0 {
+					t.Errorf("expected no failures, got %v", failures)
+				}
+			} else if len(failures) != 1 || failures[0] != tt.wantFailing+"/web" {
+				t.Errorf("failures: got %v, want %s/web", failures, tt.wantFailing)
+			}
+
+			// The report only covers the pods of the new revision
+			report := result["failures"].([]map[string]interface{})
+			if len(report) != 1 || report[0]["pod"] != "web-7c9d6b5f4-klmno" {
+				t.Errorf("expected a report on the new pod only, got %v", report)
+			}
+		})
+	}
+}
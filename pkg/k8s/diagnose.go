@@ -0,0 +1,150 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// diagnoseLogLines is how many log lines DiagnosePod collects per container and run.
+const diagnoseLogLines = int64(200)
+
+// DiagnosePod gathers everything needed to troubleshoot a pod in one call: its status
+// and conditions, the state of each container with exit codes of its current and
+// previous run, recent events, the last diagnoseLogLines log lines of each container
+// (and of its previous run if it has restarted), resource usage against requests and
+// limits, and the workload that owns it. Findings, such as containers in
+// CrashLoopBackOff or killed for running out of memory, are summarized up front.
+// Sections that cannot be gathered, e.g. metrics without metrics-server, are reported
+// in an errors list instead of failing the diagnosis.
+// Returns a map with one key per section, or an error if the pod cannot be read.
+func (c *Client) DiagnosePod(ctx context.Context, namespace, podName string) (map[string]interface{}, error) {
+	pod, err := c.clientset().CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pod '%s' in namespace '%s': %w", podName, namespace, err)
+	}
+
+	var errs []string
+	status := map[string]interface{}{
+		"phase":    pod.Status.Phase,
+		"ready":    isPodReady(pod),
+		"nodeName": pod.Spec.NodeName,
+		"podIP":    pod.Status.PodIP,
+		"qosClass": pod.Status.QOSClass,
+	}
+	if pod.Status.StartTime != nil {
+		status["startTime"] = pod.Status.StartTime.Time
+	}
+	if pod.Status.Reason != "" {
+		status["reason"] = pod.Status.Reason
+		status["message"] = pod.Status.Message
+	}
+	conditions := []map[string]interface{}{}
+	for _, condition := range pod.Status.Conditions {
+		entry := map[string]interface{}{
+			"type":               condition.Type,
+			"status":             condition.Status,
+			"lastTransitionTime": condition.LastTransitionTime.Time,
+		}
+		if condition.Reason != "" {
+			entry["reason"] = condition.Reason
+			entry["message"] = condition.Message
+		}
+		conditions = append(conditions, entry)
+	}
+	status["conditions"] = conditions
+
+	result := map[string]interface{}{
+		"pod":       podName,
+		"namespace": namespace,
+		"status":    status,
+	}
+
+	var findings []string
+	containers := []map[string]interface{}{}
+	logs := map[string]interface{}{}
+	addStatuses := func(containerType string, statuses []corev1.ContainerStatus) {
+		for _, cs := range statuses {
+			entry := map[string]interface{}{
+				"name":         cs.Name,
+				"type":         containerType,
+				"image":        cs.Image,
+				"ready":        cs.Ready,
+				"restartCount": cs.RestartCount,
+			}
+			started := false
+			switch {
+			case cs.State.Waiting != nil:
+				entry["state"] = map[string]interface{}{"waiting": map[string]interface{}{"reason": cs.State.Waiting.Reason, "message": cs.State.Waiting.Message}}
+				if reason := cs.State.Waiting.Reason; reason != "" && reason != "ContainerCreating" && reason != "PodInitializing" {
+					findings = append(findings, fmt.Sprintf("container '%s' is waiting: %s", cs.Name, reason))
+				}
+			case cs.State.Running != nil:
+				entry["state"] = map[string]interface{}{"running": map[string]interface{}{"startedAt": cs.State.Running.StartedAt.Time}}
+				started = true
+			case cs.State.Terminated != nil:
+				entry["state"] = map[string]interface{}{"terminated": terminationDetails(cs.State.Terminated)}
+				started = true
+				if cs.State.Terminated.ExitCode != 0 {
+					findings = append(findings, fmt.Sprintf("container '%s' exited with code %d (%s)", cs.Name, cs.State.Terminated.ExitCode, cs.State.Terminated.Reason))
+				}
+			}
+			previous := cs.LastTerminationState.Terminated
+			if previous != nil {
+				entry["lastTermination"] = terminationDetails(previous)
+				if previous.Reason == "OOMKilled" {
+					findings = append(findings, fmt.Sprintf("container '%s' was OOMKilled on its previous run; consider raising its memory limit", cs.Name))
+				}
+			}
+			containers = append(containers, entry)
+
+			containerLogs := map[string]interface{}{}
+			if started {
+				containerLogs["current"] = c.containerLogTail(ctx, namespace, podName, cs.Name, false, diagnoseLogLines)
+			}
+			if previous != nil {
+				containerLogs["previous"] = c.containerLogTail(ctx, namespace, podName, cs.Name, true, diagnoseLogLines)
+			}
+			if len(containerLogs) > 0 {
+				logs[cs.Name] = containerLogs
+			}
+		}
+	}
+	addStatuses("init", pod.Status.InitContainerStatuses)
+	addStatuses("container", pod.Status.ContainerStatuses)
+	result["containers"] = containers
+	result["logs"] = logs
+
+	if pod.Spec.NodeName == "" {
+		findings = append(findings, "the pod is not scheduled to a node; use diagnoseScheduling to see why")
+	}
+
+	if events, err := c.objectEvents(ctx, namespace, podName); err != nil {
+		errs = append(errs, err.Error())
+	} else {
+		result["events"] = events
+	}
+
+	if metrics, err := c.GetPodMetrics(ctx, namespace, podName, true); err != nil {
+		errs = append(errs, err.Error())
+	} else {
+		result["metrics"] = metrics
+	}
+
+	if owner, err := c.GetPodOwner(ctx, namespace, podName); err != nil {
+		errs = append(errs, err.Error())
+	} else {
+		result["owner"] = owner
+	}
+
+	if findings == nil {
+		findings = []string{}
+	}
+	result["findings"] = findings
+	if len(errs) > 0 {
+		result["errors"] = errs
+	}
+	return result, nil
+}
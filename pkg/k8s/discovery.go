@@ -0,0 +1,67 @@
+package k8s
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/restmapper"
+)
+
+// newRESTMapper returns a REST mapper backed by the given discovery client, which
+// also resolves short names such as "deploy" or "po".
+func newRESTMapper(discoveryClient discovery.CachedDiscoveryInterface) meta.RESTMapper {
+	return restmapper.NewShortcutExpander(restmapper.NewDeferredDiscoveryRESTMapper(discoveryClient), discoveryClient, nil)
+}
+
+// DiscoveryClient returns the client's discovery client. It caches the server's API
+// groups and resources in memory, and is safe for concurrent use, so it can be shared,
+// e.g. with the Helm client, to discover the API once for the whole server.
+func (c *Client) DiscoveryClient() discovery.CachedDiscoveryInterface {
+	return c.conns.Load().discoveryClient
+}
+
+// RESTMapper returns the client's REST mapper, backed by DiscoveryClient, so that
+// callers sharing it resolve kinds to the same resources as the client does.
+func (c *Client) RESTMapper() meta.RESTMapper {
+	return c.conns.Load().restMapper
+}
+
+// invalidateDiscovery drops the cached discovery data, so that it is fetched again
+// from the server on next use, e.g. to see the kinds of a CRD installed since.
+func (c *Client) invalidateDiscovery() {
+	conns := c.conns.Load()
+	if mapper, ok := conns.restMapper.(meta.ResettableRESTMapper); ok {
+		mapper.Reset()
+	}
+	conns.discoveryClient.Invalidate()
+}
+
+// preferredResources returns the server's preferred API resources. When discovery
+// fails only for some API groups, typically because an aggregated APIService such as
+// metrics.k8s.io is unavailable, the failures are logged and the resources of every
+// other group are returned together with the group versions that failed, so that a
+// single broken APIService does not break lookups for unrelated kinds.
+// Returns the discovered resource lists and the failed group versions, or an error if
+// discovery failed as a whole.
+func (c *Client) preferredResources() ([]*metav1.APIResourceList, []string, error) {
+	resourceLists, err := c.DiscoveryClient().ServerPreferredResources()
+	if err == nil {
+		return resourceLists, nil, nil
+	}
+
+	groupErrs, ok := discovery.GroupDiscoveryFailedErrorGroups(err)
+	if !ok {
+		return nil, nil, fmt.Errorf("failed to retrieve API resources: %w", err)
+	}
+	var failed []string
+	for gv, gvErr := range groupErrs {
+		failed = append(failed, gv.String())
+		log.Printf("API discovery failed for %s, continuing without it: %v", gv.String(), gvErr)
+	}
+	sort.Strings(failed)
+	return resourceLists, failed, nil
+}
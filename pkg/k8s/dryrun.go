@@ -0,0 +1,83 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
+)
+
+// DryRunResource submits a YAML or JSON manifest the same way createOrUpdateResource
+// does (a merge patch, falling back to create when the object does not exist) but with
+// DryRun set to All, so nothing is persisted. The API server still runs defaulting and
+// admission, so the returned object shows what would actually be stored: defaulted
+// fields, mutating webhook changes, and validation errors.
+// Returns a map with the operation that would be performed and the resulting object, or an error.
+func (c *Client) DryRunResource(ctx context.Context, namespace, manifest, kind string) (map[string]interface{}, error) {
+	kind = c.resolveKind(kind)
+	jsonData, err := yaml.YAMLToJSON([]byte(manifest))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+
+	obj := &unstructured.Unstructured{}
+	if err := json.Unmarshal(jsonData, &obj.Object); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+
+	if kind == "" {
+		kind = obj.GetKind()
+		if kind == "" {
+			return nil, fmt.Errorf("resource kind is required: either provide it as a parameter or include it in the manifest")
+		}
+	}
+	if obj.GetName() == "" {
+		return nil, fmt.Errorf("resource name is required in manifest")
+	}
+
+	gvr, err := c.getCachedGVR(kind)
+	if err != nil {
+		return nil, err
+	}
+	namespaced, err := c.isNamespaced(kind)
+	if err != nil {
+		return nil, err
+	}
+	if !namespaced {
+		obj.SetNamespace("")
+	} else if namespace != "" {
+		obj.SetNamespace(namespace)
+	}
+	if namespaced && obj.GetNamespace() == "" {
+		obj.SetNamespace("default")
+	}
+
+	resource := c.dynamicClient().Resource(*gvr).Namespace(obj.GetNamespace())
+
+	operation := "update"
+	result, err := resource.Patch(ctx, obj.GetName(), types.MergePatchType, jsonData, metav1.PatchOptions{
+		DryRun: []string{metav1.DryRunAll},
+	})
+	if errors.IsNotFound(err) {
+		operation = "create"
+		result, err = resource.Create(ctx, obj, metav1.CreateOptions{
+			DryRun: []string{metav1.DryRunAll},
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("dry run rejected by the API server: %w", err)
+	}
+
+	content := result.UnstructuredContent()
+	unstructured.RemoveNestedField(content, "metadata", "managedFields")
+
+	return map[string]interface{}{
+		"operation": operation,
+		"result":    content,
+	}, nil
+}
@@ -0,0 +1,360 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// GetSortedEvents returns events ordered by when they were last seen, newest first,
+// in the compact form of `kubectl get events`. Events for the same object with the
+// same type, reason, and message are collapsed into one entry whose count is the
+// total number of occurrences. Each entry carries a relative age such as "2m ago".
+// An empty namespace lists events across all namespaces; a positive limit caps the
+// number of entries returned.
+// Returns a slice of maps, each describing an event, or an error.
+func (c *Client) GetSortedEvents(ctx context.Context, namespace string, limit int) ([]map[string]interface{}, error) {
+	eventList, err := c.clientset().CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve events: %w", err)
+	}
+
+	type groupedEvent struct {
+		event     corev1.Event
+		count     int32
+		firstSeen time.Time
+		lastSeen  time.Time
+	}
+
+	groups := map[string]*groupedEvent{}
+	var order []*groupedEvent
+	for _, event := range eventList.Items {
+		object := event.InvolvedObject
+		key := fmt.Sprintf("%s/%s/%s/%s/%s/%s", object.Kind, object.Namespace, object.Name, event.Type, event.Reason, event.Message)
+
+		count := event.Count
+		if event.Series != nil && event.Series.Count > count {
+			count = event.Series.Count
+		}
+		if count == 0 {
+			count = 1
+		}
+		firstSeen, lastSeen := eventFirstSeen(event), eventLastSeen(event)
+
+		group, ok := groups[key]
+		if !ok {
+			group = &groupedEvent{event: event, firstSeen: firstSeen, lastSeen: lastSeen}
+			groups[key] = group
+			order = append(order, group)
+		}
+		group.count += count
+		if firstSeen.Before(group.firstSeen) {
+			group.firstSeen = firstSeen
+		}
+		if lastSeen.After(group.lastSeen) {
+			group.lastSeen = lastSeen
+		}
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return order[i].lastSeen.After(order[j].lastSeen)
+	})
+	if limit > 0 && len(order) > limit {
+		order = order[:limit]
+	}
+
+	now := time.Now()
+	events := []map[string]interface{}{}
+	for _, group := range order {
+		object := group.event.InvolvedObject
+		events = append(events, map[string]interface{}{
+			"namespace": group.event.Namespace,
+			"type":      group.event.Type,
+			"reason":    group.event.Reason,
+			"object":    object.Kind + "/" + object.Name,
+			"message":   group.event.Message,
+			"source":    group.event.Source.Component,
+			"count":     group.count,
+			"firstSeen": group.firstSeen,
+			"lastSeen":  group.lastSeen,
+			"age":       duration.HumanDuration(now.Sub(group.lastSeen)) + " ago",
+		})
+	}
+	return events, nil
+}
+
+// eventLastSeen returns the most recent time an event was observed, falling back
+// through the fields populated by the different event APIs.
+func eventLastSeen(event corev1.Event) time.Time {
+	switch {
+	case event.Series != nil && !event.Series.LastObservedTime.IsZero():
+		return event.Series.LastObservedTime.Time
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	}
+	return event.CreationTimestamp.Time
+}
+
+// eventFirstSeen returns the first time an event was observed.
+func eventFirstSeen(event corev1.Event) time.Time {
+	switch {
+	case !event.FirstTimestamp.IsZero():
+		return event.FirstTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	}
+	return event.CreationTimestamp.Time
+}
+
+// maxWarningSampleObjects bounds how many example objects GetClusterWarnings lists per group.
+const maxWarningSampleObjects = 5
+
+// GetClusterWarnings summarizes the Warning events seen across all namespaces within
+// the given window, grouped by reason and involved object kind. Each group reports the
+// total number of occurrences, how many distinct objects and namespaces are affected,
+// when it was last seen, the most recent message, and a few example objects.
+// Groups are ordered by occurrence count, highest first.
+// Returns a map with the window, the total count, and the groups, or an error.
+func (c *Client) GetClusterWarnings(ctx context.Context, window time.Duration) (map[string]interface{}, error) {
+	eventList, err := c.clientset().CoreV1().Events("").List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("type", corev1.EventTypeWarning).String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve warning events: %w", err)
+	}
+
+	type warningGroup struct {
+		reason     string
+		kind       string
+		count      int32
+		lastSeen   time.Time
+		message    string
+		objects    map[string]bool
+		samples    []string
+		namespaces map[string]bool
+	}
+
+	now := time.Now()
+	cutoff := now.Add(-window)
+	groups := map[string]*warningGroup{}
+	var order []*warningGroup
+	var total int32
+	for _, event := range eventList.Items {
+		lastSeen := eventLastSeen(event)
+		if lastSeen.Before(cutoff) {
+			continue
+		}
+		object := event.InvolvedObject
+
+		count := event.Count
+		if event.Series != nil && event.Series.Count > count {
+			count = event.Series.Count
+		}
+		if count == 0 {
+			count = 1
+		}
+
+		key := event.Reason + "/" + object.Kind
+		group, ok := groups[key]
+		if !ok {
+			group = &warningGroup{reason: event.Reason, kind: object.Kind, objects: map[string]bool{}, namespaces: map[string]bool{}}
+			groups[key] = group
+			order = append(order, group)
+		}
+		group.count += count
+		total += count
+		if lastSeen.After(group.lastSeen) {
+			group.lastSeen = lastSeen
+			group.message = event.Message
+		}
+		if object.Namespace != "" {
+			group.namespaces[object.Namespace] = true
+		}
+		objectName := object.Name
+		if object.Namespace != "" {
+			objectName = object.Namespace + "/" + object.Name
+		}
+		if !group.objects[objectName] {
+			group.objects[objectName] = true
+			if len(group.samples) < maxWarningSampleObjects {
+				group.samples = append(group.samples, objectName)
+			}
+		}
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		if order[i].count != order[j].count {
+			return order[i].count > order[j].count
+		}
+		return order[i].lastSeen.After(order[j].lastSeen)
+	})
+
+	warnings := []map[string]interface{}{}
+	for _, group := range order {
+		warnings = append(warnings, map[string]interface{}{
+			"reason":         group.reason,
+			"kind":           group.kind,
+			"count":          group.count,
+			"objects":        len(group.objects),
+			"namespaces":     len(group.namespaces),
+			"lastSeen":       group.lastSeen,
+			"age":            duration.HumanDuration(now.Sub(group.lastSeen)) + " ago",
+			"latestMessage":  group.message,
+			"exampleObjects": group.samples,
+		})
+	}
+
+	return map[string]interface{}{
+		"window":   window.String(),
+		"total":    total,
+		"groups":   len(warnings),
+		"warnings": warnings,
+	}, nil
+}
+
+// maxWatchedEvents bounds how many events WatchEvents keeps in its result; all events
+// are still passed to onEvent and counted.
+const maxWatchedEvents = 200
+
+// EventFilter narrows the events followed by WatchEvents. Empty fields match anything.
+type EventFilter struct {
+	Namespace    string
+	Type         string
+	InvolvedKind string
+	InvolvedName string
+}
+
+// WatchEvents follows new events as they are recorded, from the moment it is called
+// until the duration elapses or ctx is cancelled, whichever comes first. Events
+// already in the cluster are not replayed. The filter is applied by the API server;
+// an empty namespace watches all namespaces. Each event is passed to onEvent, if set,
+// as it arrives. If the server closes the watch early it is re-established from the
+// last version seen, so no events are lost.
+// Returns a map with the events seen, counts by type and reason, and why the watch
+// stopped, or an error.
+func (c *Client) WatchEvents(ctx context.Context, filter EventFilter, watchFor time.Duration, onEvent func(event map[string]interface{})) (map[string]interface{}, error) {
+	selector := fields.Set{}
+	if filter.Type != "" {
+		selector["type"] = filter.Type
+	}
+	if filter.InvolvedKind != "" {
+		selector["involvedObject.kind"] = filter.InvolvedKind
+	}
+	if filter.InvolvedName != "" {
+		selector["involvedObject.name"] = filter.InvolvedName
+	}
+	fieldSelector := fields.SelectorFromSet(selector).String()
+
+	// Start from the current version so only new events are reported
+	currentVersion := func() (string, error) {
+		eventList, err := c.clientset().CoreV1().Events(filter.Namespace).List(ctx, metav1.ListOptions{FieldSelector: fieldSelector, Limit: 1})
+		if err != nil {
+			return "", fmt.Errorf("failed to list events: %w", err)
+		}
+		return eventList.ResourceVersion, nil
+	}
+	resourceVersion, err := currentVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, watchFor)
+	defer cancel()
+
+	start := time.Now()
+	events := []map[string]interface{}{}
+	byType := map[string]int{}
+	byReason := map[string]int{}
+	total := 0
+	for ctx.Err() == nil {
+		if resourceVersion == "" {
+			// The last version seen expired; resume from now
+			if resourceVersion, err = currentVersion(); err != nil {
+				if ctx.Err() != nil {
+					break
+				}
+				return nil, err
+			}
+		}
+		watcher, err := c.clientset().CoreV1().Events(filter.Namespace).Watch(ctx, metav1.ListOptions{
+			FieldSelector:   fieldSelector,
+			ResourceVersion: resourceVersion,
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			return nil, fmt.Errorf("failed to watch events: %w", err)
+		}
+
+	watchLoop:
+		for {
+			select {
+			case <-ctx.Done():
+				break watchLoop
+			case watchEvent, ok := <-watcher.ResultChan():
+				if !ok {
+					break watchLoop
+				}
+				if watchEvent.Type == watch.Error {
+					resourceVersion = ""
+					break watchLoop
+				}
+				event, ok := watchEvent.Object.(*corev1.Event)
+				if !ok {
+					continue
+				}
+				resourceVersion = event.ResourceVersion
+				if watchEvent.Type != watch.Added && watchEvent.Type != watch.Modified {
+					continue
+				}
+				object := event.InvolvedObject
+				entry := map[string]interface{}{
+					"namespace": event.Namespace,
+					"type":      event.Type,
+					"reason":    event.Reason,
+					"object":    object.Kind + "/" + object.Name,
+					"message":   event.Message,
+					"source":    event.Source.Component,
+					"count":     event.Count,
+					"lastSeen":  eventLastSeen(*event),
+					"elapsed":   time.Since(start).Round(time.Second).String(),
+				}
+				total++
+				byType[event.Type]++
+				byReason[event.Reason]++
+				if len(events) < maxWatchedEvents {
+					events = append(events, entry)
+				}
+				if onEvent != nil {
+					onEvent(entry)
+				}
+			}
+		}
+		watcher.Stop()
+	}
+
+	stopped := "duration elapsed"
+	if errors.Is(ctx.Err(), context.Canceled) {
+		stopped = "cancelled"
+	}
+	return map[string]interface{}{
+		"duration":  time.Since(start).Round(time.Second).String(),
+		"stopped":   stopped,
+		"total":     total,
+		"truncated": total > len(events),
+		"byType":    byType,
+		"byReason":  byReason,
+		"events":    events,
+	}, nil
+}
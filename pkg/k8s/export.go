@@ -0,0 +1,149 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// DefaultExportKinds are the kinds exported by ExportNamespace when none are given,
+// in an order that applies cleanly (configuration before the workloads using it).
+var DefaultExportKinds = []string{
+	"ServiceAccount", "Role", "RoleBinding", "ConfigMap", "Secret",
+	"PersistentVolumeClaim", "Service", "Deployment", "StatefulSet", "DaemonSet",
+	"CronJob", "Job", "HorizontalPodAutoscaler", "PodDisruptionBudget",
+	"NetworkPolicy", "Ingress",
+}
+
+// serverPopulatedAnnotations are annotation prefixes written by controllers and
+// kubectl rather than by the author of a manifest.
+var serverPopulatedAnnotations = []string{
+	"kubectl.kubernetes.io/last-applied-configuration",
+	"deployment.kubernetes.io/revision",
+	"pv.kubernetes.io/",
+	"volume.beta.kubernetes.io/",
+	"volume.kubernetes.io/",
+	"kubernetes.io/service-account.",
+}
+
+// sanitizeForApply strips the fields the API server or controllers populate, so that
+// the object can be applied as a new resource: identity and bookkeeping metadata,
+// status, generated annotations, and kind-specific allocated values such as a
+// Service's cluster IPs or a claim's bound volume.
+func sanitizeForApply(obj *unstructured.Unstructured) {
+	for _, field := range []string{"uid", "resourceVersion", "generation", "creationTimestamp", "deletionTimestamp", "deletionGracePeriodSeconds", "managedFields", "selfLink", "ownerReferences"} {
+		unstructured.RemoveNestedField(obj.Object, "metadata", field)
+	}
+	unstructured.RemoveNestedField(obj.Object, "status")
+
+	if annotations := obj.GetAnnotations(); annotations != nil {
+		for key := range annotations {
+			for _, prefix := range serverPopulatedAnnotations {
+				if strings.HasPrefix(key, prefix) {
+					delete(annotations, key)
+				}
+			}
+		}
+		if len(annotations) == 0 {
+			annotations = nil
+		}
+		obj.SetAnnotations(annotations)
+	}
+
+	switch obj.GetKind() {
+	case "Service":
+		unstructured.RemoveNestedField(obj.Object, "spec", "clusterIP")
+		unstructured.RemoveNestedField(obj.Object, "spec", "clusterIPs")
+		unstructured.RemoveNestedField(obj.Object, "spec", "healthCheckNodePort")
+		if ports, found, _ := unstructured.NestedSlice(obj.Object, "spec", "ports"); found {
+			for _, p := range ports {
+				if port, ok := p.(map[string]interface{}); ok {
+					delete(port, "nodePort")
+				}
+			}
+			_ = unstructured.SetNestedSlice(obj.Object, ports, "spec", "ports")
+		}
+	case "PersistentVolumeClaim":
+		unstructured.RemoveNestedField(obj.Object, "spec", "volumeName")
+	case "Job":
+		// The controller adds a generated selector and matching pod labels
+		unstructured.RemoveNestedField(obj.Object, "spec", "selector")
+		unstructured.RemoveNestedField(obj.Object, "spec", "template", "metadata", "labels", "controller-uid")
+		unstructured.RemoveNestedField(obj.Object, "spec", "template", "metadata", "labels", "batch.kubernetes.io/controller-uid")
+	}
+}
+
+// isExportable reports whether an object belongs in an export: objects managed by a
+// controller (such as Jobs created by a CronJob) and objects created automatically
+// in every namespace are recreated by the cluster and are skipped.
+func isExportable(obj *unstructured.Unstructured) bool {
+	if metav1.GetControllerOf(obj) != nil {
+		return false
+	}
+	switch obj.GetKind() {
+	case "ServiceAccount":
+		return obj.GetName() != "default"
+	case "ConfigMap":
+		return obj.GetName() != "kube-root-ca.crt" && obj.GetName() != "openshift-service-ca.crt"
+	case "Secret":
+		secretType, _, _ := unstructured.NestedString(obj.Object, "type")
+		return secretType != "kubernetes.io/service-account-token" && secretType != "helm.sh/release.v1"
+	}
+	return true
+}
+
+// ExportNamespace exports the resources of the given kinds (DefaultExportKinds when
+// empty) in a namespace as a multi-document YAML bundle that can be re-applied to
+// another namespace or cluster. Server-populated fields are stripped and objects
+// managed by controllers are skipped. Sensitive fields are masked unless reveal is true.
+// Kinds the cluster does not serve are skipped and reported.
+// Returns the YAML bundle and the list of kinds that could not be exported, or an error.
+func (c *Client) ExportNamespace(ctx context.Context, namespace string, kinds []string, reveal bool) (string, []string, error) {
+	if len(kinds) == 0 {
+		kinds = DefaultExportKinds
+	}
+
+	var documents []string
+	var skipped []string
+	for _, kind := range kinds {
+		kind = c.resolveKind(kind)
+		gvr, err := c.getCachedGVR(kind)
+		if err != nil {
+			skipped = append(skipped, fmt.Sprintf("%s: %v", kind, err))
+			continue
+		}
+		list, err := c.dynamicClient().Resource(*gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			skipped = append(skipped, fmt.Sprintf("%s: %v", kind, err))
+			continue
+		}
+
+		items := list.Items
+		sort.Slice(items, func(i, j int) bool { return items[i].GetName() < items[j].GetName() })
+		for i := range items {
+			obj := &items[i]
+			if !isExportable(obj) {
+				continue
+			}
+			sanitizeForApply(obj)
+			obj.SetNamespace("")
+
+			content := obj.Object
+			if !reveal {
+				content = c.MaskSensitiveFields(content)
+			}
+			out, err := yaml.Marshal(content)
+			if err != nil {
+				return "", nil, fmt.Errorf("failed to serialize %s/%s: %w", kind, obj.GetName(), err)
+			}
+			documents = append(documents, string(out))
+		}
+	}
+
+	return strings.Join(documents, "---\n"), skipped, nil
+}
@@ -0,0 +1,212 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// podWorkload names the workload that manages a pod as "Kind/name", resolving a
+// Deployment's ReplicaSet to the Deployment through the pod-template-hash label so no
+// extra lookups are needed. A pod without a controller is its own workload.
+func podWorkload(pod *corev1.Pod) string {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return "Pod/" + pod.Name
+	}
+	if owner.Kind == "ReplicaSet" {
+		if hash := pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey]; hash != "" && strings.HasSuffix(owner.Name, "-"+hash) {
+			return "Deployment/" + strings.TrimSuffix(owner.Name, "-"+hash)
+		}
+	}
+	return owner.Kind + "/" + owner.Name
+}
+
+// ListOOMKills finds containers whose current or last termination reason is OOMKilled,
+// in one namespace or all namespaces when empty. Each entry reports the pod, container,
+// and workload, the container's memory request and limit for right-sizing, its restart
+// count, and when it was last killed. Entries are ordered by most recent kill first.
+// Returns a slice of maps, each describing an OOM-killed container, or an error.
+func (c *Client) ListOOMKills(ctx context.Context, namespace string) ([]map[string]interface{}, error) {
+	pods, err := c.clientset().CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	now := time.Now()
+	kills := []map[string]interface{}{}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		resources := map[string]corev1.ResourceRequirements{}
+		for _, container := range pod.Spec.InitContainers {
+			resources[container.Name] = container.Resources
+		}
+		for _, container := range pod.Spec.Containers {
+			resources[container.Name] = container.Resources
+		}
+
+		statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+		for _, status := range statuses {
+			state := status.State.Terminated
+			current := true
+			if state == nil || state.Reason != "OOMKilled" {
+				state = status.LastTerminationState.Terminated
+				current = false
+			}
+			if state == nil || state.Reason != "OOMKilled" {
+				continue
+			}
+
+			entry := map[string]interface{}{
+				"pod":          pod.Name,
+				"namespace":    pod.Namespace,
+				"container":    status.Name,
+				"workload":     podWorkload(pod),
+				"node":         pod.Spec.NodeName,
+				"restartCount": status.RestartCount,
+				"killedAt":     state.FinishedAt.Time,
+				"killedAgo":    duration.HumanDuration(now.Sub(state.FinishedAt.Time)),
+				"current":      current,
+				"memoryLimit":  "none",
+			}
+			if limit, ok := resources[status.Name].Limits[corev1.ResourceMemory]; ok {
+				entry["memoryLimit"] = limit.String()
+			}
+			if request, ok := resources[status.Name].Requests[corev1.ResourceMemory]; ok {
+				entry["memoryRequest"] = request.String()
+			}
+			kills = append(kills, entry)
+		}
+	}
+
+	sort.SliceStable(kills, func(i, j int) bool {
+		return kills[i]["killedAt"].(time.Time).After(kills[j]["killedAt"].(time.Time))
+	})
+	return kills, nil
+}
+
+// imagePullWaitingReasons are the container waiting reasons that mean an image cannot be pulled.
+var imagePullWaitingReasons = map[string]bool{
+	"ErrImagePull":        true,
+	"ImagePullBackOff":    true,
+	"InvalidImageName":    true,
+	"ErrImageNeverPull":   true,
+	"RegistryUnavailable": true,
+}
+
+// ListImagePullErrors finds containers that are waiting because their image cannot be
+// pulled (ErrImagePull, ImagePullBackOff, and related reasons), in one namespace or all
+// namespaces when empty, and groups them by image. Each image reports the waiting
+// reasons seen, the most recent pull error from the pods' events (which carries the
+// registry's actual response, such as "not found" or "unauthorized"), and the affected
+// pods and workloads. Images are ordered by the number of affected pods.
+// Returns a slice of maps, each describing a failing image, or an error.
+func (c *Client) ListImagePullErrors(ctx context.Context, namespace string) ([]map[string]interface{}, error) {
+	pods, err := c.clientset().CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	type imageFailure struct {
+		reasons    map[string]bool
+		pods       []string
+		podKeys    map[string]bool
+		workloads  map[string]bool
+		namespaces map[string]bool
+	}
+	failures := map[string]*imageFailure{}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+		for _, status := range statuses {
+			if status.State.Waiting == nil || !imagePullWaitingReasons[status.State.Waiting.Reason] {
+				continue
+			}
+			f, ok := failures[status.Image]
+			if !ok {
+				f = &imageFailure{reasons: map[string]bool{}, podKeys: map[string]bool{}, workloads: map[string]bool{}, namespaces: map[string]bool{}}
+				failures[status.Image] = f
+			}
+			f.reasons[status.State.Waiting.Reason] = true
+			key := pod.Namespace + "/" + pod.Name
+			if !f.podKeys[key] {
+				f.podKeys[key] = true
+				f.pods = append(f.pods, key+" ("+status.Name+")")
+			}
+			f.workloads[pod.Namespace+"/"+podWorkload(pod)] = true
+			f.namespaces[pod.Namespace] = true
+		}
+	}
+	if len(failures) == 0 {
+		return []map[string]interface{}{}, nil
+	}
+
+	// The kubelet reports the registry's error in Failed events on the pod
+	events, err := c.clientset().CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.SelectorFromSet(fields.Set{"involvedObject.kind": "Pod", "reason": "Failed"}).String(),
+	})
+	var eventErr string
+	latest := map[string]corev1.Event{}
+	if err != nil {
+		eventErr = fmt.Sprintf("failed to retrieve events: %v", err)
+	} else {
+		for _, event := range events.Items {
+			if !strings.Contains(strings.ToLower(event.Message), "pull") {
+				continue
+			}
+			for image, f := range failures {
+				key := event.InvolvedObject.Namespace + "/" + event.InvolvedObject.Name
+				if !f.podKeys[key] || !strings.Contains(event.Message, image) {
+					continue
+				}
+				if current, ok := latest[image]; !ok || eventLastSeen(event).After(eventLastSeen(current)) {
+					latest[image] = event
+				}
+			}
+		}
+	}
+
+	result := []map[string]interface{}{}
+	for image, f := range failures {
+		entry := map[string]interface{}{
+			"image":      image,
+			"reasons":    sortedKeys(f.reasons),
+			"pods":       f.pods,
+			"podCount":   len(f.pods),
+			"workloads":  sortedKeys(f.workloads),
+			"namespaces": sortedKeys(f.namespaces),
+		}
+		if event, ok := latest[image]; ok {
+			entry["error"] = event.Message
+			entry["lastSeen"] = eventLastSeen(event)
+		} else if eventErr != "" {
+			entry["error"] = eventErr
+		}
+		result = append(result, entry)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i]["podCount"].(int) != result[j]["podCount"].(int) {
+			return result[i]["podCount"].(int) > result[j]["podCount"].(int)
+		}
+		return result[i]["image"].(string) < result[j]["image"].(string)
+	})
+	return result, nil
+}
+
+// sortedKeys returns the keys of a set in sorted order.
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
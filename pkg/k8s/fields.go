@@ -0,0 +1,50 @@
+package k8s
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ParseFieldPaths parses a comma-separated list of dotted field paths such as
+// "metadata.name,status.phase,spec.replicas". Keys containing dots or slashes
+// are written in brackets, e.g. "metadata.labels[app.kubernetes.io/name]".
+func ParseFieldPaths(value string) ([][]string, error) {
+	var paths [][]string
+	for _, field := range strings.Split(value, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		path, err := parseFieldPath(field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid field '%s': %w", field, err)
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// ProjectFields returns only the requested fields of a resource, preserving their
+// nesting so that the result has the same shape as the original object.
+// apiVersion and kind are always kept so the projection stays self-describing.
+// Fields that do not exist in the object are omitted.
+func ProjectFields(obj map[string]interface{}, paths [][]string) map[string]interface{} {
+	projected := map[string]interface{}{}
+	for _, key := range []string{"apiVersion", "kind"} {
+		if value, ok := obj[key]; ok {
+			projected[key] = value
+		}
+	}
+	for _, path := range paths {
+		value, found, err := unstructured.NestedFieldNoCopy(obj, path...)
+		if err != nil || !found {
+			continue
+		}
+		if err := unstructured.SetNestedField(projected, value, path...); err != nil {
+			continue
+		}
+	}
+	return projected
+}
@@ -0,0 +1,283 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// DefaultStuckResourceKinds are the kinds ListStuckResources checks when none are given:
+// the ones most often left in Terminating by a finalizer whose controller is gone.
+var DefaultStuckResourceKinds = []string{
+	"Namespace", "Pod", "PersistentVolumeClaim", "PersistentVolume", "Service",
+	"Deployment", "StatefulSet", "Job", "ConfigMap", "Secret", "Ingress",
+	"CustomResourceDefinition",
+}
+
+// ListStuckResources finds objects of the given kinds (DefaultStuckResourceKinds when
+// empty) that have been terminating for longer than olderThan, reporting the
+// finalizers blocking their deletion. For namespaces, the conditions explaining what
+// content remains are included. An empty namespace searches all namespaces. Kinds the
+// cluster does not serve are skipped and reported.
+// Returns a slice of maps describing each stuck object, the kinds skipped, or an error.
+func (c *Client) ListStuckResources(ctx context.Context, namespace string, kinds []string, olderThan time.Duration) ([]map[string]interface{}, []string, error) {
+	if len(kinds) == 0 {
+		kinds = DefaultStuckResourceKinds
+	}
+
+	now := time.Now()
+	stuck := []map[string]interface{}{}
+	var skipped []string
+	for _, kind := range kinds {
+		kind = c.resolveKind(kind)
+		gvr, err := c.getCachedGVR(kind)
+		if err != nil {
+			skipped = append(skipped, fmt.Sprintf("%s: %v", kind, err))
+			continue
+		}
+		namespaced, err := c.isNamespaced(kind)
+		if err != nil {
+			skipped = append(skipped, fmt.Sprintf("%s: %v", kind, err))
+			continue
+		}
+
+		var list *unstructured.UnstructuredList
+		if namespaced && namespace != "" {
+			list, err = c.dynamicClient().Resource(*gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
+		} else {
+			list, err = c.dynamicClient().Resource(*gvr).List(ctx, metav1.ListOptions{})
+		}
+		if err != nil {
+			skipped = append(skipped, fmt.Sprintf("%s: %v", kind, err))
+			continue
+		}
+
+		for _, item := range list.Items {
+			deletion := item.GetDeletionTimestamp()
+			if deletion == nil || now.Sub(deletion.Time) < olderThan {
+				continue
+			}
+			// A namespaced search only reports the namespace itself among cluster-scoped kinds
+			if !namespaced && namespace != "" && !(kind == "Namespace" && item.GetName() == namespace) {
+				continue
+			}
+
+			entry := map[string]interface{}{
+				"kind":           item.GetKind(),
+				"name":           item.GetName(),
+				"namespace":      item.GetNamespace(),
+				"deletionSince":  deletion.Time,
+				"terminatingFor": duration.HumanDuration(now.Sub(deletion.Time)),
+				"finalizers":     item.GetFinalizers(),
+			}
+			if kind == "Namespace" {
+				if specFinalizers, found, _ := unstructured.NestedStringSlice(item.Object, "spec", "finalizers"); found && len(specFinalizers) > 0 {
+					entry["specFinalizers"] = specFinalizers
+				}
+				if conditions, found, _ := unstructured.NestedSlice(item.Object, "status", "conditions"); found {
+					var messages []string
+					for _, raw := range conditions {
+						condition, ok := raw.(map[string]interface{})
+						if !ok || condition["status"] != "True" {
+							continue
+						}
+						if message, ok := condition["message"].(string); ok && message != "" {
+							messages = append(messages, message)
+						}
+					}
+					if len(messages) > 0 {
+						entry["blockedBy"] = messages
+					}
+				}
+			}
+			stuck = append(stuck, entry)
+		}
+	}
+
+	sort.SliceStable(stuck, func(i, j int) bool {
+		return stuck[i]["deletionSince"].(time.Time).Before(stuck[j]["deletionSince"].(time.Time))
+	})
+	return stuck, skipped, nil
+}
+
+// getObject fetches a single object by kind, resolving whether the kind is namespaced.
+func (c *Client) getObject(ctx context.Context, kind, name, namespace string) (*unstructured.Unstructured, error) {
+	gvr, err := c.getCachedGVR(kind)
+	if err != nil {
+		return nil, err
+	}
+	namespaced, err := c.isNamespaced(kind)
+	if err != nil {
+		return nil, err
+	}
+	if namespaced {
+		return c.dynamicClient().Resource(*gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	}
+	return c.dynamicClient().Resource(*gvr).Get(ctx, name, metav1.GetOptions{})
+}
+
+// setFinalizers replaces an object's finalizers. The patch carries the object's
+// resourceVersion, so it fails rather than overwriting a concurrent change.
+func (c *Client) setFinalizers(ctx context.Context, obj *unstructured.Unstructured, finalizers []string) error {
+	kind := obj.GetKind()
+	gvr, err := c.getCachedGVR(kind)
+	if err != nil {
+		return err
+	}
+
+	if finalizers == nil {
+		finalizers = []string{}
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"finalizers":      finalizers,
+			"resourceVersion": obj.GetResourceVersion(),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if obj.GetNamespace() != "" {
+		_, err = c.dynamicClient().Resource(*gvr).Namespace(obj.GetNamespace()).Patch(ctx, obj.GetName(), types.MergePatchType, patch, metav1.PatchOptions{})
+	} else {
+		_, err = c.dynamicClient().Resource(*gvr).Patch(ctx, obj.GetName(), types.MergePatchType, patch, metav1.PatchOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update finalizers of %s '%s': %w", kind, obj.GetName(), err)
+	}
+	return nil
+}
+
+// RemoveFinalizers removes every finalizer from an object that is already being
+// deleted, letting the deletion complete. Objects that are not terminating are
+// refused, since removing their finalizers would skip cleanup on a later delete.
+// When dryRun is true nothing is changed and the finalizers that would be removed
+// are reported.
+// Returns a map describing the finalizers removed, or an error.
+func (c *Client) RemoveFinalizers(ctx context.Context, kind, name, namespace string, dryRun bool) (map[string]interface{}, error) {
+	kind = c.resolveKind(kind)
+	obj, err := c.getObject(ctx, kind, name, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s '%s': %w", kind, name, err)
+	}
+	if obj.GetDeletionTimestamp() == nil {
+		return nil, fmt.Errorf("%s '%s' is not being deleted; only finalizers of terminating objects can be cleared", kind, name)
+	}
+
+	finalizers := obj.GetFinalizers()
+	result := map[string]interface{}{
+		"kind":       kind,
+		"name":       name,
+		"namespace":  obj.GetNamespace(),
+		"finalizers": finalizers,
+		"removed":    false,
+	}
+	if len(finalizers) == 0 {
+		result["message"] = "object has no finalizers"
+		return result, nil
+	}
+	if dryRun {
+		return result, nil
+	}
+
+	if err := c.setFinalizers(ctx, obj, nil); err != nil {
+		return nil, err
+	}
+	result["removed"] = true
+	return result, nil
+}
+
+// validateFinalizer checks that a finalizer is a qualified name, as the API server requires.
+func validateFinalizer(finalizer string) error {
+	if errs := validation.IsQualifiedName(finalizer); len(errs) > 0 {
+		return fmt.Errorf("invalid finalizer '%s': %s", finalizer, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// AddFinalizer adds a finalizer to an object, protecting it from being deleted until
+// the finalizer is removed. Adding a finalizer the object already has is a no-op.
+// Returns a map with the object's resulting finalizers, or an error.
+func (c *Client) AddFinalizer(ctx context.Context, kind, name, namespace, finalizer string) (map[string]interface{}, error) {
+	kind = c.resolveKind(kind)
+	if err := validateFinalizer(finalizer); err != nil {
+		return nil, err
+	}
+	obj, err := c.getObject(ctx, kind, name, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s '%s': %w", kind, name, err)
+	}
+	if obj.GetDeletionTimestamp() != nil {
+		return nil, fmt.Errorf("%s '%s' is being deleted; finalizers cannot be added", kind, name)
+	}
+
+	finalizers := obj.GetFinalizers()
+	changed := !slices.Contains(finalizers, finalizer)
+	if changed {
+		finalizers = append(finalizers, finalizer)
+		if err := c.setFinalizers(ctx, obj, finalizers); err != nil {
+			return nil, err
+		}
+	}
+
+	return map[string]interface{}{
+		"kind":       kind,
+		"name":       name,
+		"namespace":  obj.GetNamespace(),
+		"finalizers": finalizers,
+		"changed":    changed,
+	}, nil
+}
+
+// RemoveFinalizer removes a single finalizer from an object, leaving any others in
+// place. If the object is terminating and this was its last finalizer, the deletion
+// completes. When dryRun is true nothing is changed and the result shows what the
+// finalizers would become.
+// Returns a map with the object's resulting finalizers, or an error.
+func (c *Client) RemoveFinalizer(ctx context.Context, kind, name, namespace, finalizer string, dryRun bool) (map[string]interface{}, error) {
+	kind = c.resolveKind(kind)
+	obj, err := c.getObject(ctx, kind, name, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s '%s': %w", kind, name, err)
+	}
+
+	current := obj.GetFinalizers()
+	if !slices.Contains(current, finalizer) {
+		return nil, fmt.Errorf("%s '%s' does not have finalizer '%s' (finalizers: %s)", kind, name, finalizer, strings.Join(current, ", "))
+	}
+	remaining := []string{}
+	for _, f := range current {
+		if f != finalizer {
+			remaining = append(remaining, f)
+		}
+	}
+
+	result := map[string]interface{}{
+		"kind":        kind,
+		"name":        name,
+		"namespace":   obj.GetNamespace(),
+		"finalizers":  remaining,
+		"terminating": obj.GetDeletionTimestamp() != nil,
+		"removed":     false,
+	}
+	if dryRun {
+		return result, nil
+	}
+
+	if err := c.setFinalizers(ctx, obj, remaining); err != nil {
+		return nil, err
+	}
+	result["removed"] = true
+	return result, nil
+}
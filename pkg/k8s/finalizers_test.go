@@ -0,0 +1,138 @@
+package k8s_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/reza-gholizade/k8s-mcp-server/pkg/k8s/k8stest"
+)
+
+// finalizerObjects returns the seeded objects with two ConfigMaps holding the
+// example.com/cleanup finalizer: "stuck", deleted an hour ago, and "live".
+func finalizerObjects() []runtime.Object {
+	deleted := metav1.NewTime(time.Now().Add(-time.Hour))
+	configMap := func(name string, deletion *metav1.Time) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         k8stest.Namespace,
+				Finalizers:        []string{"example.com/cleanup"},
+				DeletionTimestamp: deletion,
+			},
+		}
+	}
+	return append(k8stest.Objects(), configMap("stuck", &deleted), configMap("live", nil))
+}
+
+// configMapFinalizers returns the finalizers of a ConfigMap as stored by the dynamic fake,
+// which the finalizer methods read and patch.
+func configMapFinalizers(t *testing.T, fakes *k8stest.Fakes, name string) []string {
+	t.Helper()
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	obj, err := fakes.Dynamic.Resource(gvr).Namespace(k8stest.Namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get configmap '%s': %v", name, err)
+	}
+	return obj.GetFinalizers()
+}
+
+func TestListStuckResources(t *testing.T) {
+	client := k8stest.NewFakeClient(finalizerObjects()...)
+	ctx := context.Background()
+
+	stuck, skipped, err := client.ListStuckResources(ctx, k8stest.Namespace, []string{"ConfigMap", "Widget"}, 30*time.Minute)
+	if err != nil {
+		t.Fatalf("ListStuckResources: %v", err)
+	}
+	if len(stuck) != 1 || stuck[0]["name"] != "stuck" || !slices.Equal(stuck[0]["finalizers"].([]string), []string{"example.com/cleanup"}) {
+		t.Errorf("got %v, want only the terminating configmap with its finalizer", stuck)
+	}
+	if len(skipped) != 1 {
+		t.Errorf("got skipped %v, want the kind the cluster does not serve", skipped)
+	}
+
+	// Objects terminating for less than olderThan are left out
+	stuck, _, err = client.ListStuckResources(ctx, k8stest.Namespace, []string{"ConfigMap"}, 2*time.Hour)
+	if err != nil || len(stuck) != 0 {
+		t.Errorf("got %v, %v; want nothing stuck for two hours", stuck, err)
+	}
+}
+
+func TestRemoveFinalizers(t *testing.T) {
+	fakes := k8stest.NewFakes(finalizerObjects()...)
+	client := fakes.Client()
+	ctx := context.Background()
+
+	// Clearing the finalizers of a live object would skip its cleanup on a later delete
+	if _, err := client.RemoveFinalizers(ctx, "ConfigMap", "live", k8stest.Namespace, false); err == nil {
+		t.Error("expected an error for an object that is not being deleted")
+	}
+
+	result, err := client.RemoveFinalizers(ctx, "ConfigMap", "stuck", k8stest.Namespace, true)
+	if err != nil {
+		t.Fatalf("RemoveFinalizers: %v", err)
+	}
+	if result["removed"] != false || len(configMapFinalizers(t, fakes, "stuck")) != 1 {
+		t.Errorf("dry run removed the finalizers: %v", result)
+	}
+
+	result, err = client.RemoveFinalizers(ctx, "ConfigMap", "stuck", k8stest.Namespace, false)
+	if err != nil {
+		t.Fatalf("RemoveFinalizers: %v", err)
+	}
+	if finalizers := configMapFinalizers(t, fakes, "stuck"); result["removed"] != true || len(finalizers) != 0 {
+		t.Errorf("got %v with finalizers %v left, want them removed", result, finalizers)
+	}
+}
+
+func TestAddAndRemoveFinalizer(t *testing.T) {
+	fakes := k8stest.NewFakes(finalizerObjects()...)
+	client := fakes.Client()
+	ctx := context.Background()
+
+	if _, err := client.AddFinalizer(ctx, "ConfigMap", "live", k8stest.Namespace, "not a finalizer"); err == nil {
+		t.Error("expected an error for an invalid finalizer")
+	}
+	if _, err := client.AddFinalizer(ctx, "ConfigMap", "stuck", k8stest.Namespace, "example.com/protect"); err == nil {
+		t.Error("expected an error adding a finalizer to an object being deleted")
+	}
+
+	for _, wantChanged := range []bool{true, false} {
+		result, err := client.AddFinalizer(ctx, "ConfigMap", "live", k8stest.Namespace, "example.com/protect")
+		if err != nil {
+			t.Fatalf("AddFinalizer: %v", err)
+		}
+		if result["changed"] != wantChanged {
+			t.Errorf("changed: got %v, want %v", result["changed"], wantChanged)
+		}
+	}
+	if finalizers := configMapFinalizers(t, fakes, "live"); !slices.Equal(finalizers, []string{"example.com/cleanup", "example.com/protect"}) {
+		t.Errorf("got finalizers %v, want the new one added once", finalizers)
+	}
+
+	if _, err := client.RemoveFinalizer(ctx, "ConfigMap", "live", k8stest.Namespace, "example.com/other", false); err == nil {
+		t.Error("expected an error removing a finalizer the object does not have")
+	}
+	result, err := client.RemoveFinalizer(ctx, "ConfigMap", "live", k8stest.Namespace, "example.com/cleanup", true)
+	if err != nil {
+		t.Fatalf("RemoveFinalizer: %v", err)
+	}
+	if result["removed"] != false || len(configMapFinalizers(t, fakes, "live")) != 2 {
+		t.Errorf("dry run removed the finalizer: %v", result)
+	}
+	if _, err := client.RemoveFinalizer(ctx, "ConfigMap", "live", k8stest.Namespace, "example.com/cleanup", false); err != nil {
+		t.Fatalf("RemoveFinalizer: %v", err)
+	}
+	// Other finalizers are left in place
+	if finalizers := configMapFinalizers(t, fakes, "live"); !slices.Equal(finalizers, []string{"example.com/protect"}) {
+		t.Errorf("got finalizers %v, want only example.com/protect", finalizers)
+	}
+}
@@ -0,0 +1,98 @@
+package k8s
+
+import (
+	"context"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// controlPlaneComponents are the kube-system components checked by GetControlPlaneHealth,
+// matched against the "component" label used by kubeadm and most self-managed installers.
+var controlPlaneComponents = []string{"kube-apiserver", "kube-scheduler", "kube-controller-manager", "etcd"}
+
+// GetControlPlaneHealth reports the health of the control plane.
+// It combines the API server's verbose /readyz checks, the (deprecated but still served)
+// ComponentStatus API, and the state of control-plane pods in kube-system.
+// On managed clusters the control-plane pods are usually not visible; those sections are
+// then empty rather than treated as failures.
+// Returns a map with one section per source, or an error.
+func (c *Client) GetControlPlaneHealth(ctx context.Context) (map[string]interface{}, error) {
+	result := map[string]interface{}{}
+
+	// API server readiness checks
+	readyz := map[string]interface{}{}
+	body, err := c.clientset().Discovery().RESTClient().Get().AbsPath("/readyz").Param("verbose", "").DoRaw(ctx)
+	if err != nil {
+		readyz["healthy"] = false
+		readyz["error"] = err.Error()
+	} else {
+		readyz["healthy"] = true
+	}
+	if len(body) > 0 {
+		var failedChecks []string
+		for _, line := range strings.Split(string(body), "\n") {
+			if strings.HasPrefix(line, "[-]") {
+				failedChecks = append(failedChecks, strings.TrimPrefix(line, "[-]"))
+			}
+		}
+		readyz["failedChecks"] = failedChecks
+	}
+	result["apiServer"] = readyz
+
+	// Component statuses
+	var components []map[string]interface{}
+	componentStatuses, err := c.clientset().CoreV1().ComponentStatuses().List(ctx, metav1.ListOptions{})
+	if err == nil {
+		for _, cs := range componentStatuses.Items {
+			healthy := false
+			var messages []string
+			for _, condition := range cs.Conditions {
+				if condition.Type == corev1.ComponentHealthy && condition.Status == corev1.ConditionTrue {
+					healthy = true
+				}
+				if condition.Message != "" {
+					messages = append(messages, condition.Message)
+				}
+				if condition.Error != "" {
+					messages = append(messages, condition.Error)
+				}
+			}
+			components = append(components, map[string]interface{}{
+				"name":     cs.Name,
+				"healthy":  healthy,
+				"messages": messages,
+			})
+		}
+	}
+	result["componentStatuses"] = components
+
+	// Control-plane pods in kube-system
+	var pods []map[string]interface{}
+	for _, component := range controlPlaneComponents {
+		podList, err := c.clientset().CoreV1().Pods("kube-system").List(ctx, metav1.ListOptions{
+			LabelSelector: "component=" + component,
+		})
+		if err != nil {
+			continue
+		}
+		for _, pod := range podList.Items {
+			var restarts int32
+			for _, status := range pod.Status.ContainerStatuses {
+				restarts += status.RestartCount
+			}
+			pods = append(pods, map[string]interface{}{
+				"component": component,
+				"name":      pod.Name,
+				"node":      pod.Spec.NodeName,
+				"phase":     pod.Status.Phase,
+				"ready":     isPodReady(&pod),
+				"restarts":  restarts,
+			})
+		}
+	}
+	result["controlPlanePods"] = pods
+
+	return result, nil
+}
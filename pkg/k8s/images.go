@@ -0,0 +1,165 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// maxImagePods bounds how many pods GetImageDetails lists individually; the digests
+// of all pods are still counted per container.
+const maxImagePods = 50
+
+// GetImageDetails reports the images of a pod, or of the pods of a workload
+// (Deployment, StatefulSet, DaemonSet, ReplicaSet, or Job). For each container of the
+// pod template it returns the image reference, the imagePullPolicy, whether the
+// reference is pinned to a digest, and the digests the pods are actually running,
+// resolved from status.containerStatuses[].imageID, with the pods running each. More
+// than one digest for a container means the pods run different builds of the same
+// reference, e.g. a mutable tag that was pushed again while some nodes kept a cached
+// copy. Mutable tags pulled with a policy other than Always are flagged for that reason.
+// Returns a map with the containers and the per-pod images, or an error.
+func (c *Client) GetImageDetails(ctx context.Context, kind, name, namespace string) (map[string]interface{}, error) {
+	kind = c.resolveKind(kind)
+	var template *corev1.PodTemplateSpec
+	var pods []corev1.Pod
+	if kind == "Pod" {
+		pod, err := c.clientset().CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pod '%s': %w", name, err)
+		}
+		template = &corev1.PodTemplateSpec{ObjectMeta: pod.ObjectMeta, Spec: pod.Spec}
+		pods = []corev1.Pod{*pod}
+	} else {
+		var err error
+		template, err = c.podTemplateOf(ctx, kind, name, namespace)
+		if err != nil {
+			return nil, err
+		}
+		pods, err = c.workloadPods(ctx, kind, name, namespace)
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Name < pods[j].Name })
+
+	// Pods by digest, per container
+	running := map[string]map[string][]string{}
+	podResults := []map[string]interface{}{}
+	for _, pod := range pods {
+		statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+		containers := []map[string]interface{}{}
+		for _, status := range statuses {
+			digest := imageDigest(status.ImageID)
+			if running[status.Name] == nil {
+				running[status.Name] = map[string][]string{}
+			}
+			if digest != "" {
+				running[status.Name][digest] = append(running[status.Name][digest], pod.Name)
+			}
+			containers = append(containers, map[string]interface{}{
+				"name":    status.Name,
+				"image":   status.Image,
+				"imageID": status.ImageID,
+				"digest":  digest,
+				"ready":   status.Ready,
+			})
+		}
+		if len(podResults) < maxImagePods {
+			podResults = append(podResults, map[string]interface{}{
+				"name":       pod.Name,
+				"nodeName":   pod.Spec.NodeName,
+				"phase":      string(pod.Status.Phase),
+				"containers": containers,
+			})
+		}
+	}
+
+	specContainers := append(append([]corev1.Container{}, template.Spec.InitContainers...), template.Spec.Containers...)
+	containers := []map[string]interface{}{}
+	for i, container := range specContainers {
+		pullPolicy := container.ImagePullPolicy
+		if pullPolicy == "" {
+			pullPolicy = defaultPullPolicy(container.Image)
+		}
+		pinned := strings.Contains(container.Image, "@")
+
+		digests := []map[string]interface{}{}
+		for digest, podNames := range running[container.Name] {
+			digests = append(digests, map[string]interface{}{"digest": digest, "pods": len(podNames), "podNames": podNames[:min(len(podNames), maxImagePods)]})
+		}
+		sort.Slice(digests, func(i, j int) bool { return digests[i]["pods"].(int) > digests[j]["pods"].(int) })
+
+		entry := map[string]interface{}{
+			"name":            container.Name,
+			"init":            i < len(template.Spec.InitContainers),
+			"image":           container.Image,
+			"imagePullPolicy": string(pullPolicy),
+			"pinned":          pinned,
+			"runningDigests":  digests,
+		}
+		var warnings []string
+		if len(digests) > 1 {
+			warnings = append(warnings, fmt.Sprintf("pods run %d different digests of this image", len(digests)))
+		}
+		if !pinned && pullPolicy != corev1.PullAlways && isMutableTag(container.Image) {
+			warnings = append(warnings, fmt.Sprintf("mutable tag with imagePullPolicy %s; nodes may keep running a stale cached image", pullPolicy))
+		}
+		if len(warnings) > 0 {
+			entry["warnings"] = warnings
+		}
+		containers = append(containers, entry)
+	}
+
+	return map[string]interface{}{
+		"kind":       kind,
+		"name":       name,
+		"namespace":  namespace,
+		"containers": containers,
+		"totalPods":  len(pods),
+		"pods":       podResults,
+	}, nil
+}
+
+// imageDigest extracts the registry digest from a container status imageID, such as
+// "docker-pullable://nginx@sha256:..." or "docker.io/library/nginx@sha256:...".
+// An imageID without a repository, which some runtimes report for locally built
+// images, is a local image ID rather than a registry digest, and yields "".
+func imageDigest(imageID string) string {
+	_, digest, ok := strings.Cut(imageID, "@")
+	if !ok {
+		return ""
+	}
+	return digest
+}
+
+// imageTag returns the tag of an image reference, or "" if it has none. A colon
+// before the last slash belongs to a registry port, not a tag.
+func imageTag(image string) string {
+	image, _, _ = strings.Cut(image, "@")
+	lastSlash := strings.LastIndex(image, "/")
+	if colon := strings.LastIndex(image, ":"); colon > lastSlash {
+		return image[colon+1:]
+	}
+	return ""
+}
+
+// isMutableTag reports whether an image reference uses the latest tag, explicitly or
+// by omitting the tag.
+func isMutableTag(image string) bool {
+	tag := imageTag(image)
+	return tag == "" || tag == "latest"
+}
+
+// defaultPullPolicy returns the imagePullPolicy the API server defaults to for an
+// image: Always for the latest tag, IfNotPresent otherwise.
+func defaultPullPolicy(image string) corev1.PullPolicy {
+	if !strings.Contains(image, "@") && isMutableTag(image) {
+		return corev1.PullAlways
+	}
+	return corev1.PullIfNotPresent
+}
@@ -0,0 +1,469 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// maxImpactDependents bounds how many cascading dependents AnalyzeDeletionImpact lists.
+const maxImpactDependents = 500
+
+// AnalyzeDeletionImpact reports what deleting a resource would affect, without
+// deleting anything:
+//   - dependents: every object the garbage collector would delete with it, found by
+//     following ownerReferences transitively (e.g. a Deployment's ReplicaSets and their
+//     pods); for a Namespace, the objects it contains, counted by kind
+//   - referencedBy: objects that refer to it and would break or lose their target,
+//     such as Services and PodDisruptionBudgets selecting a workload's pods,
+//     HorizontalPodAutoscalers scaling it, workloads mounting a ConfigMap, Secret, or
+//     PersistentVolumeClaim, and Ingresses routing to a Service
+//   - uses: the ConfigMaps, Secrets, PersistentVolumeClaims, and ServiceAccount a
+//     workload's pods use, which are left behind
+//
+// Objects are searched through metadata-only lists, so that their contents, e.g. the
+// data of Secrets, are never read. Kinds that cannot be listed are skipped and
+// reported in errors.
+// Returns a map describing the impact, or an error.
+func (c *Client) AnalyzeDeletionImpact(ctx context.Context, kind, name, namespace string) (map[string]interface{}, error) {
+	kind = c.resolveKind(kind)
+	gvr, err := c.getCachedGVR(kind)
+	if err != nil {
+		return nil, err
+	}
+	namespaced, err := c.isNamespaced(kind)
+	if err != nil {
+		return nil, err
+	}
+	if !namespaced {
+		namespace = ""
+	} else if namespace == "" {
+		namespace = "default"
+	}
+
+	target, err := c.dynamicClient().Resource(*gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s '%s': %w", kind, name, err)
+	}
+
+	result := map[string]interface{}{
+		"kind":      kind,
+		"name":      name,
+		"namespace": namespace,
+	}
+	var warnings, errs []string
+	if finalizers := target.GetFinalizers(); len(finalizers) > 0 {
+		result["finalizers"] = finalizers
+		warnings = append(warnings, fmt.Sprintf("deletion waits until the finalizers %s are removed by their controllers", strings.Join(finalizers, ", ")))
+	}
+
+	// Dependents live in the owner's namespace; those of a cluster-scoped owner may be
+	// cluster-scoped or in any namespace
+	objects, listErrs := c.listAllObjects(ctx, true, namespace)
+	errs = append(errs, listErrs...)
+	if !namespaced {
+		clusterObjects, listErrs := c.listAllObjects(ctx, false, "")
+		errs = append(errs, listErrs...)
+		objects = append(objects, clusterObjects...)
+	}
+	dependents, truncated := cascadeDependents(target, objects)
+	result["dependents"] = dependents
+	result["dependentCounts"] = countByKind(dependents)
+	if truncated {
+		result["dependentsTruncated"] = true
+	}
+
+	if kind == "Namespace" {
+		contents, listErrs := c.listAllObjects(ctx, true, name)
+		errs = append(errs, listErrs...)
+		counts := map[string]int{}
+		for _, obj := range contents {
+			counts[obj.Kind]++
+		}
+		result["namespaceContents"] = counts
+		if len(contents) > 0 {
+			warnings = append(warnings, fmt.Sprintf("every one of the %d objects in namespace '%s' is deleted with it", len(contents), name))
+		}
+	}
+
+	referencedBy, uses, refErrs := c.deletionReferences(ctx, kind, name, namespace)
+	errs = append(errs, refErrs...)
+	result["referencedBy"] = referencedBy
+	if uses != nil {
+		result["uses"] = uses
+	}
+	for _, ref := range referencedBy {
+		if ref["kind"] == "Service" {
+			warnings = append(warnings, fmt.Sprintf("Service '%s' selects these pods and will have no endpoints unless other pods match it", ref["name"]))
+		}
+	}
+
+	if len(warnings) > 0 {
+		result["warnings"] = warnings
+	}
+	if len(errs) > 0 {
+		result["errors"] = errs
+	}
+	return result, nil
+}
+
+// listAllObjects lists the metadata of the objects of every listable kind, except
+// events, that are namespaced (in namespace, or all namespaces if empty) or
+// cluster-scoped, depending on namespaced. Kinds that fail to list are skipped and
+// returned as errors.
+func (c *Client) listAllObjects(ctx context.Context, namespaced bool, namespace string) ([]metav1.PartialObjectMetadata, []string) {
+	resourceLists, failedGroups, err := c.preferredResources()
+	if err != nil {
+		return nil, []string{err.Error()}
+	}
+	var errs []string
+	for _, gv := range failedGroups {
+		errs = append(errs, fmt.Sprintf("%s: API discovery failed", gv))
+	}
+
+	var objects []metav1.PartialObjectMetadata
+	for _, list := range resourceLists {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			continue
+		}
+		for _, r := range list.APIResources {
+			if strings.Contains(r.Name, "/") || r.Namespaced != namespaced || r.Kind == "Event" || !slices.Contains(r.Verbs, "list") {
+				continue
+			}
+			items, err := c.metadataClient().Resource(gv.WithResource(r.Name)).Namespace(namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %v", r.Kind, err))
+				continue
+			}
+			for _, item := range items.Items {
+				// Metadata lists report their items as PartialObjectMetadata
+				item.TypeMeta = metav1.TypeMeta{APIVersion: list.GroupVersion, Kind: r.Kind}
+				objects = append(objects, item)
+			}
+		}
+	}
+	return objects, errs
+}
+
+// cascadeDependents returns the objects that would be garbage collected with target,
+// following ownerReferences from target through its dependents' dependents, breadth
+// first, and whether the list was cut off at maxImpactDependents. An object with
+// another owner that is not being deleted survives, so it is reported as sharedOwner
+// rather than followed.
+func cascadeDependents(target *unstructured.Unstructured, objects []metav1.PartialObjectMetadata) ([]map[string]interface{}, bool) {
+	byOwner := map[types.UID][]int{}
+	for i := range objects {
+		for _, ref := range objects[i].GetOwnerReferences() {
+			byOwner[ref.UID] = append(byOwner[ref.UID], i)
+		}
+	}
+
+	dependents := []map[string]interface{}{}
+	reported := map[types.UID]map[string]interface{}{}
+	deleted := map[types.UID]bool{target.GetUID(): true}
+	queue := []types.UID{target.GetUID()}
+	owners := map[types.UID]string{target.GetUID(): target.GetKind() + "/" + target.GetName()}
+	for len(queue) > 0 {
+		owner := queue[0]
+		queue = queue[1:]
+		for _, i := range byOwner[owner] {
+			obj := &objects[i]
+			if deleted[obj.GetUID()] {
+				continue
+			}
+			var survivingOwners []string
+			for _, ref := range obj.GetOwnerReferences() {
+				if !deleted[ref.UID] {
+					survivingOwners = append(survivingOwners, ref.Kind+"/"+ref.Name)
+				}
+			}
+			entry, ok := reported[obj.GetUID()]
+			if !ok {
+				if len(dependents) >= maxImpactDependents {
+					return dependents, true
+				}
+				entry = map[string]interface{}{
+					"kind":      obj.Kind,
+					"name":      obj.GetName(),
+					"namespace": obj.GetNamespace(),
+					"owner":     owners[owner],
+				}
+				reported[obj.GetUID()] = entry
+				dependents = append(dependents, entry)
+			}
+			if len(survivingOwners) > 0 {
+				// The garbage collector only deletes an object once all its owners are gone
+				entry["sharedOwner"] = survivingOwners
+				continue
+			}
+			delete(entry, "sharedOwner")
+			deleted[obj.GetUID()] = true
+			owners[obj.GetUID()] = obj.Kind + "/" + obj.GetName()
+			queue = append(queue, obj.GetUID())
+		}
+	}
+	return dependents, false
+}
+
+// countByKind counts the entries of a list of object summaries by their kind.
+func countByKind(entries []map[string]interface{}) map[string]int {
+	counts := map[string]int{}
+	for _, entry := range entries {
+		if _, shared := entry["sharedOwner"]; !shared {
+			counts[entry["kind"].(string)]++
+		}
+	}
+	return counts
+}
+
+// deletionReferences finds the objects that refer to a resource, and for a workload,
+// the configuration objects its pods use. Failed lookups are returned as errors.
+func (c *Client) deletionReferences(ctx context.Context, kind, name, namespace string) ([]map[string]interface{}, []map[string]interface{}, []string) {
+	referencedBy := []map[string]interface{}{}
+	var uses []map[string]interface{}
+	var errs []string
+	add := func(refKind, refName, reason string) {
+		referencedBy = append(referencedBy, map[string]interface{}{"kind": refKind, "name": refName, "reason": reason})
+	}
+
+	switch kind {
+	case "Deployment", "StatefulSet", "DaemonSet", "ReplicaSet", "Job", "CronJob", "Pod":
+		template, err := c.podTemplateOf(ctx, kind, name, namespace)
+		if err != nil {
+			return referencedBy, nil, []string{err.Error()}
+		}
+		uses = podSpecReferences(&template.Spec)
+
+		if kind != "CronJob" && len(template.Labels) > 0 {
+			podLabels := labels.Set(template.Labels)
+			services, err := c.selectingServices(ctx, namespace, podLabels)
+			if err != nil {
+				errs = append(errs, err.Error())
+			}
+			for _, service := range services {
+				add("Service", service["name"].(string), "selects the pods")
+			}
+
+			pdbs, err := c.clientset().PolicyV1().PodDisruptionBudgets(namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("failed to list poddisruptionbudgets: %v", err))
+			} else {
+				for _, pdb := range pdbs.Items {
+					selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+					if err == nil && !selector.Empty() && selector.Matches(podLabels) {
+						add("PodDisruptionBudget", pdb.Name, "selects the pods")
+					}
+				}
+			}
+		}
+
+		hpas, err := c.clientset().AutoscalingV2().HorizontalPodAutoscalers(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("failed to list horizontalpodautoscalers: %v", err))
+		} else {
+			for _, hpa := range hpas.Items {
+				if hpa.Spec.ScaleTargetRef.Kind == kind && hpa.Spec.ScaleTargetRef.Name == name {
+					add("HorizontalPodAutoscaler", hpa.Name, "scales it")
+				}
+			}
+		}
+	case "ConfigMap", "Secret":
+		consumers, err := c.configConsumers(ctx, namespace, kind, name)
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+		for _, consumer := range consumers {
+			referencedBy = append(referencedBy, map[string]interface{}{
+				"kind":   consumer["kind"],
+				"name":   consumer["name"],
+				"reason": "uses it in its pods: " + strings.Join(consumer["usages"].([]string), ", "),
+			})
+		}
+		if kind == "Secret" {
+			ingresses, err := c.clientset().NetworkingV1().Ingresses(namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("failed to list ingresses: %v", err))
+			} else {
+				for _, ingress := range ingresses.Items {
+					for _, tls := range ingress.Spec.TLS {
+						if tls.SecretName == name {
+							add("Ingress", ingress.Name, "serves TLS with it")
+							break
+						}
+					}
+				}
+			}
+		}
+	case "Service":
+		ingresses, err := c.clientset().NetworkingV1().Ingresses(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("failed to list ingresses: %v", err))
+			break
+		}
+		for _, ingress := range ingresses.Items {
+			routes := ingress.Spec.DefaultBackend != nil && ingress.Spec.DefaultBackend.Service != nil && ingress.Spec.DefaultBackend.Service.Name == name
+			for _, rule := range ingress.Spec.Rules {
+				if rule.HTTP == nil {
+					continue
+				}
+				for _, path := range rule.HTTP.Paths {
+					if path.Backend.Service != nil && path.Backend.Service.Name == name {
+						routes = true
+					}
+				}
+			}
+			if routes {
+				add("Ingress", ingress.Name, "routes traffic to it")
+			}
+		}
+	case "PersistentVolumeClaim", "ServiceAccount":
+		pods, err := c.clientset().CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("failed to list pods: %v", err))
+			break
+		}
+		for _, pod := range pods.Items {
+			if kind == "ServiceAccount" && pod.Spec.ServiceAccountName == name {
+				add("Pod", pod.Name, "runs as it")
+				continue
+			}
+			for _, volume := range pod.Spec.Volumes {
+				if kind == "PersistentVolumeClaim" && volume.PersistentVolumeClaim != nil && volume.PersistentVolumeClaim.ClaimName == name {
+					// The storage protection finalizer holds the claim until these pods are gone
+					add("Pod", pod.Name, "mounts it")
+					break
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(referencedBy, func(i, j int) bool {
+		if referencedBy[i]["kind"] != referencedBy[j]["kind"] {
+			return referencedBy[i]["kind"].(string) < referencedBy[j]["kind"].(string)
+		}
+		return referencedBy[i]["name"].(string) < referencedBy[j]["name"].(string)
+	})
+	return referencedBy, uses, errs
+}
+
+// podTemplateOf returns the pod template of a workload, or a bare pod's metadata and spec.
+func (c *Client) podTemplateOf(ctx context.Context, kind, name, namespace string) (*corev1.PodTemplateSpec, error) {
+	switch kind {
+	case "Deployment":
+		d, err := c.clientset().AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get deployment '%s': %w", name, err)
+		}
+		return &d.Spec.Template, nil
+	case "CronJob":
+		cj, err := c.clientset().BatchV1().CronJobs(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get cronjob '%s': %w", name, err)
+		}
+		return &cj.Spec.JobTemplate.Spec.Template, nil
+	case "Pod":
+		pod, err := c.clientset().CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pod '%s': %w", name, err)
+		}
+		return &corev1.PodTemplateSpec{ObjectMeta: pod.ObjectMeta, Spec: pod.Spec}, nil
+	case "StatefulSet":
+		s, err := c.clientset().AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get statefulset '%s': %w", name, err)
+		}
+		return &s.Spec.Template, nil
+	case "DaemonSet":
+		d, err := c.clientset().AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get daemonset '%s': %w", name, err)
+		}
+		return &d.Spec.Template, nil
+	case "ReplicaSet":
+		rs, err := c.clientset().AppsV1().ReplicaSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get replicaset '%s': %w", name, err)
+		}
+		return &rs.Spec.Template, nil
+	case "Job":
+		j, err := c.clientset().BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get job '%s': %w", name, err)
+		}
+		return &j.Spec.Template, nil
+	}
+	return nil, fmt.Errorf("kind '%s' has no pod template", kind)
+}
+
+// podSpecReferences lists the ConfigMaps, Secrets, PersistentVolumeClaims, and
+// ServiceAccount a pod spec uses, each once.
+func podSpecReferences(spec *corev1.PodSpec) []map[string]interface{} {
+	seen := map[string]bool{}
+	refs := []map[string]interface{}{}
+	add := func(kind, name string) {
+		if name == "" || seen[kind+"/"+name] {
+			return
+		}
+		seen[kind+"/"+name] = true
+		refs = append(refs, map[string]interface{}{"kind": kind, "name": name})
+	}
+
+	if spec.ServiceAccountName != "" && spec.ServiceAccountName != "default" {
+		add("ServiceAccount", spec.ServiceAccountName)
+	}
+	for _, secret := range spec.ImagePullSecrets {
+		add("Secret", secret.Name)
+	}
+	for _, volume := range spec.Volumes {
+		switch {
+		case volume.ConfigMap != nil:
+			add("ConfigMap", volume.ConfigMap.Name)
+		case volume.Secret != nil:
+			add("Secret", volume.Secret.SecretName)
+		case volume.PersistentVolumeClaim != nil:
+			add("PersistentVolumeClaim", volume.PersistentVolumeClaim.ClaimName)
+		case volume.Projected != nil:
+			for _, source := range volume.Projected.Sources {
+				if source.ConfigMap != nil {
+					add("ConfigMap", source.ConfigMap.Name)
+				}
+				if source.Secret != nil {
+					add("Secret", source.Secret.Name)
+				}
+			}
+		}
+	}
+	containers := append(append([]corev1.Container{}, spec.InitContainers...), spec.Containers...)
+	for _, container := range containers {
+		for _, source := range container.EnvFrom {
+			if source.ConfigMapRef != nil {
+				add("ConfigMap", source.ConfigMapRef.Name)
+			}
+			if source.SecretRef != nil {
+				add("Secret", source.SecretRef.Name)
+			}
+		}
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			if env.ValueFrom.ConfigMapKeyRef != nil {
+				add("ConfigMap", env.ValueFrom.ConfigMapKeyRef.Name)
+			}
+			if env.ValueFrom.SecretKeyRef != nil {
+				add("Secret", env.ValueFrom.SecretKeyRef.Name)
+			}
+		}
+	}
+	return refs
+}
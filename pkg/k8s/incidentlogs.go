@@ -0,0 +1,257 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+// Limits CollectIncidentLogs applies unless told otherwise, and the most it allows.
+const (
+	DefaultIncidentLogLines    = int64(500)
+	DefaultIncidentLogBytes    = int64(64 * 1024)
+	DefaultIncidentLogPods     = 50
+	DefaultIncidentParallelism = 4
+
+	maxIncidentLogPods     = 200
+	maxIncidentParallelism = 10
+	// maxIncidentTotalBytes bounds the logs returned by one call, across all containers
+	maxIncidentTotalBytes = 1024 * 1024
+	// Log requests are rate limited on top of the parallelism bound, so that large
+	// collections do not flood the API server and the kubelets behind it
+	incidentLogQPS   = 10
+	incidentLogBurst = 10
+)
+
+// IncidentLogOptions selects the pods CollectIncidentLogs reads from and caps what it
+// reads. Zero values select the defaults.
+type IncidentLogOptions struct {
+	// Namespace to read pods from; empty reads all namespaces, which requires a
+	// LabelSelector
+	Namespace     string
+	LabelSelector string
+	// Since is how far back to read logs from
+	Since time.Duration
+	// MaxLines and MaxBytes cap what is read from each container
+	MaxLines int64
+	MaxBytes int64
+	// MaxPods caps how many pods are read from; the rest are listed as skipped
+	MaxPods     int
+	Parallelism int
+}
+
+// withDefaults returns the options with zero values replaced by the defaults and
+// others clamped to the allowed maximums.
+func (o IncidentLogOptions) withDefaults() IncidentLogOptions {
+	if o.Since <= 0 {
+		o.Since = 10 * time.Minute
+	}
+	if o.MaxLines <= 0 {
+		o.MaxLines = DefaultIncidentLogLines
+	}
+	if o.MaxBytes <= 0 {
+		o.MaxBytes = DefaultIncidentLogBytes
+	}
+	if o.MaxPods <= 0 {
+		o.MaxPods = DefaultIncidentLogPods
+	}
+	o.MaxPods = min(o.MaxPods, maxIncidentLogPods)
+	if o.Parallelism <= 0 {
+		o.Parallelism = DefaultIncidentParallelism
+	}
+	o.Parallelism = min(o.Parallelism, maxIncidentParallelism)
+	return o
+}
+
+// incidentLogSource is a container whose logs CollectIncidentLogs reads, and whether
+// the logs are those of its previous run.
+type incidentLogSource struct {
+	pod       *corev1.Pod
+	container string
+	previous  bool
+}
+
+// CollectIncidentLogs gathers the logs written in the last opts.Since by every
+// container of the pods matching a namespace and label selector, e.g. all logs of a
+// set of services over the last 10 minutes during an incident. The logs of a
+// container's previous run are read too if it restarted within the window, since they
+// often hold the crash. Each container contributes at most its last opts.MaxLines
+// lines, of which no more than opts.MaxBytes bytes are read, and at most
+// maxIncidentTotalBytes are returned overall, filled pod by pod in name order.
+// Requests are issued opts.Parallelism at a time and rate limited. Containers whose
+// logs cannot be read are reported with their error and do not stop the others.
+// Returns a map with the logs of each container, labelled with their pod, and a
+// summary of what was read, truncated, or skipped, or an error.
+func (c *Client) CollectIncidentLogs(ctx context.Context, opts IncidentLogOptions) (map[string]interface{}, error) {
+	if opts.Namespace == "" && opts.LabelSelector == "" {
+		return nil, fmt.Errorf("a namespace or a label selector is required")
+	}
+	opts = opts.withDefaults()
+
+	podList, err := c.clientset().CoreV1().Pods(opts.Namespace).List(ctx, metav1.ListOptions{LabelSelector: opts.LabelSelector})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	pods := podList.Items
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[i].Namespace+"/"+pods[i].Name < pods[j].Namespace+"/"+pods[j].Name
+	})
+
+	// Pods that never started a container have no logs to read
+	var skipped []map[string]interface{}
+	var sources []incidentLogSource
+	windowStart := time.Now().Add(-opts.Since)
+	collected := 0
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Status.Phase == corev1.PodPending && len(pod.Status.ContainerStatuses) == 0 {
+			skipped = append(skipped, map[string]interface{}{"pod": pod.Name, "namespace": pod.Namespace, "reason": "pod has not started"})
+			continue
+		}
+		if collected >= opts.MaxPods {
+			skipped = append(skipped, map[string]interface{}{"pod": pod.Name, "namespace": pod.Namespace, "reason": fmt.Sprintf("more than %d pods matched", opts.MaxPods)})
+			continue
+		}
+		collected++
+		for _, container := range pod.Spec.Containers {
+			for _, status := range pod.Status.ContainerStatuses {
+				if status.Name != container.Name {
+					continue
+				}
+				if previous := status.LastTerminationState.Terminated; previous != nil && previous.FinishedAt.Time.After(windowStart) {
+					sources = append(sources, incidentLogSource{pod: pod, container: container.Name, previous: true})
+				}
+			}
+			sources = append(sources, incidentLogSource{pod: pod, container: container.Name})
+		}
+	}
+
+	sinceSeconds := int64(opts.Since.Seconds())
+	limiter := flowcontrol.NewTokenBucketRateLimiter(incidentLogQPS, incidentLogBurst)
+	entries := make([]map[string]interface{}, len(sources))
+	slots := make(chan struct{}, opts.Parallelism)
+	var wg sync.WaitGroup
+	for i, source := range sources {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			entry := map[string]interface{}{
+				"pod":       source.pod.Name,
+				"namespace": source.pod.Namespace,
+				"node":      source.pod.Spec.NodeName,
+				"container": source.container,
+			}
+			if source.previous {
+				entry["previous"] = true
+			}
+			entries[i] = entry
+			if err := limiter.Wait(ctx); err != nil {
+				entry["error"] = err.Error()
+				return
+			}
+
+			logs, err := c.readIncidentLogs(ctx, source, sinceSeconds, opts.MaxLines, opts.MaxBytes)
+			if err != nil {
+				entry["error"] = err.Error()
+				return
+			}
+			lines := strings.Count(logs, "\n")
+			entry["logs"] = logs
+			entry["lines"] = lines
+			// The API server stops at the caps without saying so; reaching one means
+			// lines were likely left out
+			entry["truncated"] = int64(lines) >= opts.MaxLines || int64(len(logs)) >= opts.MaxBytes
+		}()
+	}
+	wg.Wait()
+
+	totalBytes, truncated, failed, dropped := 0, 0, 0, 0
+	for _, entry := range entries {
+		if _, ok := entry["error"]; ok {
+			failed++
+			continue
+		}
+		logs := entry["logs"].(string)
+		if remaining := maxIncidentTotalBytes - totalBytes; len(logs) > remaining {
+			// Keep the most recent lines that fit
+			logs = logs[len(logs)-remaining:]
+			if newline := strings.IndexByte(logs, '\n'); newline >= 0 {
+				logs = logs[newline+1:]
+			} else {
+				logs = ""
+			}
+			entry["logs"] = logs
+			entry["lines"] = strings.Count(logs, "\n")
+			entry["truncated"] = true
+			dropped++
+		}
+		totalBytes += len(logs)
+		if entry["truncated"].(bool) {
+			truncated++
+		}
+	}
+
+	if skipped == nil {
+		skipped = []map[string]interface{}{}
+	}
+	summary := map[string]interface{}{
+		"podsMatched": len(pods),
+		"podsRead":    collected,
+		"podsSkipped": len(skipped),
+		"containers":  len(entries),
+		"failed":      failed,
+		"truncated":   truncated,
+		"bytes":       totalBytes,
+	}
+	if dropped > 0 {
+		summary["note"] = fmt.Sprintf("the logs of %d containers were cut to stay within %d bytes overall; narrow the selector or window to see more", dropped, maxIncidentTotalBytes)
+	}
+	return map[string]interface{}{
+		"namespace":     opts.Namespace,
+		"labelSelector": opts.LabelSelector,
+		"since":         opts.Since.String(),
+		"limits": map[string]interface{}{
+			"maxLinesPerContainer": opts.MaxLines,
+			"maxBytesPerContainer": opts.MaxBytes,
+			"maxPods":              opts.MaxPods,
+			"maxTotalBytes":        maxIncidentTotalBytes,
+		},
+		"summary": summary,
+		"logs":    entries,
+		"skipped": skipped,
+	}, nil
+}
+
+// readIncidentLogs reads the most recent logs of a container written in the last
+// sinceSeconds, with timestamps, capped at tailLines lines and limitBytes bytes.
+func (c *Client) readIncidentLogs(ctx context.Context, source incidentLogSource, sinceSeconds, tailLines, limitBytes int64) (string, error) {
+	logOptions := &corev1.PodLogOptions{
+		Container:    source.container,
+		Previous:     source.previous,
+		Timestamps:   true,
+		SinceSeconds: &sinceSeconds,
+		TailLines:    &tailLines,
+		LimitBytes:   &limitBytes,
+	}
+	stream, err := c.clientset().CoreV1().Pods(source.pod.Namespace).GetLogs(source.pod.Name, logOptions).Stream(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get logs: %w", err)
+	}
+	defer stream.Close()
+	data, err := io.ReadAll(io.LimitReader(stream, limitBytes))
+	if err != nil {
+		return "", fmt.Errorf("failed to read logs: %w", err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,197 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+	"sigs.k8s.io/yaml"
+)
+
+// jobReportTimeout bounds how long WaitForJob spends collecting the final state and
+// logs of a Job once it has finished or the wait has timed out.
+const jobReportTimeout = 30 * time.Second
+
+// CreateJob creates a Job from a YAML manifest in namespace, or in the manifest's
+// namespace if namespace is empty. Unlike CreateOrUpdateResourceYAML it never patches
+// an existing Job, whose template cannot be changed and which would not run again:
+// a Job of the same name is reported as an error, and a manifest with
+// metadata.generateName instead of a name creates a Job with a new name every time.
+// Returns the name of the created Job, or an error.
+func (c *Client) CreateJob(ctx context.Context, namespace, manifest string) (string, error) {
+	job := &batchv1.Job{}
+	if err := yaml.Unmarshal([]byte(manifest), job); err != nil {
+		return "", fmt.Errorf("failed to parse job manifest: %w", err)
+	}
+	if job.Kind != "" && job.Kind != "Job" {
+		return "", fmt.Errorf("manifest is a %s, not a Job", job.Kind)
+	}
+	if job.Name == "" && job.GenerateName == "" {
+		return "", fmt.Errorf("job manifest requires metadata.name or metadata.generateName")
+	}
+	if namespace == "" {
+		namespace = job.Namespace
+	}
+	if namespace == "" {
+		namespace = "default"
+	}
+	job.Namespace = namespace
+
+	created, err := c.clientset().BatchV1().Jobs(namespace).Create(ctx, job, metav1.CreateOptions{})
+	if errors.IsAlreadyExists(err) {
+		return "", fmt.Errorf("job '%s' already exists in namespace '%s' and cannot be run again: delete it first, or use metadata.generateName instead of metadata.name to create a new Job on every run", job.Name, namespace)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to create job: %w", err)
+	}
+	return created.Name, nil
+}
+
+// WaitForJob watches a Job until it completes or fails, or until the timeout expires.
+// The watch is re-established whenever the API server ends it, as it routinely does
+// after a few minutes, so long-running Jobs can be waited for. Once the Job has
+// finished, or the timeout has expired, it collects the logs of the pods the Job
+// created; a Job still running at the timeout is reported as timed out with its
+// current status and logs so far.
+// Returns a map containing the Job status and per-pod logs, or an error.
+func (c *Client) WaitForJob(ctx context.Context, namespace, name string, timeout time.Duration) (map[string]interface{}, error) {
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	fieldSelector := fields.OneTermEqualSelector("metadata.name", name).String()
+	lw := &cache.ListWatch{
+		ListWithContextFunc: func(ctx context.Context, options metav1.ListOptions) (runtime.Object, error) {
+			options.FieldSelector = fieldSelector
+			return c.clientset().BatchV1().Jobs(namespace).List(ctx, options)
+		},
+		WatchFuncWithContext: func(ctx context.Context, options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = fieldSelector
+			return c.clientset().BatchV1().Jobs(namespace).Watch(ctx, options)
+		},
+	}
+
+	var job *batchv1.Job
+	finished := func(obj interface{}) bool {
+		if updated, ok := obj.(*batchv1.Job); ok {
+			job = updated
+		}
+		return job != nil && jobFinishedStatus(job) != ""
+	}
+	precondition := func(store cache.Store) (bool, error) {
+		obj, exists, err := store.GetByKey(namespace + "/" + name)
+		if err != nil {
+			return false, err
+		}
+		if !exists {
+			return false, fmt.Errorf("job '%s' not found", name)
+		}
+		return finished(obj), nil
+	}
+	_, err := watchtools.UntilWithSync(waitCtx, lw, &batchv1.Job{}, precondition, func(event watch.Event) (bool, error) {
+		if event.Type == watch.Deleted {
+			return false, fmt.Errorf("job '%s' was deleted while waiting for it to finish", name)
+		}
+		return finished(event.Object), nil
+	})
+	timedOut := false
+	if err != nil {
+		if ctx.Err() != nil || waitCtx.Err() == nil {
+			return nil, fmt.Errorf("failed to wait for job '%s': %w", name, err)
+		}
+		timedOut = true
+	}
+
+	// The wait may have used up the context, so the report gets a fresh deadline
+	reportCtx, cancelReport := context.WithTimeout(ctx, jobReportTimeout)
+	defer cancelReport()
+	if timedOut || job == nil {
+		current, err := c.clientset().BatchV1().Jobs(namespace).Get(reportCtx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get job '%s': %w", name, err)
+		}
+		job = current
+	}
+
+	status := jobFinishedStatus(job)
+	if status == "" {
+		status = "Running"
+	}
+	result := map[string]interface{}{
+		"name":           job.Name,
+		"namespace":      job.Namespace,
+		"status":         status,
+		"succeeded":      job.Status.Succeeded,
+		"failed":         job.Status.Failed,
+		"startTime":      job.Status.StartTime,
+		"completionTime": job.Status.CompletionTime,
+	}
+	if timedOut {
+		result["timedOut"] = true
+		result["message"] = fmt.Sprintf("job '%s' did not finish within %s", name, timeout)
+	}
+
+	var conditions []map[string]interface{}
+	for _, condition := range job.Status.Conditions {
+		conditions = append(conditions, map[string]interface{}{
+			"type":    condition.Type,
+			"status":  condition.Status,
+			"reason":  condition.Reason,
+			"message": condition.Message,
+		})
+	}
+	result["conditions"] = conditions
+
+	// Collect logs from the pods owned by the job
+	selector, err := metav1.LabelSelectorAsSelector(job.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse selector for job '%s': %w", name, err)
+	}
+	pods, err := c.clientset().CoreV1().Pods(namespace).List(reportCtx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods for job '%s': %w", name, err)
+	}
+
+	var podResults []map[string]interface{}
+	for _, pod := range pods.Items {
+		podResult := map[string]interface{}{
+			"name":  pod.Name,
+			"phase": pod.Status.Phase,
+		}
+		logs, err := c.GetPodsLogs(reportCtx, namespace, "", pod.Name, true, 0)
+		if err != nil {
+			podResult["error"] = err.Error()
+		} else {
+			podResult["logs"] = logs
+		}
+		podResults = append(podResults, podResult)
+	}
+	result["pods"] = podResults
+
+	return result, nil
+}
+
+// jobFinishedStatus returns "Complete" or "Failed" when the job has reached a
+// terminal condition, or an empty string while it is still running.
+func jobFinishedStatus(job *batchv1.Job) string {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return "Complete"
+		case batchv1.JobFailed:
+			return "Failed"
+		}
+	}
+	return ""
+}
@@ -0,0 +1,54 @@
+package k8s_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clienttesting "k8s.io/client-go/testing"
+
+	"github.com/reza-gholizade/k8s-mcp-server/pkg/k8s/k8stest"
+)
+
+const jobManifest = `apiVersion: batch/v1
+kind: Job
+metadata:
+  name: migrate
+spec:
+  template:
+    spec:
+      restartPolicy: Never
+      containers:
+      - name: migrate
+        image: busybox
+`
+
+func TestCreateJob(t *testing.T) {
+	fakes := k8stest.NewFakes()
+	client := fakes.Client()
+	ctx := context.Background()
+
+	name, err := client.CreateJob(ctx, k8stest.Namespace, jobManifest)
+	if err != nil || name != "migrate" {
+		t.Fatalf("CreateJob: got %q, %v", name, err)
+	}
+	// A second run must not patch the finished Job into looking like a new one
+	if _, err := client.CreateJob(ctx, k8stest.Namespace, jobManifest); err == nil || !strings.Contains(err.Error(), "generateName") {
+		t.Errorf("expected an already exists error suggesting generateName, got %v", err)
+	}
+
+	// The fake does not generate names, so do it as the API server would
+	fakes.Clientset.PrependReactor("create", "jobs", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		job := action.(clienttesting.CreateAction).GetObject().(*batchv1.Job)
+		if job.Name == "" {
+			job.Name = job.GenerateName + "x7k2p"
+		}
+		return false, nil, nil
+	})
+	generated := strings.Replace(jobManifest, "name: migrate\n", "generateName: migrate-\n", 1)
+	if name, err := client.CreateJob(ctx, k8stest.Namespace, generated); err != nil || name != "migrate-x7k2p" {
+		t.Errorf("CreateJob with generateName: got %q, %v; want migrate-x7k2p", name, err)
+	}
+}
@@ -0,0 +1,52 @@
+package k8stest_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/reza-gholizade/k8s-mcp-server/handlers"
+	"github.com/reza-gholizade/k8s-mcp-server/pkg/k8s"
+	"github.com/reza-gholizade/k8s-mcp-server/pkg/k8s/k8stest"
+)
+
+func ExampleNewFakeClient() {
+	client := k8stest.NewFakeClient()
+
+	deployment, err := client.GetResource(context.Background(), "Deployment", k8stest.DeploymentName, k8stest.Namespace, k8s.ConsistencyStrong)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	spec := deployment["spec"].(map[string]interface{})
+	fmt.Println("replicas:", spec["replicas"])
+	// Output: replicas: 2
+}
+
+// A tool handler is tested by calling it with a fake client and a request.
+func ExampleNewFakeClient_handler() {
+	handler := handlers.ListResources(k8stest.NewFakeClient())
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "listResources"
+	request.Params.Arguments = map[string]interface{}{"Kind": "Pod", "namespace": k8stest.Namespace}
+	result, err := handler(context.Background(), request)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	var pods []map[string]interface{}
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &pods); err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, pod := range pods {
+		fmt.Println(pod["name"])
+	}
+	// Unordered output:
+	// web-5d4f8b7c9-abcde
+	// web-5d4f8b7c9-fghij
+}
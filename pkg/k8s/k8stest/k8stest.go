@@ -0,0 +1,327 @@
+// Package k8stest provides a k8s.Client backed by in-memory fake clients, seeded with
+// common objects, so that client methods and tool handlers can be tested without a
+// cluster.
+//
+// The helpers live in this package rather than in package k8s, so that the fakes and
+// seed objects stay out of the client's API and out of the server binary. Tests of
+// package k8s use them from the external k8s_test package.
+package k8stest
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubernetesfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	metadatafake "k8s.io/client-go/metadata/fake"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+
+	"github.com/reza-gholizade/k8s-mcp-server/pkg/k8s"
+)
+
+// Names of the objects seeded by Objects.
+const (
+	Namespace      = "demo"
+	NodeName       = "node-1"
+	DeploymentName = "web"
+	ReplicaSetName = "web-5d4f8b7c9"
+	templateHash   = "5d4f8b7c9"
+)
+
+// PodNames are the names of the pods seeded by Objects.
+var PodNames = []string{ReplicaSetName + "-abcde", ReplicaSetName + "-fghij"}
+
+// fakeAPIResources is the discovery document served by the fakes. It covers the
+// kinds the seeded objects and the client's methods use most often.
+var fakeAPIResources = []*metav1.APIResourceList{
+	{
+		GroupVersion: "v1",
+		APIResources: []metav1.APIResource{
+			fakeAPIResource("namespaces", "Namespace", false),
+			fakeAPIResource("nodes", "Node", false),
+			fakeAPIResource("pods", "Pod", true),
+			fakeAPIResource("services", "Service", true),
+			fakeAPIResource("configmaps", "ConfigMap", true),
+			fakeAPIResource("secrets", "Secret", true),
+			fakeAPIResource("events", "Event", true),
+			fakeAPIResource("serviceaccounts", "ServiceAccount", true),
+			fakeAPIResource("persistentvolumeclaims", "PersistentVolumeClaim", true),
+			fakeAPIResource("resourcequotas", "ResourceQuota", true),
+		},
+	},
+	{
+		GroupVersion: "apps/v1",
+		APIResources: []metav1.APIResource{
+			fakeAPIResource("deployments", "Deployment", true),
+			fakeAPIResource("replicasets", "ReplicaSet", true),
+			fakeAPIResource("statefulsets", "StatefulSet", true),
+			fakeAPIResource("daemonsets", "DaemonSet", true),
+		},
+	},
+	{
+		GroupVersion: "batch/v1",
+		APIResources: []metav1.APIResource{
+			fakeAPIResource("jobs", "Job", true),
+			fakeAPIResource("cronjobs", "CronJob", true),
+		},
+	},
+}
+
+// fakeAPIResource describes a resource that supports every standard verb.
+func fakeAPIResource(name, kind string, namespaced bool) metav1.APIResource {
+	return metav1.APIResource{
+		Name:       name,
+		Kind:       kind,
+		Namespaced: namespaced,
+		Verbs:      metav1.Verbs{"create", "delete", "deletecollection", "get", "list", "patch", "update", "watch"},
+	}
+}
+
+// fakeDiscovery serves the fake clientset's resources from ServerPreferredResources,
+// which the upstream fake leaves empty but getCachedGVR relies on. It implements
+// discovery.CachedDiscoveryInterface, so that k8s.NewClientFromInterfaces uses it as is.
+type fakeDiscovery struct {
+	*fakediscovery.FakeDiscovery
+}
+
+// ServerPreferredResources returns the resources configured on the fake.
+func (d *fakeDiscovery) ServerPreferredResources() ([]*metav1.APIResourceList, error) {
+	return d.Resources, nil
+}
+
+// Fresh reports that the fake's resources are always up to date.
+func (d *fakeDiscovery) Fresh() bool {
+	return true
+}
+
+// Invalidate does nothing, since the fake's resources are not cached.
+func (d *fakeDiscovery) Invalidate() {}
+
+// ServerPreferredNamespacedResources returns the namespaced resources configured on the fake.
+func (d *fakeDiscovery) ServerPreferredNamespacedResources() ([]*metav1.APIResourceList, error) {
+	var lists []*metav1.APIResourceList
+	for _, list := range d.Resources {
+		namespaced := &metav1.APIResourceList{GroupVersion: list.GroupVersion}
+		for _, r := range list.APIResources {
+			if r.Namespaced {
+				namespaced.APIResources = append(namespaced.APIResources, r)
+			}
+		}
+		lists = append(lists, namespaced)
+	}
+	return lists, nil
+}
+
+// Fakes are the in-memory fake clients behind the Clients built by NewFakes. Tests can
+// add reactors to them, e.g. to fail or inspect requests, and read the actions they
+// recorded.
+type Fakes struct {
+	Clientset *kubernetesfake.Clientset
+	Dynamic   *dynamicfake.FakeDynamicClient
+	Metadata  *metadatafake.FakeMetadataClient
+	Metrics   *metricsfake.Clientset
+	discovery *fakeDiscovery
+}
+
+// NewFakeClient creates a Client backed by in-memory fakes seeded with the given
+// objects, or with Objects if none are given; see NewFakes.
+func NewFakeClient(objects ...runtime.Object) *k8s.Client {
+	return NewFakes(objects...).Client()
+}
+
+// NewFakes creates fake typed, dynamic, metadata, discovery, and metrics clients. The
+// typed, dynamic, and metadata clients are each seeded with the given objects, or with
+// Objects if none are given. The fakes keep separate stores: an object written through
+// one is not visible through the others.
+func NewFakes(objects ...runtime.Object) *Fakes {
+	if len(objects) == 0 {
+		objects = Objects()
+	}
+	typedObjects := make([]runtime.Object, 0, len(objects))
+	dynamicObjects := make([]runtime.Object, 0, len(objects))
+	metadataObjects := make([]runtime.Object, 0, len(objects))
+	for _, obj := range objects {
+		typedObjects = append(typedObjects, obj.DeepCopyObject())
+		dynamicObjects = append(dynamicObjects, obj.DeepCopyObject())
+		// The metadata fake files objects under the kind set on their TypeMeta
+		if accessor, err := meta.Accessor(obj.DeepCopyObject()); err == nil {
+			partial := meta.AsPartialObjectMetadata(accessor)
+			partial.TypeMeta = metav1.TypeMeta{APIVersion: obj.GetObjectKind().GroupVersionKind().GroupVersion().String(), Kind: obj.GetObjectKind().GroupVersionKind().Kind}
+			metadataObjects = append(metadataObjects, partial)
+		}
+	}
+	metadataScheme := metadatafake.NewTestScheme()
+	_ = metav1.AddMetaToScheme(metadataScheme)
+
+	clientset := kubernetesfake.NewSimpleClientset(typedObjects...)
+	discoveryClient := &fakeDiscovery{FakeDiscovery: clientset.Discovery().(*fakediscovery.FakeDiscovery)}
+	discoveryClient.Resources = fakeAPIResources
+
+	return &Fakes{
+		Clientset: clientset,
+		Dynamic:   dynamicfake.NewSimpleDynamicClient(scheme.Scheme, dynamicObjects...),
+		Metadata:  metadatafake.NewSimpleMetadataClient(metadataScheme, metadataObjects...),
+		Metrics:   metricsfake.NewSimpleClientset(),
+		discovery: discoveryClient,
+	}
+}
+
+// Client creates a Client using the fakes. Clients created from the same fakes share
+// their objects but not their caches.
+func (f *Fakes) Client() *k8s.Client {
+	return k8s.NewClientFromInterfaces(f.Clientset, f.Dynamic, f.Metadata, f.discovery, f.Metrics)
+}
+
+// Objects returns the objects NewFakes seeds by default: the Namespace namespace, a
+// node, and a two-replica Deployment at revision 1 with its ReplicaSet and running
+// pods, linked by owner references and the pod-template-hash label as a real cluster
+// would. Each call returns new objects, which tests may modify before seeding them.
+func Objects() []runtime.Object {
+	labels := map[string]string{"app": DeploymentName}
+	podLabels := map[string]string{"app": DeploymentName, appsv1.DefaultDeploymentUniqueLabelKey: templateHash}
+	replicas := int32(len(PodNames))
+	created := metav1.Now()
+	controller := true
+	started := true
+
+	container := corev1.Container{
+		Name:  "web",
+		Image: "nginx:1.27",
+		Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 80}},
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("100m"),
+				corev1.ResourceMemory: resource.MustParse("128Mi"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("256Mi"),
+			},
+		},
+	}
+
+	objects := []runtime.Object{
+		&corev1.Namespace{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Namespace"},
+			ObjectMeta: metav1.ObjectMeta{Name: Namespace, CreationTimestamp: created},
+			Status:     corev1.NamespaceStatus{Phase: corev1.NamespaceActive},
+		},
+		&corev1.Node{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Node"},
+			ObjectMeta: metav1.ObjectMeta{Name: NodeName, CreationTimestamp: created},
+			Status: corev1.NodeStatus{
+				Capacity: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("4"),
+					corev1.ResourceMemory: resource.MustParse("16Gi"),
+					corev1.ResourcePods:   resource.MustParse("110"),
+				},
+				Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("3800m"),
+					corev1.ResourceMemory: resource.MustParse("15Gi"),
+					corev1.ResourcePods:   resource.MustParse("110"),
+				},
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+			},
+		},
+		&appsv1.Deployment{
+			TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              DeploymentName,
+				Namespace:         Namespace,
+				UID:               "fake-deployment-uid",
+				Labels:            labels,
+				Annotations:       map[string]string{"deployment.kubernetes.io/revision": "1"},
+				Generation:        1,
+				CreationTimestamp: created,
+			},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Selector: &metav1.LabelSelector{MatchLabels: labels},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: labels},
+					Spec:       corev1.PodSpec{Containers: []corev1.Container{container}},
+				},
+			},
+			Status: appsv1.DeploymentStatus{
+				ObservedGeneration: 1,
+				Replicas:           replicas,
+				UpdatedReplicas:    replicas,
+				ReadyReplicas:      replicas,
+				AvailableReplicas:  replicas,
+			},
+		},
+		&appsv1.ReplicaSet{
+			TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "ReplicaSet"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              ReplicaSetName,
+				Namespace:         Namespace,
+				UID:               "fake-replicaset-uid",
+				Labels:            podLabels,
+				Annotations:       map[string]string{"deployment.kubernetes.io/revision": "1"},
+				CreationTimestamp: created,
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: "apps/v1",
+					Kind:       "Deployment",
+					Name:       DeploymentName,
+					UID:        "fake-deployment-uid",
+					Controller: &controller,
+				}},
+			},
+			Spec: appsv1.ReplicaSetSpec{
+				Replicas: &replicas,
+				Selector: &metav1.LabelSelector{MatchLabels: podLabels},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: podLabels},
+					Spec:       corev1.PodSpec{Containers: []corev1.Container{container}},
+				},
+			},
+			Status: appsv1.ReplicaSetStatus{
+				Replicas:          replicas,
+				ReadyReplicas:     replicas,
+				AvailableReplicas: replicas,
+			},
+		},
+	}
+
+	for _, name := range PodNames {
+		objects = append(objects, &corev1.Pod{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         Namespace,
+				UID:               types.UID("fake-pod-uid-" + name),
+				Labels:            podLabels,
+				CreationTimestamp: created,
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: "apps/v1",
+					Kind:       "ReplicaSet",
+					Name:       ReplicaSetName,
+					UID:        "fake-replicaset-uid",
+					Controller: &controller,
+				}},
+			},
+			Spec: corev1.PodSpec{
+				NodeName:   NodeName,
+				Containers: []corev1.Container{container},
+			},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+				StartTime:  &created,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:    container.Name,
+					Image:   container.Image,
+					Ready:   true,
+					Started: &started,
+					State:   corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: created}},
+				}},
+			},
+		})
+	}
+	return objects
+}
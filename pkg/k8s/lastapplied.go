@@ -0,0 +1,59 @@
+package k8s
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// LastAppliedConfigAnnotation is the annotation kubectl apply uses to record the
+// configuration it last applied, which its three-way merge compares against.
+const LastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// SetLastAppliedConfiguration records a YAML or JSON manifest in its own
+// last-applied-configuration annotation, as kubectl apply does, so that objects
+// created or updated through a merge patch remain compatible with a later
+// kubectl apply and with drift detection. The annotation holds the manifest as
+// compact JSON without the annotation itself. A non-empty namespace is set on the
+// manifest first, so that the recorded configuration matches what is applied.
+// Returns the annotated manifest as JSON, or an error.
+func SetLastAppliedConfiguration(manifest, namespace string) (string, error) {
+	jsonData, err := yaml.YAMLToJSON([]byte(manifest))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	obj := &unstructured.Unstructured{}
+	if err := json.Unmarshal(jsonData, &obj.Object); err != nil {
+		return "", fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	if namespace != "" {
+		obj.SetNamespace(namespace)
+	}
+
+	annotations := obj.GetAnnotations()
+	delete(annotations, LastAppliedConfigAnnotation)
+	if len(annotations) == 0 {
+		unstructured.RemoveNestedField(obj.Object, "metadata", "annotations")
+	} else {
+		obj.SetAnnotations(annotations)
+	}
+	applied, err := json.Marshal(obj.Object)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize manifest: %w", err)
+	}
+
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	// kubectl terminates the recorded configuration with a newline
+	annotations[LastAppliedConfigAnnotation] = string(applied) + "\n"
+	obj.SetAnnotations(annotations)
+
+	out, err := json.Marshal(obj.Object)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize manifest: %w", err)
+	}
+	return string(out), nil
+}
@@ -0,0 +1,181 @@
+package k8s
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RedactedValue replaces the value of every masked field.
+const RedactedValue = "<redacted>"
+
+// MaskRule identifies a field to redact in resources of a given kind.
+// A Kind of "*" applies the rule to every kind.
+type MaskRule struct {
+	Kind string
+	Path []string
+}
+
+// DefaultMaskRules are always applied: Secret payloads, and the last-applied
+// annotation, which carries a full copy of the object (including Secret data).
+var DefaultMaskRules = []MaskRule{
+	{Kind: "Secret", Path: []string{"data"}},
+	{Kind: "Secret", Path: []string{"stringData"}},
+	{Kind: "*", Path: []string{"metadata", "annotations", LastAppliedConfigAnnotation}},
+}
+
+// ParseMaskRules parses a comma-separated list of "Kind:path" rules, e.g.
+// "Secret:data,*:metadata.annotations[example.com/token]". Path segments are
+// separated by dots; keys containing dots or slashes are written in brackets.
+func ParseMaskRules(value string) ([]MaskRule, error) {
+	var rules []MaskRule
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		kind, path, ok := strings.Cut(entry, ":")
+		if !ok || kind == "" || path == "" {
+			return nil, fmt.Errorf("invalid mask rule '%s': expected 'Kind:path'", entry)
+		}
+		segments, err := parseFieldPath(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid mask rule '%s': %w", entry, err)
+		}
+		rules = append(rules, MaskRule{Kind: kind, Path: segments})
+	}
+	return rules, nil
+}
+
+// parseFieldPath splits a dotted field path into its segments, treating
+// bracketed segments such as [example.com/key] as a single literal key.
+func parseFieldPath(path string) ([]string, error) {
+	var segments []string
+	var current strings.Builder
+	for i := 0; i < len(path); i++ {
+		switch path[i] {
+		case '.':
+			if current.Len() > 0 {
+				segments = append(segments, current.String())
+				current.Reset()
+			}
+		case '[':
+			end := strings.IndexByte(path[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated '[' in path '%s'", path)
+			}
+			if current.Len() > 0 {
+				segments = append(segments, current.String())
+				current.Reset()
+			}
+			segments = append(segments, path[i+1:i+end])
+			i += end
+		default:
+			current.WriteByte(path[i])
+		}
+	}
+	if current.Len() > 0 {
+		segments = append(segments, current.String())
+	}
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("empty path")
+	}
+	return segments, nil
+}
+
+// SetMaskRules configures additional fields to redact on top of DefaultMaskRules.
+func (c *Client) SetMaskRules(rules []MaskRule) {
+	c.maskRules = rules
+}
+
+// MaskSensitiveFields returns a copy of a resource's content with every field
+// matched by the mask rules redacted. Map-valued fields keep their keys so callers
+// can still see which entries exist. The input is not modified.
+func (c *Client) MaskSensitiveFields(obj map[string]interface{}) map[string]interface{} {
+	if obj == nil {
+		return nil
+	}
+	kind, _ := obj["kind"].(string)
+
+	var masked map[string]interface{}
+	for _, rules := range [][]MaskRule{DefaultMaskRules, c.maskRules} {
+		for _, rule := range rules {
+			if rule.Kind != "*" && !strings.EqualFold(rule.Kind, kind) {
+				continue
+			}
+			if !hasFieldPath(obj, rule.Path) {
+				continue
+			}
+			if masked == nil {
+				masked = deepCopyMap(obj)
+			}
+			redactFieldPath(masked, rule.Path)
+		}
+	}
+	if masked == nil {
+		return obj
+	}
+	return masked
+}
+
+// hasFieldPath reports whether the nested field at path exists in obj.
+func hasFieldPath(obj map[string]interface{}, path []string) bool {
+	current := obj
+	for i, segment := range path {
+		value, ok := current[segment]
+		if !ok {
+			return false
+		}
+		if i == len(path)-1 {
+			return true
+		}
+		if current, ok = value.(map[string]interface{}); !ok {
+			return false
+		}
+	}
+	return false
+}
+
+// redactFieldPath replaces the value at path with RedactedValue. If the value is
+// a map, each of its entries is redacted instead.
+func redactFieldPath(obj map[string]interface{}, path []string) {
+	current := obj
+	for _, segment := range path[:len(path)-1] {
+		next, ok := current[segment].(map[string]interface{})
+		if !ok {
+			return
+		}
+		current = next
+	}
+	last := path[len(path)-1]
+	if values, ok := current[last].(map[string]interface{}); ok {
+		for key := range values {
+			values[key] = RedactedValue
+		}
+		return
+	}
+	current[last] = RedactedValue
+}
+
+// deepCopyMap copies the map and slice structure of unstructured content.
+func deepCopyMap(in map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(in))
+	for key, value := range in {
+		out[key] = deepCopyValue(value)
+	}
+	return out
+}
+
+func deepCopyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return deepCopyMap(v)
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = deepCopyValue(item)
+		}
+		return out
+	default:
+		return v
+	}
+}
@@ -0,0 +1,140 @@
+package k8s
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// workloadPods returns the pods selected by a workload's spec.selector. Any kind with
+// a label selector in that field is supported (Deployment, StatefulSet, DaemonSet,
+// ReplicaSet, Job, ...).
+func (c *Client) workloadPods(ctx context.Context, kind, name, namespace string) ([]corev1.Pod, error) {
+	gvr, err := c.getCachedGVR(kind)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := c.dynamicClient().Resource(*gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s '%s': %w", kind, name, err)
+	}
+
+	rawSelector, found, _ := unstructured.NestedMap(obj.Object, "spec", "selector")
+	if !found {
+		return nil, fmt.Errorf("%s '%s' has no pod selector", kind, name)
+	}
+	selector := &metav1.LabelSelector{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(rawSelector, selector); err != nil {
+		return nil, fmt.Errorf("invalid selector on %s '%s': %w", kind, name, err)
+	}
+	labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector on %s '%s': %w", kind, name, err)
+	}
+
+	podList, err := c.clientset().CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods for %s '%s': %w", kind, name, err)
+	}
+	return podList.Items, nil
+}
+
+// logLine is a single timestamped line of container output.
+type logLine struct {
+	time    time.Time
+	source  string
+	message string
+}
+
+// GetMergedWorkloadLogs fetches the last tailLines lines of every container in every
+// pod of a workload, with timestamps, and merges them into a single stream ordered by
+// time. Each line is prefixed with its timestamp and the pod/container it came from,
+// and the merged stream is cut to the most recent tailLines lines overall. If limitBytes
+// is positive, no more than that many bytes are read from each container, so the
+// oldest of its tailLines lines are kept.
+// Containers whose logs cannot be read are reported in an errors list.
+// Returns a map containing the merged logs and the sources read, or an error.
+func (c *Client) GetMergedWorkloadLogs(ctx context.Context, kind, name, namespace string, tailLines, limitBytes int64) (map[string]interface{}, error) {
+	kind = c.resolveKind(kind)
+	pods, err := c.workloadPods(ctx, kind, name, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if len(pods) == 0 {
+		return nil, fmt.Errorf("%s '%s' has no pods", kind, name)
+	}
+
+	var lines []logLine
+	var sources []string
+	var errs []string
+	for _, pod := range pods {
+		for _, container := range pod.Spec.Containers {
+			source := pod.Name + "/" + container.Name
+			logOptions := &corev1.PodLogOptions{
+				Container:  container.Name,
+				TailLines:  &tailLines,
+				Timestamps: true,
+			}
+			if limitBytes > 0 {
+				logOptions.LimitBytes = &limitBytes
+			}
+			stream, err := c.clientset().CoreV1().Pods(namespace).GetLogs(pod.Name, logOptions).Stream(ctx)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %v", source, err))
+				continue
+			}
+			sources = append(sources, source)
+
+			var last time.Time
+			scanner := bufio.NewScanner(stream)
+			scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+			for scanner.Scan() {
+				timestamp, message, _ := strings.Cut(scanner.Text(), " ")
+				// Lines are written with an RFC3339Nano prefix; keep any line without
+				// one next to the line before it
+				if parsed, err := time.Parse(time.RFC3339Nano, timestamp); err == nil {
+					last = parsed
+				} else {
+					message = scanner.Text()
+				}
+				lines = append(lines, logLine{time: last, source: source, message: message})
+			}
+			if err := scanner.Err(); err != nil {
+				errs = append(errs, fmt.Sprintf("%s: failed to read logs: %v", source, err))
+			}
+			stream.Close()
+		}
+	}
+
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].time.Before(lines[j].time)
+	})
+	if tailLines > 0 && int64(len(lines)) > tailLines {
+		lines = lines[int64(len(lines))-tailLines:]
+	}
+
+	var merged strings.Builder
+	for _, line := range lines {
+		fmt.Fprintf(&merged, "%s [%s] %s\n", line.time.Format(time.RFC3339Nano), line.source, line.message)
+	}
+
+	result := map[string]interface{}{
+		"kind":    kind,
+		"name":    name,
+		"sources": sources,
+		"lines":   len(lines),
+		"logs":    merged.String(),
+	}
+	if len(errs) > 0 {
+		result["errors"] = errs
+	}
+	return result, nil
+}
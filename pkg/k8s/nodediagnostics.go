@@ -0,0 +1,214 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+)
+
+// maxNodeEvents is how many of a node's most recent events DiagnoseNode returns.
+const maxNodeEvents = 20
+
+// nodePressureConditions are the node conditions that report resource pressure, with
+// the name DiagnoseNode reports each under.
+var nodePressureConditions = []struct {
+	name          string
+	conditionType corev1.NodeConditionType
+}{
+	{"memory", corev1.NodeMemoryPressure},
+	{"disk", corev1.NodeDiskPressure},
+	{"pid", corev1.NodePIDPressure},
+	{"network", corev1.NodeNetworkUnavailable},
+}
+
+// DiagnoseNode gathers a node's health signals in one call: its conditions as reported
+// by the kubelet, whether it is under memory, disk, or PID pressure or has its network
+// unavailable, its allocatable CPU, memory, and ephemeral storage against the requests
+// and limits of the pods running on it and, from metrics-server, its actual usage, its
+// pod count against maxPods, its taints, and its maxNodeEvents most recent events.
+// Findings, such as the node not being ready or being under pressure, are summarized
+// up front. Sections that cannot be gathered, e.g. usage without metrics-server, are
+// reported in an errors list instead of failing the diagnosis.
+// Returns a map with one key per section, or an error if the node cannot be read.
+func (c *Client) DiagnoseNode(ctx context.Context, nodeName string) (map[string]interface{}, error) {
+	node, err := c.clientset().CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node '%s': %w", nodeName, err)
+	}
+
+	var errs []string
+	var findings []string
+	result := map[string]interface{}{
+		"node":          nodeName,
+		"unschedulable": node.Spec.Unschedulable,
+		"taints":        node.Spec.Taints,
+		"info": map[string]interface{}{
+			"kubeletVersion":          node.Status.NodeInfo.KubeletVersion,
+			"containerRuntimeVersion": node.Status.NodeInfo.ContainerRuntimeVersion,
+			"osImage":                 node.Status.NodeInfo.OSImage,
+			"kernelVersion":           node.Status.NodeInfo.KernelVersion,
+			"architecture":            node.Status.NodeInfo.Architecture,
+			"creationTimestamp":       node.CreationTimestamp.Time,
+		},
+	}
+	if node.Spec.Unschedulable {
+		findings = append(findings, "the node is cordoned; no new pods are scheduled to it")
+	}
+
+	ready := corev1.ConditionUnknown
+	conditionStatus := map[corev1.NodeConditionType]corev1.ConditionStatus{}
+	conditions := []map[string]interface{}{}
+	for _, condition := range node.Status.Conditions {
+		conditionStatus[condition.Type] = condition.Status
+		if condition.Type == corev1.NodeReady {
+			ready = condition.Status
+		}
+		entry := map[string]interface{}{
+			"type":               condition.Type,
+			"status":             condition.Status,
+			"lastHeartbeatTime":  condition.LastHeartbeatTime.Time,
+			"lastTransitionTime": condition.LastTransitionTime.Time,
+		}
+		if condition.Reason != "" {
+			entry["reason"] = condition.Reason
+			entry["message"] = condition.Message
+		}
+		conditions = append(conditions, entry)
+	}
+	result["ready"] = ready == corev1.ConditionTrue
+	result["conditions"] = conditions
+	switch ready {
+	case corev1.ConditionFalse:
+		findings = append(findings, "the node is not ready")
+	case corev1.ConditionUnknown:
+		findings = append(findings, "the node's readiness is unknown; the kubelet may have stopped posting status")
+	}
+
+	pressure := map[string]bool{}
+	for _, condition := range nodePressureConditions {
+		pressure[condition.name] = conditionStatus[condition.conditionType] == corev1.ConditionTrue
+		if pressure[condition.name] {
+			findings = append(findings, fmt.Sprintf("the node reports %s; the kubelet may evict pods or refuse new ones", condition.conditionType))
+		}
+	}
+	result["pressure"] = pressure
+
+	// Requests and limits of the pods on the node, as the scheduler accounts for them
+	pods, err := c.clientset().CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", nodeName).String(),
+	})
+	if err != nil {
+		errs = append(errs, fmt.Sprintf("failed to list pods on node '%s': %v", nodeName, err))
+	} else {
+		requests := corev1.ResourceList{}
+		limits := corev1.ResourceList{}
+		podCount := int64(0)
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+				continue
+			}
+			podCount++
+			podRequests, podLimits := podRequestsAndLimits(pod)
+			addResourceList(requests, podRequests)
+			addResourceList(limits, podLimits)
+		}
+
+		var usage corev1.ResourceList
+		if nodeMetrics, err := c.metricsClientset().MetricsV1beta1().NodeMetricses().Get(ctx, nodeName, metav1.GetOptions{}); err != nil {
+			errs = append(errs, fmt.Sprintf("failed to get metrics for node '%s': %v", nodeName, err))
+		} else {
+			usage = nodeMetrics.Usage
+		}
+
+		resources := map[string]interface{}{}
+		for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory, corev1.ResourceEphemeralStorage} {
+			allocatable, ok := node.Status.Allocatable[name]
+			if !ok {
+				continue
+			}
+			entry := map[string]interface{}{"allocatable": allocatable.String()}
+			if capacity, ok := node.Status.Capacity[name]; ok {
+				entry["capacity"] = capacity.String()
+			}
+			for key, list := range map[string]corev1.ResourceList{"requests": requests, "limits": limits, "usage": usage} {
+				quantity, ok := list[name]
+				if !ok {
+					if key == "usage" {
+						continue
+					}
+					quantity = resource.Quantity{}
+				}
+				entry[key] = quantity.String()
+				entry[key+"Percent"] = percentOf(quantity.MilliValue(), allocatable.MilliValue())
+			}
+			resources[string(name)] = entry
+		}
+		result["resources"] = resources
+
+		podsEntry := map[string]interface{}{"count": podCount}
+		if maxPods, ok := node.Status.Allocatable[corev1.ResourcePods]; ok {
+			podsEntry["maxPods"] = maxPods.Value()
+			podsEntry["percent"] = percentOf(podCount, maxPods.Value())
+			if podCount >= maxPods.Value() {
+				findings = append(findings, fmt.Sprintf("the node runs %d pods, its maxPods; no more pods fit on it", podCount))
+			}
+		}
+		result["pods"] = podsEntry
+	}
+
+	if events, err := c.nodeEvents(ctx, nodeName); err != nil {
+		errs = append(errs, err.Error())
+	} else {
+		result["events"] = events
+	}
+
+	if findings == nil {
+		findings = []string{}
+	}
+	result["findings"] = findings
+	if len(errs) > 0 {
+		result["errors"] = errs
+	}
+	return result, nil
+}
+
+// nodeEvents returns the maxNodeEvents most recent events about a node, newest first.
+// Node events are recorded in whichever namespace the reporting component chose, so
+// all namespaces are searched.
+func (c *Client) nodeEvents(ctx context.Context, nodeName string) ([]map[string]interface{}, error) {
+	eventList, err := c.clientset().CoreV1().Events("").List(ctx, metav1.ListOptions{
+		FieldSelector: fields.AndSelectors(
+			fields.OneTermEqualSelector("involvedObject.kind", "Node"),
+			fields.OneTermEqualSelector("involvedObject.name", nodeName),
+		).String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve events for node '%s': %w", nodeName, err)
+	}
+
+	sort.SliceStable(eventList.Items, func(i, j int) bool {
+		return eventLastSeen(eventList.Items[i]).After(eventLastSeen(eventList.Items[j]))
+	})
+	if len(eventList.Items) > maxNodeEvents {
+		eventList.Items = eventList.Items[:maxNodeEvents]
+	}
+
+	events := []map[string]interface{}{}
+	for _, event := range eventList.Items {
+		events = append(events, map[string]interface{}{
+			"type":     event.Type,
+			"reason":   event.Reason,
+			"source":   event.Source.Component,
+			"message":  event.Message,
+			"count":    event.Count,
+			"lastSeen": eventLastSeen(event),
+		})
+	}
+	return events, nil
+}
@@ -0,0 +1,206 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// maxOverviewItems bounds the unhealthy workloads and warning groups GetClusterOverview lists.
+	maxOverviewItems = 10
+	// maxOverviewConsumers bounds the pods GetClusterOverview lists per resource as top consumers.
+	maxOverviewConsumers = 5
+	// overviewWarningWindow is how far back GetClusterOverview looks for warning events.
+	overviewWarningWindow = time.Hour
+)
+
+// GetClusterOverview returns a single snapshot of cluster health: node count and
+// readiness, pods by phase, unhealthy workloads, the most frequent warning events of
+// the last hour, and the pods using the most CPU and memory. The sections are gathered
+// concurrently, and a section that cannot be gathered (for example top consumers when
+// metrics-server is not installed) is reported under "errors" instead of failing the
+// whole overview.
+// Returns a map with one key per section, or an error if no section could be gathered.
+func (c *Client) GetClusterOverview(ctx context.Context) (map[string]interface{}, error) {
+	sections := map[string]func(ctx context.Context) (interface{}, error){
+		"nodes":              c.overviewNodes,
+		"pods":               c.overviewPods,
+		"unhealthyWorkloads": c.overviewWorkloads,
+		"warnings":           c.overviewWarnings,
+		"topConsumers":       c.overviewConsumers,
+	}
+
+	result := map[string]interface{}{}
+	errs := map[string]string{}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, gather := range sections {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			section, err := gather(ctx)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[name] = err.Error()
+				return
+			}
+			result[name] = section
+		}()
+	}
+	wg.Wait()
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("failed to get cluster overview: %v", errs)
+	}
+	if len(errs) > 0 {
+		result["errors"] = errs
+	}
+	return result, nil
+}
+
+// overviewNodes counts nodes by readiness and lists those that are not ready,
+// unschedulable, or under memory, disk, or PID pressure.
+func (c *Client) overviewNodes(ctx context.Context) (interface{}, error) {
+	nodes, err := c.clientset().CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	ready := 0
+	problems := []map[string]interface{}{}
+	for _, node := range nodes.Items {
+		isReady := false
+		var pressure []string
+		for _, condition := range node.Status.Conditions {
+			switch condition.Type {
+			case corev1.NodeReady:
+				isReady = condition.Status == corev1.ConditionTrue
+			case corev1.NodeMemoryPressure, corev1.NodeDiskPressure, corev1.NodePIDPressure:
+				if condition.Status == corev1.ConditionTrue {
+					pressure = append(pressure, string(condition.Type))
+				}
+			}
+		}
+		if isReady {
+			ready++
+		}
+		if isReady && !node.Spec.Unschedulable && len(pressure) == 0 {
+			continue
+		}
+		problem := map[string]interface{}{
+			"name":          node.Name,
+			"ready":         isReady,
+			"unschedulable": node.Spec.Unschedulable,
+		}
+		if len(pressure) > 0 {
+			problem["pressure"] = pressure
+		}
+		problems = append(problems, problem)
+	}
+
+	return map[string]interface{}{
+		"total":    len(nodes.Items),
+		"ready":    ready,
+		"notReady": len(nodes.Items) - ready,
+		"problems": problems,
+	}, nil
+}
+
+// overviewPods counts the pods in all namespaces by phase.
+func (c *Client) overviewPods(ctx context.Context) (interface{}, error) {
+	pods, err := c.clientset().CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	byPhase := map[string]int{}
+	for _, pod := range pods.Items {
+		phase := string(pod.Status.Phase)
+		if phase == "" {
+			phase = "Unknown"
+		}
+		byPhase[phase]++
+	}
+	return map[string]interface{}{
+		"total":   len(pods.Items),
+		"byPhase": byPhase,
+	}, nil
+}
+
+// overviewWorkloads lists the workloads with fewer ready replicas than desired, largest
+// shortfall first.
+func (c *Client) overviewWorkloads(ctx context.Context) (interface{}, error) {
+	unhealthy, err := c.GetWorkloadReadiness(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(unhealthy, func(i, j int) bool {
+		return unhealthy[i]["shortfall"].(int32) > unhealthy[j]["shortfall"].(int32)
+	})
+	return map[string]interface{}{
+		"total":     len(unhealthy),
+		"workloads": unhealthy[:min(len(unhealthy), maxOverviewItems)],
+	}, nil
+}
+
+// overviewWarnings summarizes the warning events of the last hour, most frequent first.
+func (c *Client) overviewWarnings(ctx context.Context) (interface{}, error) {
+	warnings, err := c.GetClusterWarnings(ctx, overviewWarningWindow)
+	if err != nil {
+		return nil, err
+	}
+	groups := warnings["warnings"].([]map[string]interface{})
+	warnings["warnings"] = groups[:min(len(groups), maxOverviewItems)]
+	return warnings, nil
+}
+
+// overviewConsumers lists the pods using the most CPU and the most memory, as reported
+// by metrics-server.
+func (c *Client) overviewConsumers(ctx context.Context) (interface{}, error) {
+	podMetrics, err := c.metricsClientset().MetricsV1beta1().PodMetricses("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pod metrics: %w", err)
+	}
+
+	type podUsage struct {
+		namespace, name string
+		usage           corev1.ResourceList
+	}
+	usages := make([]podUsage, 0, len(podMetrics.Items))
+	for _, metrics := range podMetrics.Items {
+		usage := podUsage{namespace: metrics.Namespace, name: metrics.Name, usage: corev1.ResourceList{}}
+		for _, container := range metrics.Containers {
+			addResourceList(usage.usage, container.Usage)
+		}
+		usages = append(usages, usage)
+	}
+
+	top := func(name corev1.ResourceName) []map[string]interface{} {
+		sort.SliceStable(usages, func(i, j int) bool {
+			a, b := usages[i].usage[name], usages[j].usage[name]
+			return a.Cmp(b) > 0
+		})
+		consumers := []map[string]interface{}{}
+		for _, usage := range usages[:min(len(usages), maxOverviewConsumers)] {
+			quantity := usage.usage[name]
+			consumers = append(consumers, map[string]interface{}{
+				"namespace": usage.namespace,
+				"pod":       usage.name,
+				"usage":     quantity.String(),
+			})
+		}
+		return consumers
+	}
+
+	return map[string]interface{}{
+		"cpu":    top(corev1.ResourceCPU),
+		"memory": top(corev1.ResourceMemory),
+	}, nil
+}
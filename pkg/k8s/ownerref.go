@@ -0,0 +1,78 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
+)
+
+// ResolveOwnerReference looks up an owner object and builds an owner reference to it,
+// so that an object carrying the reference is garbage-collected when the owner is
+// deleted. Namespaced owners are looked up in the given namespace, since an owner must
+// be in the same namespace as its dependents or be cluster-scoped. If uid is given it
+// must match the owner's UID, which guards against a recreated owner with the same name.
+// Returns the owner reference, or an error.
+func (c *Client) ResolveOwnerReference(ctx context.Context, namespace, ownerKind, ownerName, uid string, controller bool) (*metav1.OwnerReference, error) {
+	gvr, err := c.getCachedGVR(ownerKind)
+	if err != nil {
+		return nil, err
+	}
+	namespaced, err := c.isNamespaced(ownerKind)
+	if err != nil {
+		return nil, err
+	}
+
+	var owner *unstructured.Unstructured
+	if namespaced {
+		owner, err = c.dynamicClient().Resource(*gvr).Namespace(namespace).Get(ctx, ownerName, metav1.GetOptions{})
+	} else {
+		owner, err = c.dynamicClient().Resource(*gvr).Get(ctx, ownerName, metav1.GetOptions{})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get owner %s '%s': %w", ownerKind, ownerName, err)
+	}
+	if uid != "" && types.UID(uid) != owner.GetUID() {
+		return nil, fmt.Errorf("owner %s '%s' has UID %s, not %s", ownerKind, ownerName, owner.GetUID(), uid)
+	}
+
+	return &metav1.OwnerReference{
+		APIVersion: owner.GetAPIVersion(),
+		Kind:       owner.GetKind(),
+		Name:       owner.GetName(),
+		UID:        owner.GetUID(),
+		Controller: &controller,
+	}, nil
+}
+
+// AddOwnerReference adds an owner reference to a YAML or JSON manifest, replacing any
+// existing reference to the same owner.
+// Returns the updated manifest as JSON, or an error.
+func AddOwnerReference(manifest string, ref *metav1.OwnerReference) (string, error) {
+	jsonData, err := yaml.YAMLToJSON([]byte(manifest))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	obj := &unstructured.Unstructured{}
+	if err := json.Unmarshal(jsonData, &obj.Object); err != nil {
+		return "", fmt.Errorf("failed to parse manifest: %w", err)
+	}
+
+	refs := []metav1.OwnerReference{*ref}
+	for _, existing := range obj.GetOwnerReferences() {
+		if existing.UID != ref.UID {
+			refs = append(refs, existing)
+		}
+	}
+	obj.SetOwnerReferences(refs)
+
+	out, err := json.Marshal(obj.Object)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize manifest: %w", err)
+	}
+	return string(out), nil
+}
@@ -0,0 +1,213 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// planIgnoredFields are the server-maintained fields left out when comparing a live
+// object with the result of applying its manifest.
+var planIgnoredFields = [][]string{
+	{"metadata", "managedFields"},
+	{"metadata", "resourceVersion"},
+	{"metadata", "generation"},
+	{"status"},
+}
+
+// PlanReconcile is the dry-run counterpart of ApplyAndPrune: it computes what applying
+// the bundle with the same prune selector would do, without changing anything. Each
+// object in the bundle is submitted as a server-side dry run, so defaulting and
+// admission apply, and the outcome is compared with the live object: it is planned as
+// a create if it does not exist, an update listing the changed field paths if applying
+// it changes anything, and unchanged otherwise. Objects matching the prune selector
+// whose kind appears in the bundle or in pruneKinds, but which are not in the bundle,
+// are planned as deletes. Objects the API server rejects are reported as errors and
+// never planned for deletion.
+// Returns a map with the planned creates, updates, deletes, and unchanged objects, or an error.
+func (c *Client) PlanReconcile(ctx context.Context, namespace, manifests, pruneSelector string, pruneKinds []string) (map[string]interface{}, error) {
+	if pruneSelector == "" {
+		return nil, fmt.Errorf("a prune label selector is required")
+	}
+	selector, err := labels.Parse(pruneSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid prune selector: %w", err)
+	}
+	pruneLabels, err := labels.ConvertSelectorToLabelsMap(pruneSelector)
+	if err != nil {
+		return nil, fmt.Errorf("prune selector must only use equality requirements: %w", err)
+	}
+
+	objects, err := decodeManifests(manifests)
+	if err != nil {
+		return nil, err
+	}
+	if len(objects) == 0 {
+		return nil, fmt.Errorf("no manifests to plan")
+	}
+
+	keep := map[string]bool{}
+	kinds := map[string]bool{}
+	for _, kind := range pruneKinds {
+		kinds[c.resolveKind(kind)] = true
+	}
+
+	creates := []string{}
+	updates := []map[string]interface{}{}
+	unchanged := []string{}
+	var errs []string
+	for _, obj := range objects {
+		kind := obj.GetKind()
+		kinds[kind] = true
+
+		objLabels := obj.GetLabels()
+		if objLabels == nil {
+			objLabels = map[string]string{}
+		}
+		for key, value := range pruneLabels {
+			objLabels[key] = value
+		}
+		obj.SetLabels(objLabels)
+
+		operation, changes, err := c.planObject(ctx, namespace, obj)
+		key := objectKey(kind, obj.GetNamespace(), obj.GetName())
+		keep[key] = true
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", key, err))
+			continue
+		}
+		switch operation {
+		case "create":
+			creates = append(creates, key)
+		case "update":
+			updates = append(updates, map[string]interface{}{"object": key, "changes": changes})
+		default:
+			unchanged = append(unchanged, key)
+		}
+	}
+
+	deletes := []string{}
+	for kind := range kinds {
+		pruned, err := c.pruneKind(ctx, kind, namespace, selector, keep, true)
+		deletes = append(deletes, pruned...)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("prune %s: %v", kind, err))
+		}
+	}
+	sort.Strings(deletes)
+
+	return map[string]interface{}{
+		"creates":   creates,
+		"updates":   updates,
+		"deletes":   deletes,
+		"unchanged": unchanged,
+		"errors":    errs,
+		"summary": map[string]int{
+			"create":    len(creates),
+			"update":    len(updates),
+			"delete":    len(deletes),
+			"unchanged": len(unchanged),
+		},
+	}, nil
+}
+
+// planObject determines what applying obj would do, using the same merge patch (or
+// create) as applyObject submitted as a server-side dry run. Namespaced objects without
+// a namespace are placed in the given namespace; the resolved namespace is set on obj.
+// Returns "create", "update" with the changed field paths, or "unchanged".
+func (c *Client) planObject(ctx context.Context, namespace string, obj *unstructured.Unstructured) (string, []string, error) {
+	kind := obj.GetKind()
+	gvr, err := c.getCachedGVR(kind)
+	if err != nil {
+		return "", nil, err
+	}
+	namespaced, err := c.isNamespaced(kind)
+	if err != nil {
+		return "", nil, err
+	}
+	objNamespace := ""
+	if namespaced {
+		objNamespace = obj.GetNamespace()
+		if objNamespace == "" {
+			objNamespace = namespace
+		}
+	}
+	obj.SetNamespace(objNamespace)
+	resource := c.dynamicClient().Resource(*gvr).Namespace(objNamespace)
+
+	live, err := resource.Get(ctx, obj.GetName(), metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		if _, err := resource.Create(ctx, obj, metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}}); err != nil {
+			return "", nil, fmt.Errorf("dry run rejected by the API server: %w", err)
+		}
+		return "create", nil, nil
+	}
+	if err != nil {
+		return "", nil, err
+	}
+
+	patch, err := json.Marshal(obj.Object)
+	if err != nil {
+		return "", nil, err
+	}
+	result, err := resource.Patch(ctx, obj.GetName(), types.MergePatchType, patch, metav1.PatchOptions{DryRun: []string{metav1.DryRunAll}})
+	if err != nil {
+		return "", nil, fmt.Errorf("dry run rejected by the API server: %w", err)
+	}
+
+	before := live.DeepCopy().Object
+	after := result.DeepCopy().Object
+	for _, field := range planIgnoredFields {
+		unstructured.RemoveNestedField(before, field...)
+		unstructured.RemoveNestedField(after, field...)
+	}
+	changes := changedPaths("", before, after)
+	if len(changes) == 0 {
+		return "unchanged", nil, nil
+	}
+	sort.Strings(changes)
+	return "update", changes, nil
+}
+
+// changedPaths returns the dotted paths of the fields that differ between two objects.
+// Nested maps are compared field by field; lists and scalars are compared whole.
+func changedPaths(prefix string, before, after map[string]interface{}) []string {
+	var paths []string
+	join := func(key string) string {
+		if prefix == "" {
+			return key
+		}
+		return prefix + "." + key
+	}
+	for key, afterValue := range after {
+		beforeValue, ok := before[key]
+		if !ok {
+			paths = append(paths, join(key))
+			continue
+		}
+		beforeMap, beforeIsMap := beforeValue.(map[string]interface{})
+		afterMap, afterIsMap := afterValue.(map[string]interface{})
+		if beforeIsMap && afterIsMap {
+			paths = append(paths, changedPaths(join(key), beforeMap, afterMap)...)
+			continue
+		}
+		beforeJSON, _ := json.Marshal(beforeValue)
+		afterJSON, _ := json.Marshal(afterValue)
+		if string(beforeJSON) != string(afterJSON) {
+			paths = append(paths, join(key))
+		}
+	}
+	for key := range before {
+		if _, ok := after[key]; !ok {
+			paths = append(paths, join(key))
+		}
+	}
+	return paths
+}
@@ -0,0 +1,449 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// EvictPod evicts a single pod using the eviction API so that PodDisruptionBudgets
+// are honored. An eviction blocked by a disruption budget is not treated as an error;
+// instead the result reports that the eviction was not allowed and why.
+// Returns a map describing the outcome, or an error.
+func (c *Client) EvictPod(ctx context.Context, namespace, podName string, gracePeriodSeconds *int64) (map[string]interface{}, error) {
+	eviction := &policyv1.Eviction{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      podName,
+			Namespace: namespace,
+		},
+	}
+	if gracePeriodSeconds != nil {
+		eviction.DeleteOptions = &metav1.DeleteOptions{GracePeriodSeconds: gracePeriodSeconds}
+	}
+
+	result := map[string]interface{}{
+		"pod":       podName,
+		"namespace": namespace,
+	}
+
+	err := c.clientset().PolicyV1().Evictions(namespace).Evict(ctx, eviction)
+	switch {
+	case err == nil:
+		result["evicted"] = true
+		result["message"] = fmt.Sprintf("Pod '%s' in namespace '%s' evicted", podName, namespace)
+	case errors.IsTooManyRequests(err):
+		// The API server returns 429 when a PodDisruptionBudget does not allow the disruption
+		result["evicted"] = false
+		result["blockedByDisruptionBudget"] = true
+		result["message"] = err.Error()
+	default:
+		return nil, fmt.Errorf("failed to evict pod '%s' in namespace '%s': %w", podName, namespace, err)
+	}
+
+	return result, nil
+}
+
+// ResolveSelector returns the pods in a namespace currently matched by a label selector.
+// This is useful for verifying which pods a Service, NetworkPolicy, or workload selector
+// actually targets.
+// Returns a slice of maps, each describing a matching pod, or an error.
+func (c *Client) ResolveSelector(ctx context.Context, namespace, labelSelector string) ([]map[string]interface{}, error) {
+	selector, err := labels.Parse(labelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label selector '%s': %w", labelSelector, err)
+	}
+
+	pods, err := c.clientset().CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	matches := []map[string]interface{}{}
+	for _, pod := range pods.Items {
+		matches = append(matches, map[string]interface{}{
+			"name":      pod.Name,
+			"namespace": pod.Namespace,
+			"phase":     pod.Status.Phase,
+			"ready":     isPodReady(&pod),
+			"podIP":     pod.Status.PodIP,
+			"node":      pod.Spec.NodeName,
+			"labels":    pod.Labels,
+		})
+	}
+	return matches, nil
+}
+
+// isPodReady reports whether the pod's Ready condition is true.
+func isPodReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// GetPodEnv returns the effective environment of each container in a pod.
+// Literal values are returned as-is; valueFrom references to ConfigMaps, Secrets,
+// and pod fields are resolved, and envFrom sources are expanded into individual
+// variables. Secret values are redacted unless reveal is true.
+// Returns a map from container name to its resolved variables, or an error.
+func (c *Client) GetPodEnv(ctx context.Context, namespace, podName, containerName string, reveal bool) (map[string]interface{}, error) {
+	pod, err := c.clientset().CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pod '%s' in namespace '%s': %w", podName, namespace, err)
+	}
+
+	resolver := &envResolver{
+		client:     c,
+		namespace:  namespace,
+		pod:        pod,
+		reveal:     reveal,
+		configMaps: map[string]*corev1.ConfigMap{},
+		secrets:    map[string]*corev1.Secret{},
+	}
+
+	containers := append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+	result := map[string]interface{}{}
+	for _, container := range containers {
+		if containerName != "" && container.Name != containerName {
+			continue
+		}
+		result[container.Name] = resolver.resolveContainer(ctx, container)
+	}
+	if containerName != "" && len(result) == 0 {
+		return nil, fmt.Errorf("container '%s' not found in pod '%s'", containerName, podName)
+	}
+	return result, nil
+}
+
+// envResolver resolves container environment references, caching the
+// ConfigMaps and Secrets it reads.
+type envResolver struct {
+	client     *Client
+	namespace  string
+	pod        *corev1.Pod
+	reveal     bool
+	configMaps map[string]*corev1.ConfigMap
+	secrets    map[string]*corev1.Secret
+}
+
+// resolveContainer resolves envFrom sources first and then env entries, matching
+// the kubelet's precedence where explicit env entries override envFrom keys.
+func (r *envResolver) resolveContainer(ctx context.Context, container corev1.Container) []map[string]interface{} {
+	var vars []map[string]interface{}
+	index := map[string]int{}
+	set := func(entry map[string]interface{}) {
+		name := entry["name"].(string)
+		if i, ok := index[name]; ok {
+			vars[i] = entry
+			return
+		}
+		index[name] = len(vars)
+		vars = append(vars, entry)
+	}
+
+	for _, source := range container.EnvFrom {
+		switch {
+		case source.ConfigMapRef != nil:
+			cm, err := r.configMap(ctx, source.ConfigMapRef.Name)
+			if err != nil {
+				set(map[string]interface{}{"name": source.Prefix + "*", "source": "configMap/" + source.ConfigMapRef.Name, "error": err.Error()})
+				continue
+			}
+			for key, value := range cm.Data {
+				set(map[string]interface{}{"name": source.Prefix + key, "value": value, "source": "configMap/" + cm.Name})
+			}
+		case source.SecretRef != nil:
+			secret, err := r.secret(ctx, source.SecretRef.Name)
+			if err != nil {
+				set(map[string]interface{}{"name": source.Prefix + "*", "source": "secret/" + source.SecretRef.Name, "error": err.Error()})
+				continue
+			}
+			for key, value := range secret.Data {
+				set(map[string]interface{}{"name": source.Prefix + key, "value": r.secretValue(value), "source": "secret/" + secret.Name})
+			}
+		}
+	}
+
+	for _, env := range container.Env {
+		entry := map[string]interface{}{"name": env.Name}
+		switch {
+		case env.ValueFrom == nil:
+			entry["value"] = env.Value
+		case env.ValueFrom.ConfigMapKeyRef != nil:
+			ref := env.ValueFrom.ConfigMapKeyRef
+			entry["source"] = "configMap/" + ref.Name + "#" + ref.Key
+			if cm, err := r.configMap(ctx, ref.Name); err != nil {
+				entry["error"] = err.Error()
+			} else if value, ok := cm.Data[ref.Key]; ok {
+				entry["value"] = value
+			} else {
+				entry["error"] = fmt.Sprintf("key '%s' not found", ref.Key)
+			}
+		case env.ValueFrom.SecretKeyRef != nil:
+			ref := env.ValueFrom.SecretKeyRef
+			entry["source"] = "secret/" + ref.Name + "#" + ref.Key
+			if secret, err := r.secret(ctx, ref.Name); err != nil {
+				entry["error"] = err.Error()
+			} else if value, ok := secret.Data[ref.Key]; ok {
+				entry["value"] = r.secretValue(value)
+			} else {
+				entry["error"] = fmt.Sprintf("key '%s' not found", ref.Key)
+			}
+		case env.ValueFrom.FieldRef != nil:
+			entry["source"] = "field/" + env.ValueFrom.FieldRef.FieldPath
+			if value, ok := podFieldValue(r.pod, env.ValueFrom.FieldRef.FieldPath); ok {
+				entry["value"] = value
+			} else {
+				entry["error"] = "unsupported field path"
+			}
+		case env.ValueFrom.ResourceFieldRef != nil:
+			ref := env.ValueFrom.ResourceFieldRef
+			entry["source"] = "resource/" + ref.Resource
+			if ref.ContainerName != "" {
+				entry["source"] = "resource/" + ref.ContainerName + "/" + ref.Resource
+			}
+		}
+		set(entry)
+	}
+	return vars
+}
+
+func (r *envResolver) configMap(ctx context.Context, name string) (*corev1.ConfigMap, error) {
+	if cm, ok := r.configMaps[name]; ok {
+		return cm, nil
+	}
+	cm, err := r.client.clientset().CoreV1().ConfigMaps(r.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get configmap '%s': %w", name, err)
+	}
+	r.configMaps[name] = cm
+	return cm, nil
+}
+
+func (r *envResolver) secret(ctx context.Context, name string) (*corev1.Secret, error) {
+	if secret, ok := r.secrets[name]; ok {
+		return secret, nil
+	}
+	secret, err := r.client.clientset().CoreV1().Secrets(r.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret '%s': %w", name, err)
+	}
+	r.secrets[name] = secret
+	return secret, nil
+}
+
+func (r *envResolver) secretValue(value []byte) string {
+	if r.reveal {
+		return string(value)
+	}
+	return RedactedValue
+}
+
+// podFieldValue resolves the field paths supported by the downward API.
+func podFieldValue(pod *corev1.Pod, fieldPath string) (string, bool) {
+	switch fieldPath {
+	case "metadata.name":
+		return pod.Name, true
+	case "metadata.namespace":
+		return pod.Namespace, true
+	case "metadata.uid":
+		return string(pod.UID), true
+	case "spec.nodeName":
+		return pod.Spec.NodeName, true
+	case "spec.serviceAccountName":
+		return pod.Spec.ServiceAccountName, true
+	case "status.hostIP":
+		return pod.Status.HostIP, true
+	case "status.podIP":
+		return pod.Status.PodIP, true
+	}
+	for prefix, values := range map[string]map[string]string{
+		"metadata.labels['":      pod.Labels,
+		"metadata.annotations['": pod.Annotations,
+	} {
+		if strings.HasPrefix(fieldPath, prefix) && strings.HasSuffix(fieldPath, "']") {
+			return values[strings.TrimSuffix(strings.TrimPrefix(fieldPath, prefix), "']")], true
+		}
+	}
+	return "", false
+}
+
+// maxOwnerDepth guards against ownership cycles when walking ownerReferences.
+const maxOwnerDepth = 10
+
+// GetPodOwner walks a pod's controller ownerReferences upwards (for example
+// Pod → ReplicaSet → Deployment, or Pod → Job → CronJob) and returns the top-level
+// controlling workload along with the full chain. A pod without a controller is
+// its own top-level owner. If an owner in the chain no longer exists, the walk
+// stops there and the result is marked as orphaned.
+// Returns a map with the owner and the ownership chain, or an error.
+func (c *Client) GetPodOwner(ctx context.Context, namespace, podName string) (map[string]interface{}, error) {
+	pod, err := c.clientset().CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pod '%s' in namespace '%s': %w", podName, namespace, err)
+	}
+
+	chain := []map[string]interface{}{{"kind": "Pod", "name": pod.Name}}
+	owner := metav1.GetControllerOf(pod)
+	orphaned := false
+	for depth := 0; owner != nil && depth < maxOwnerDepth; depth++ {
+		link := map[string]interface{}{
+			"kind":       owner.Kind,
+			"name":       owner.Name,
+			"apiVersion": owner.APIVersion,
+		}
+		chain = append(chain, link)
+
+		gvr, err := c.getCachedGVR(owner.Kind)
+		if err != nil {
+			link["error"] = err.Error()
+			break
+		}
+		// Static pods are owned by their (cluster-scoped) Node
+		ownerNamespace := namespace
+		if namespaced, err := c.isNamespaced(owner.Kind); err == nil && !namespaced {
+			ownerNamespace = ""
+		}
+		obj, err := c.dynamicClient().Resource(*gvr).Namespace(ownerNamespace).Get(ctx, owner.Name, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			link["missing"] = true
+			orphaned = true
+			break
+		}
+		if err != nil {
+			link["error"] = err.Error()
+			break
+		}
+		if obj.GetUID() != owner.UID {
+			// An object with the same name was recreated; the original owner is gone
+			link["missing"] = true
+			orphaned = true
+			break
+		}
+		owner = metav1.GetControllerOf(obj)
+	}
+
+	top := chain[len(chain)-1]
+	if orphaned {
+		top = chain[len(chain)-2]
+	}
+	return map[string]interface{}{
+		"pod":       pod.Name,
+		"namespace": namespace,
+		"owner":     map[string]interface{}{"kind": top["kind"], "name": top["name"]},
+		"chain":     chain,
+		"orphaned":  orphaned,
+	}, nil
+}
+
+// exitSignals names the signals commonly behind exit codes above 128.
+var exitSignals = map[int32]string{
+	1:  "SIGHUP",
+	2:  "SIGINT",
+	3:  "SIGQUIT",
+	6:  "SIGABRT",
+	9:  "SIGKILL",
+	11: "SIGSEGV",
+	13: "SIGPIPE",
+	15: "SIGTERM",
+}
+
+// GetTerminationDetails returns, for each container of a pod that has terminated, the
+// details of its current and previous terminations: exit code, reason, signal, the
+// termination message the container wrote (by default to /dev/termination-log), and
+// when it ran. A signal is inferred from exit codes above 128 when the runtime did not
+// report one, e.g. 137 as SIGKILL.
+// Returns a map with the pod's phase and a per-container report, or an error.
+func (c *Client) GetTerminationDetails(ctx context.Context, namespace, podName string) (map[string]interface{}, error) {
+	pod, err := c.clientset().CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pod '%s': %w", podName, err)
+	}
+
+	messagePolicies := map[string]corev1.TerminationMessagePolicy{}
+	for _, container := range pod.Spec.InitContainers {
+		messagePolicies[container.Name] = container.TerminationMessagePolicy
+	}
+	for _, container := range pod.Spec.Containers {
+		messagePolicies[container.Name] = container.TerminationMessagePolicy
+	}
+
+	var containers []map[string]interface{}
+	addStatuses := func(containerType string, statuses []corev1.ContainerStatus) {
+		for _, status := range statuses {
+			entry := map[string]interface{}{
+				"name":         status.Name,
+				"type":         containerType,
+				"restartCount": status.RestartCount,
+			}
+			if policy := messagePolicies[status.Name]; policy != "" {
+				entry["terminationMessagePolicy"] = policy
+			}
+			terminated := false
+			if status.State.Terminated != nil {
+				entry["current"] = terminationDetails(status.State.Terminated)
+				terminated = true
+			}
+			if status.LastTerminationState.Terminated != nil {
+				entry["previous"] = terminationDetails(status.LastTerminationState.Terminated)
+				terminated = true
+			}
+			if terminated {
+				containers = append(containers, entry)
+			}
+		}
+	}
+	addStatuses("init", pod.Status.InitContainerStatuses)
+	addStatuses("container", pod.Status.ContainerStatuses)
+	addStatuses("ephemeral", pod.Status.EphemeralContainerStatuses)
+
+	result := map[string]interface{}{
+		"pod":        podName,
+		"namespace":  namespace,
+		"phase":      pod.Status.Phase,
+		"containers": containers,
+	}
+	if pod.Status.Reason != "" {
+		result["reason"] = pod.Status.Reason
+		result["message"] = pod.Status.Message
+	}
+	if len(containers) == 0 {
+		result["note"] = "no container in this pod has terminated"
+	}
+	return result, nil
+}
+
+// terminationDetails describes a terminated container state.
+func terminationDetails(state *corev1.ContainerStateTerminated) map[string]interface{} {
+	details := map[string]interface{}{
+		"exitCode":   state.ExitCode,
+		"reason":     state.Reason,
+		"startedAt":  state.StartedAt.Time,
+		"finishedAt": state.FinishedAt.Time,
+	}
+	signal := state.Signal
+	if signal == 0 && state.ExitCode > 128 {
+		signal = state.ExitCode - 128
+	}
+	if signal > 0 {
+		details["signal"] = signal
+		if name, ok := exitSignals[signal]; ok {
+			details["signalName"] = name
+		}
+	}
+	if state.Message != "" {
+		details["terminationMessage"] = strings.TrimSpace(state.Message)
+	}
+	if !state.StartedAt.IsZero() && !state.FinishedAt.IsZero() {
+		details["ranFor"] = state.FinishedAt.Sub(state.StartedAt.Time).String()
+	}
+	return details
+}
@@ -0,0 +1,104 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// crdGVR identifies CustomResourceDefinitions, whose printer columns are read directly.
+var crdGVR = schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}
+
+// PrinterColumn is one of a custom resource's additionalPrinterColumns, the columns
+// `kubectl get` shows for it.
+type PrinterColumn struct {
+	Name     string
+	Type     string
+	JSONPath string
+	Priority int64
+}
+
+// GetPrinterColumns looks up the CustomResourceDefinition behind a kind and returns
+// the additionalPrinterColumns declared for the served version.
+// Returns the columns (empty if the CRD declares none), or an error if the kind is
+// not a custom resource.
+func (c *Client) GetPrinterColumns(ctx context.Context, kind string) ([]PrinterColumn, error) {
+	kind = c.resolveKind(kind)
+	gvr, err := c.getCachedGVR(kind)
+	if err != nil {
+		return nil, err
+	}
+	if gvr.Group == "" {
+		return nil, fmt.Errorf("kind '%s' is a built-in resource and has no printer columns", kind)
+	}
+
+	crd, err := c.dynamicClient().Resource(crdGVR).Get(ctx, gvr.Resource+"."+gvr.Group, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the CustomResourceDefinition for kind '%s': %w", kind, err)
+	}
+
+	versions, _, _ := unstructured.NestedSlice(crd.Object, "spec", "versions")
+	for _, v := range versions {
+		version, ok := v.(map[string]interface{})
+		if !ok || version["name"] != gvr.Version {
+			continue
+		}
+		rawColumns, _, _ := unstructured.NestedSlice(version, "additionalPrinterColumns")
+		columns := make([]PrinterColumn, 0, len(rawColumns))
+		for _, rc := range rawColumns {
+			column, ok := rc.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, _, _ := unstructured.NestedString(column, "name")
+			columnType, _, _ := unstructured.NestedString(column, "type")
+			path, _, _ := unstructured.NestedString(column, "jsonPath")
+			priority, _, _ := unstructured.NestedInt64(column, "priority")
+			columns = append(columns, PrinterColumn{Name: name, Type: columnType, JSONPath: path, Priority: priority})
+		}
+		return columns, nil
+	}
+	return nil, fmt.Errorf("version '%s' of kind '%s' is not defined in its CustomResourceDefinition", gvr.Version, kind)
+}
+
+// printerColumnValues evaluates each column's JSONPath against an object. Columns
+// that do not resolve are set to nil, matching the empty cell kubectl prints.
+func printerColumnValues(obj map[string]interface{}, columns []PrinterColumn) map[string]interface{} {
+	values := make(map[string]interface{}, len(columns))
+	for _, column := range columns {
+		values[column.Name] = nil
+
+		parser := jsonpath.New(column.Name).AllowMissingKeys(true)
+		if err := parser.Parse(printerColumnTemplate(column.JSONPath)); err != nil {
+			continue
+		}
+		results, err := parser.FindResults(obj)
+		if err != nil || len(results) == 0 || len(results[0]) == 0 {
+			continue
+		}
+		if len(results[0]) == 1 {
+			values[column.Name] = results[0][0].Interface()
+			continue
+		}
+		var items []interface{}
+		for _, result := range results[0] {
+			items = append(items, result.Interface())
+		}
+		values[column.Name] = items
+	}
+	return values
+}
+
+// printerColumnTemplate wraps a CRD column path such as ".status.phase" in the
+// braces the jsonpath package expects.
+func printerColumnTemplate(path string) string {
+	if strings.HasPrefix(path, "{") {
+		return path
+	}
+	return "{" + path + "}"
+}
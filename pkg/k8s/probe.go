@@ -0,0 +1,253 @@
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// DefaultDNSProbeImage is the image used by TestDNS when none is given. It must
+// provide nslookup.
+const DefaultDNSProbeImage = "busybox:1.36"
+
+// probePodLabel marks the short-lived pods created by the probe tools so that any
+// left behind (e.g. if the server is killed mid-probe) are easy to find.
+const probePodLabel = "k8s-mcp-server/probe"
+
+// probeResult is the outcome of a probe pod run.
+type probeResult struct {
+	pod      string
+	output   string
+	exitCode int32
+	duration time.Duration
+}
+
+// runProbePod runs a command in a short-lived pod in the namespace, waits for it to
+// finish within timeout, and returns its output and exit code. The pod is always
+// deleted afterwards.
+func (c *Client) runProbePod(ctx context.Context, namespace, probe, image string, command []string, timeout time.Duration) (*probeResult, error) {
+	deadline := int64(timeout.Seconds()) + 30
+	gracePeriod := int64(0)
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "mcp-" + probe + "-",
+			Namespace:    namespace,
+			Labels:       map[string]string{probePodLabel: probe},
+			Annotations:  map[string]string{DefaultContainerAnnotation: "probe"},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy:                 corev1.RestartPolicyNever,
+			ActiveDeadlineSeconds:         &deadline,
+			TerminationGracePeriodSeconds: &gracePeriod,
+			Containers: []corev1.Container{{
+				Name:    "probe",
+				Image:   image,
+				Command: command,
+			}},
+		},
+	}
+
+	created, err := c.clientset().CoreV1().Pods(namespace).Create(ctx, pod, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create probe pod: %w", err)
+	}
+	defer func() {
+		// Clean up even if the caller's context was cancelled
+		_ = c.clientset().CoreV1().Pods(namespace).Delete(context.Background(), created.Name, metav1.DeleteOptions{GracePeriodSeconds: &gracePeriod})
+	}()
+
+	start := time.Now()
+	var finished *corev1.Pod
+	// Allow time for scheduling and the image pull on top of the probe's own timeout
+	err = wait.PollUntilContextTimeout(ctx, time.Second, timeout+time.Minute, true, func(ctx context.Context) (bool, error) {
+		current, err := c.clientset().CoreV1().Pods(namespace).Get(ctx, created.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		finished = current
+		return current.Status.Phase == corev1.PodSucceeded || current.Status.Phase == corev1.PodFailed, nil
+	})
+	if err != nil {
+		reason := ""
+		if finished != nil {
+			for _, status := range finished.Status.ContainerStatuses {
+				if status.State.Waiting != nil {
+					reason = fmt.Sprintf(" (container waiting: %s %s)", status.State.Waiting.Reason, status.State.Waiting.Message)
+				}
+			}
+		}
+		return nil, fmt.Errorf("probe pod '%s' did not complete%s: %w", created.Name, reason, err)
+	}
+
+	result := &probeResult{pod: created.Name, duration: time.Since(start)}
+	for _, status := range finished.Status.ContainerStatuses {
+		if status.State.Terminated != nil {
+			result.exitCode = status.State.Terminated.ExitCode
+		}
+	}
+
+	logs, err := c.clientset().CoreV1().Pods(namespace).GetLogs(created.Name, &corev1.PodLogOptions{Container: "probe"}).Stream(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read probe pod output: %w", err)
+	}
+	defer logs.Close()
+	buf := new(bytes.Buffer)
+	if _, err := io.Copy(buf, logs); err != nil {
+		return nil, fmt.Errorf("failed to read probe pod output: %w", err)
+	}
+	result.output = buf.String()
+	return result, nil
+}
+
+// TestDNS resolves a hostname through the cluster DNS from a short-lived pod in the
+// given namespace, so that the namespace's search domains and DNS policy apply just as
+// they would for a workload there. The pod is deleted afterwards.
+// Returns a map with whether the name resolved, the addresses found, and the raw
+// nslookup output, or an error if the probe pod could not be run.
+func (c *Client) TestDNS(ctx context.Context, namespace, hostname, image string, timeout time.Duration) (map[string]interface{}, error) {
+	if image == "" {
+		image = DefaultDNSProbeImage
+	}
+	probe, err := c.runProbePod(ctx, namespace, "dns", image, []string{"nslookup", hostname}, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	// nslookup prints the server first, then a Name/Address pair for each answer
+	var addresses []string
+	answers := false
+	for _, line := range strings.Split(probe.output, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "Name:") {
+			answers = true
+			continue
+		}
+		if answers && strings.HasPrefix(line, "Address") {
+			if _, address, ok := strings.Cut(line, ":"); ok {
+				addresses = append(addresses, strings.TrimSpace(address))
+			}
+		}
+	}
+
+	return map[string]interface{}{
+		"hostname":  hostname,
+		"namespace": namespace,
+		"resolved":  probe.exitCode == 0 && len(addresses) > 0,
+		"addresses": addresses,
+		"exitCode":  probe.exitCode,
+		"output":    probe.output,
+		"duration":  probe.duration.Round(time.Millisecond).String(),
+		"probePod":  probe.pod,
+	}, nil
+}
+
+// DefaultConnectivityProbeImage is the image used by TestServiceConnectivity when
+// none is given. It must provide nc for TCP probes and curl for HTTP probes.
+const DefaultConnectivityProbeImage = "curlimages/curl:8.10.1"
+
+// connectivityResultPrefix marks the line curl writes with the probe's measurements.
+const connectivityResultPrefix = "MCP_PROBE_RESULT"
+
+// TestServiceConnectivity attempts a TCP connection (or an HTTP request, when mode is
+// "http") to a Service's ClusterIP and port from a short-lived pod in sourceNamespace,
+// so that network policies apply as they would to a client there. The port may be
+// given by number or name and may be omitted for single-port Services. Headless
+// Services are reached through their DNS name. The pod is deleted afterwards.
+// TCP connections are attempted with nc -z, which closes them as soon as they are
+// established, and HTTP requests are made with curl.
+// Returns a map with whether the connection succeeded, for HTTP its latency and
+// status, and the probe's output, or an error if the probe pod could not be run.
+func (c *Client) TestServiceConnectivity(ctx context.Context, namespace, serviceName, port, mode, path, sourceNamespace, image string, timeout time.Duration) (map[string]interface{}, error) {
+	service, err := c.clientset().CoreV1().Services(namespace).Get(ctx, serviceName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service '%s': %w", serviceName, err)
+	}
+
+	var target *corev1.ServicePort
+	for i, p := range service.Spec.Ports {
+		if (port == "" && len(service.Spec.Ports) == 1) || (port != "" && (p.Name == port || fmt.Sprint(p.Port) == port)) {
+			target = &service.Spec.Ports[i]
+			break
+		}
+	}
+	if target == nil {
+		var available []string
+		for _, p := range service.Spec.Ports {
+			available = append(available, fmt.Sprintf("%s/%d", p.Name, p.Port))
+		}
+		return nil, fmt.Errorf("service '%s' has no port matching '%s' (ports: %s)", serviceName, port, strings.Join(available, ", "))
+	}
+
+	host := service.Spec.ClusterIP
+	if host == "" || host == corev1.ClusterIPNone {
+		host = fmt.Sprintf("%s.%s.svc", service.Name, service.Namespace)
+	}
+	if sourceNamespace == "" {
+		sourceNamespace = namespace
+	}
+	if image == "" {
+		image = DefaultConnectivityProbeImage
+	}
+
+	seconds := fmt.Sprint(int(timeout.Seconds()))
+	var command []string
+	switch mode {
+	case "", "tcp":
+		mode = "tcp"
+		command = []string{"nc", "-z", "-w", seconds, host, fmt.Sprint(target.Port)}
+	case "http", "https":
+		url := fmt.Sprintf("%s://%s:%d/%s", mode, host, target.Port, strings.TrimPrefix(path, "/"))
+		command = []string{"curl", "-sS", "-k", "-o", "/dev/null",
+			"--connect-timeout", seconds, "--max-time", seconds,
+			"-w", "\n" + connectivityResultPrefix + " %{http_code} %{time_connect} %{time_total}\n", url}
+	default:
+		return nil, fmt.Errorf("invalid mode '%s': expected tcp, http, or https", mode)
+	}
+
+	probe, err := c.runProbePod(ctx, sourceNamespace, "connectivity", image, command, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]interface{}{
+		"service":         serviceName,
+		"namespace":       namespace,
+		"sourceNamespace": sourceNamespace,
+		"target":          fmt.Sprintf("%s:%d", host, target.Port),
+		"mode":            mode,
+		"exitCode":        probe.exitCode,
+		"output":          strings.TrimSpace(probe.output),
+		"probePod":        probe.pod,
+	}
+	if mode == "tcp" {
+		// nc -z exits zero only once the connection is established
+		result["connected"] = probe.exitCode == 0
+		result["success"] = probe.exitCode == 0
+		return result, nil
+	}
+
+	// curl reports a zero connect time when no connection was established
+	var httpCode string
+	var connectTime, totalTime float64
+	for _, line := range strings.Split(probe.output, "\n") {
+		if strings.HasPrefix(line, connectivityResultPrefix) {
+			fmt.Sscanf(strings.TrimPrefix(line, connectivityResultPrefix), "%s %f %f", &httpCode, &connectTime, &totalTime)
+		}
+	}
+	connected := connectTime > 0
+	result["connected"] = connected
+	if connected {
+		result["connectLatency"] = time.Duration(connectTime * float64(time.Second)).Round(time.Microsecond).String()
+	}
+	result["success"] = connected && probe.exitCode == 0 && httpCode != "000"
+	result["httpStatus"] = httpCode
+	result["totalLatency"] = time.Duration(totalTime * float64(time.Second)).Round(time.Microsecond).String()
+	return result, nil
+}
@@ -0,0 +1,168 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// podTemplatePaths locates the pod spec and replica count of each workload kind the
+// quota preflight understands. An empty replicas path means one pod.
+var podTemplatePaths = map[string]struct {
+	spec     []string
+	replicas []string
+}{
+	"Pod":         {spec: []string{"spec"}},
+	"Deployment":  {spec: []string{"spec", "template", "spec"}, replicas: []string{"spec", "replicas"}},
+	"StatefulSet": {spec: []string{"spec", "template", "spec"}, replicas: []string{"spec", "replicas"}},
+	"ReplicaSet":  {spec: []string{"spec", "template", "spec"}, replicas: []string{"spec", "replicas"}},
+	"Job":         {spec: []string{"spec", "template", "spec"}, replicas: []string{"spec", "parallelism"}},
+	"CronJob":     {spec: []string{"spec", "jobTemplate", "spec", "template", "spec"}, replicas: []string{"spec", "jobTemplate", "spec", "parallelism"}},
+}
+
+// CheckQuotaHeadroom verifies, before a workload is created, that its pods fit within
+// the remaining ResourceQuota of the namespace. A Pod over quota is rejected with an
+// opaque admission error, and a controller's pods silently fail to be created, so an
+// actionable error up front is more useful than either. The manifest may be JSON or
+// YAML. Only new Pods, Deployments, StatefulSets, ReplicaSets, Jobs, and CronJobs are
+// checked: existing objects are already counted in the quota's usage. The pod's
+// requests and limits, multiplied by its replica count, are compared against each
+// quota's hard limit minus its usage for pods, cpu, memory, and ephemeral storage.
+// Scoped quotas are skipped, as are resources the manifest leaves to LimitRange defaults.
+// Returns nil if the workload fits or cannot be checked, or an error describing every
+// quota it would exceed.
+func (c *Client) CheckQuotaHeadroom(ctx context.Context, namespace, manifest, kind string) error {
+	kind = c.resolveKind(kind)
+	jsonData, err := yaml.YAMLToJSON([]byte(manifest))
+	if err != nil {
+		return nil
+	}
+	obj := &unstructured.Unstructured{}
+	if err := json.Unmarshal(jsonData, &obj.Object); err != nil {
+		return nil
+	}
+	if kind == "" {
+		kind = obj.GetKind()
+	}
+	paths, ok := podTemplatePaths[kind]
+	if !ok || obj.GetName() == "" {
+		return nil
+	}
+	if namespace == "" {
+		namespace = obj.GetNamespace()
+	}
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	quotas, err := c.clientset().CoreV1().ResourceQuotas(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil || len(quotas.Items) == 0 {
+		return nil
+	}
+	if _, err := c.getObject(ctx, kind, obj.GetName(), namespace); !errors.IsNotFound(err) {
+		return nil
+	}
+
+	rawSpec, found, err := unstructured.NestedMap(obj.Object, paths.spec...)
+	if err != nil || !found {
+		return nil
+	}
+	spec := &corev1.PodSpec{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(rawSpec, spec); err != nil {
+		return nil
+	}
+	replicas := int64(1)
+	if paths.replicas != nil {
+		// The manifest was decoded by encoding/json, which reads every number as a float64
+		if value, found, _ := unstructured.NestedFloat64(obj.Object, paths.replicas...); found {
+			replicas = int64(value)
+		}
+	}
+	if replicas == 0 {
+		return nil
+	}
+
+	requested := workloadQuotaUsage(spec, replicas)
+	var violations []string
+	for _, quota := range quotas.Items {
+		if len(quota.Spec.Scopes) > 0 || quota.Spec.ScopeSelector != nil {
+			continue
+		}
+		for name, hard := range quota.Status.Hard {
+			want, ok := requested[name]
+			if !ok {
+				continue
+			}
+			used := quota.Status.Used[name]
+			remaining := hard.DeepCopy()
+			remaining.Sub(used)
+			if want.Cmp(remaining) > 0 {
+				if remaining.Sign() < 0 {
+					remaining = resource.Quantity{}
+				}
+				violations = append(violations, fmt.Sprintf("%s: %s needs %s but only %s of %s remains (%s used)",
+					quota.Name, name, want.String(), remaining.String(), hard.String(), used.String()))
+			}
+		}
+	}
+	if len(violations) > 0 {
+		return fmt.Errorf("%s '%s' (%d pod(s)) would exceed the resource quota in namespace '%s': %s",
+			kind, obj.GetName(), replicas, namespace, strings.Join(violations, "; "))
+	}
+	return nil
+}
+
+// workloadQuotaUsage computes what replicas pods with the given spec count against a
+// ResourceQuota. As the scheduler does, a pod's effective request for a resource is the
+// larger of the sum over its containers and the largest single init container.
+func workloadQuotaUsage(spec *corev1.PodSpec, replicas int64) corev1.ResourceList {
+	effective := func(get func(corev1.ResourceRequirements) corev1.ResourceList, name corev1.ResourceName) (resource.Quantity, bool) {
+		total := resource.Quantity{}
+		set := false
+		for _, container := range spec.Containers {
+			if q, ok := get(container.Resources)[name]; ok {
+				total.Add(q)
+				set = true
+			}
+		}
+		for _, container := range spec.InitContainers {
+			if q, ok := get(container.Resources)[name]; ok {
+				set = true
+				if q.Cmp(total) > 0 {
+					total = q.DeepCopy()
+				}
+			}
+		}
+		return total, set
+	}
+	requests := func(r corev1.ResourceRequirements) corev1.ResourceList { return r.Requests }
+	limits := func(r corev1.ResourceRequirements) corev1.ResourceList { return r.Limits }
+
+	usage := corev1.ResourceList{
+		corev1.ResourcePods: *resource.NewQuantity(replicas, resource.DecimalSI),
+	}
+	add := func(quotaNames []corev1.ResourceName, perPod resource.Quantity) {
+		total := resource.NewMilliQuantity(perPod.MilliValue()*replicas, perPod.Format)
+		for _, name := range quotaNames {
+			usage[name] = *total
+		}
+	}
+	for _, name := range []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory, corev1.ResourceEphemeralStorage} {
+		if q, ok := effective(requests, name); ok {
+			add([]corev1.ResourceName{name, corev1.ResourceName("requests." + string(name))}, q)
+		}
+		if q, ok := effective(limits, name); ok {
+			add([]corev1.ResourceName{corev1.ResourceName("limits." + string(name))}, q)
+		}
+	}
+	return usage
+}
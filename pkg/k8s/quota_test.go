@@ -0,0 +1,88 @@
+package k8s_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/reza-gholizade/k8s-mcp-server/pkg/k8s/k8stest"
+)
+
+// quotaManifest returns a Deployment manifest whose pods request cpu in a container
+// and, if initCPU is set, in an init container.
+func quotaManifest(name string, replicas int, cpu, initCPU string) string {
+	manifest := fmt.Sprintf(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: %s
+spec:
+  replicas: %d
+  template:
+    spec:
+      containers:
+      - name: app
+        image: nginx
+        resources:
+          requests:
+            cpu: %s
+            memory: 256Mi
+`, name, replicas, cpu)
+	if initCPU != "" {
+		manifest += fmt.Sprintf(`      initContainers:
+      - name: init
+        image: busybox
+        resources:
+          requests:
+            cpu: %s
+`, initCPU)
+	}
+	return manifest
+}
+
+func TestCheckQuotaHeadroom(t *testing.T) {
+	quota := func(name string, scoped bool) *corev1.ResourceQuota {
+		q := &corev1.ResourceQuota{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ResourceQuota"},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: k8stest.Namespace},
+			Status: corev1.ResourceQuotaStatus{
+				Hard: corev1.ResourceList{"requests.cpu": resource.MustParse("2"), "pods": resource.MustParse("10")},
+				Used: corev1.ResourceList{"requests.cpu": resource.MustParse("1"), "pods": resource.MustParse("2")},
+			},
+		}
+		if scoped {
+			q.Spec.Scopes = []corev1.ResourceQuotaScope{corev1.ResourceQuotaScopeBestEffort}
+		}
+		return q
+	}
+	tests := []struct {
+		name     string
+		quota    *corev1.ResourceQuota
+		manifest string
+		wantErr  string
+	}{
+		{name: "fits", quota: quota("compute", false), manifest: quotaManifest("api", 2, "500m", "")},
+		{name: "exceeds", quota: quota("compute", false), manifest: quotaManifest("api", 3, "500m", ""), wantErr: "compute: requests.cpu needs 1500m but only 1 of 2 remains (1 used)"},
+		// The largest init container counts when it requests more than the containers together
+		{name: "init container", quota: quota("compute", false), manifest: quotaManifest("api", 1, "500m", "1500m"), wantErr: "requests.cpu needs 1500m"},
+		// An existing workload is already counted in the quota's usage
+		{name: "existing workload", quota: quota("compute", false), manifest: quotaManifest(k8stest.DeploymentName, 3, "500m", "")},
+		{name: "scoped quota", quota: quota("best-effort", true), manifest: quotaManifest("api", 3, "500m", "")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := k8stest.NewFakeClient(append(k8stest.Objects(), tt.quota)...)
+			err := client.CheckQuotaHeadroom(context.Background(), k8stest.Namespace, tt.manifest, "")
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("expected the workload to fit, got %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("got error %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
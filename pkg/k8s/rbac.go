@@ -0,0 +1,174 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RBACSubject filters GetRBAC to the bindings granting access to one subject.
+// For a ServiceAccount, Namespace is the account's namespace.
+type RBACSubject struct {
+	Kind      string
+	Name      string
+	Namespace string
+}
+
+// GetRBAC joins role bindings with the roles they grant. For a namespace, it returns
+// the RoleBindings in that namespace together with the ClusterRoleBindings, which
+// grant access in every namespace; for an empty namespace, only ClusterRoleBindings.
+// Each binding lists its subjects and the resolved rules of its role. When subject is
+// given, only bindings that apply to it are returned; for a ServiceAccount this
+// includes bindings to the groups every service account belongs to.
+// Returns a map with the bindings and a per-subject summary of granted roles, or an error.
+func (c *Client) GetRBAC(ctx context.Context, namespace string, subject *RBACSubject) (map[string]interface{}, error) {
+	clusterRoles, err := c.clientset().RbacV1().ClusterRoles().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cluster roles: %w", err)
+	}
+	clusterRoleRules := map[string][]rbacv1.PolicyRule{}
+	for _, role := range clusterRoles.Items {
+		clusterRoleRules[role.Name] = role.Rules
+	}
+
+	roleRules := map[string][]rbacv1.PolicyRule{}
+	var bindings []map[string]interface{}
+	summary := map[string][]string{}
+
+	addBinding := func(bindingKind, name, bindingNamespace string, roleRef rbacv1.RoleRef, subjects []rbacv1.Subject) {
+		if subject != nil && !bindingAppliesTo(subjects, bindingNamespace, subject) {
+			return
+		}
+		rules, found := clusterRoleRules[roleRef.Name]
+		if roleRef.Kind == "Role" {
+			rules, found = roleRules[roleRef.Name]
+		}
+
+		scope := "cluster"
+		if bindingNamespace != "" {
+			scope = "namespace " + bindingNamespace
+		}
+		entry := map[string]interface{}{
+			"binding":     name,
+			"bindingKind": bindingKind,
+			"scope":       scope,
+			"role":        roleRef.Kind + "/" + roleRef.Name,
+			"subjects":    formatSubjects(subjects),
+			"rules":       formatRules(rules),
+		}
+		if !found {
+			entry["roleMissing"] = true
+		}
+		bindings = append(bindings, entry)
+
+		for _, s := range formatSubjects(subjects) {
+			summary[s] = append(summary[s], fmt.Sprintf("%s/%s (%s)", roleRef.Kind, roleRef.Name, scope))
+		}
+	}
+
+	if namespace != "" {
+		roles, err := c.clientset().RbacV1().Roles(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list roles: %w", err)
+		}
+		for _, role := range roles.Items {
+			roleRules[role.Name] = role.Rules
+		}
+
+		roleBindings, err := c.clientset().RbacV1().RoleBindings(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list role bindings: %w", err)
+		}
+		for _, binding := range roleBindings.Items {
+			addBinding("RoleBinding", binding.Name, namespace, binding.RoleRef, binding.Subjects)
+		}
+	}
+
+	clusterRoleBindings, err := c.clientset().RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cluster role bindings: %w", err)
+	}
+	for _, binding := range clusterRoleBindings.Items {
+		addBinding("ClusterRoleBinding", binding.Name, "", binding.RoleRef, binding.Subjects)
+	}
+
+	return map[string]interface{}{
+		"namespace": namespace,
+		"bindings":  bindings,
+		"subjects":  summary,
+	}, nil
+}
+
+// bindingAppliesTo reports whether any of a binding's subjects matches the filter.
+// Subjects without a namespace in a RoleBinding default to the binding's namespace.
+func bindingAppliesTo(subjects []rbacv1.Subject, bindingNamespace string, filter *RBACSubject) bool {
+	for _, s := range subjects {
+		switch {
+		case s.Kind == filter.Kind && s.Name == filter.Name:
+			if s.Kind != rbacv1.ServiceAccountKind {
+				return true
+			}
+			saNamespace := s.Namespace
+			if saNamespace == "" {
+				saNamespace = bindingNamespace
+			}
+			if filter.Namespace == "" || saNamespace == filter.Namespace {
+				return true
+			}
+		case filter.Kind == rbacv1.ServiceAccountKind && s.Kind == rbacv1.GroupKind:
+			// Service accounts are implicitly members of these groups
+			if s.Name == "system:serviceaccounts" || s.Name == "system:authenticated" ||
+				(filter.Namespace != "" && s.Name == "system:serviceaccounts:"+filter.Namespace) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// formatSubjects renders subjects as "Kind:name", with "namespace/name" for service accounts.
+func formatSubjects(subjects []rbacv1.Subject) []string {
+	formatted := []string{}
+	for _, s := range subjects {
+		name := s.Name
+		if s.Kind == rbacv1.ServiceAccountKind && s.Namespace != "" {
+			name = s.Namespace + "/" + s.Name
+		}
+		formatted = append(formatted, s.Kind+":"+name)
+	}
+	sort.Strings(formatted)
+	return formatted
+}
+
+// formatRules renders policy rules compactly, one map per rule.
+func formatRules(rules []rbacv1.PolicyRule) []map[string]interface{} {
+	formatted := []map[string]interface{}{}
+	for _, rule := range rules {
+		entry := map[string]interface{}{
+			"verbs": strings.Join(rule.Verbs, ","),
+		}
+		if len(rule.Resources) > 0 {
+			groups := make([]string, len(rule.APIGroups))
+			for i, group := range rule.APIGroups {
+				if group == "" {
+					group = "core"
+				}
+				groups[i] = group
+			}
+			entry["apiGroups"] = strings.Join(groups, ",")
+			entry["resources"] = strings.Join(rule.Resources, ",")
+		}
+		if len(rule.ResourceNames) > 0 {
+			entry["resourceNames"] = strings.Join(rule.ResourceNames, ",")
+		}
+		if len(rule.NonResourceURLs) > 0 {
+			entry["nonResourceURLs"] = strings.Join(rule.NonResourceURLs, ",")
+		}
+		formatted = append(formatted, entry)
+	}
+	return formatted
+}
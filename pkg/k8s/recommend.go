@@ -0,0 +1,193 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Right-sizing parameters used by RecommendResources.
+const (
+	// requestHeadroom is the margin added to observed peak usage for a request.
+	requestHeadroom = 1.2
+	// memoryLimitHeadroom is the margin added to observed peak memory for a limit.
+	memoryLimitHeadroom = 1.5
+	// overProvisionedRatio is how many times the recommendation a request must be
+	// before it is flagged as too high.
+	overProvisionedRatio = 2.0
+	// minCPURequestMilli and minMemoryRequestBytes are floors for recommended requests.
+	minCPURequestMilli    = 10
+	minMemoryRequestBytes = 16 * 1024 * 1024
+)
+
+// containerUsage accumulates metrics for one container across a workload's pods.
+type containerUsage struct {
+	samples   int
+	cpuMax    int64
+	cpuSum    int64
+	memoryMax int64
+	memorySum int64
+}
+
+// RecommendResources compares the requests and limits configured for each container of
+// a workload with the current usage reported by the metrics API across its pods, and
+// suggests adjusted values: requests of peak usage plus 20% headroom, and memory limits
+// of peak usage plus 50%. Containers are flagged when a request is more than twice the
+// recommendation, when usage exceeds the request, when requests are missing, or when
+// usage is close to a limit. Metrics are a point-in-time sample, so recommendations are
+// most meaningful for a workload under representative load; containers that were
+// OOMKilled are flagged because their peak memory is not visible in the sample.
+// Returns a map with a recommendation per container, or an error.
+func (c *Client) RecommendResources(ctx context.Context, kind, name, namespace string) (map[string]interface{}, error) {
+	kind = c.resolveKind(kind)
+	pods, err := c.workloadPods(ctx, kind, name, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if len(pods) == 0 {
+		return nil, fmt.Errorf("%s '%s' has no pods", kind, name)
+	}
+	// The newest pod carries the current configuration
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[i].CreationTimestamp.After(pods[j].CreationTimestamp.Time)
+	})
+	configured := map[string]corev1.ResourceRequirements{}
+	var containerNames []string
+	for _, container := range pods[0].Spec.Containers {
+		configured[container.Name] = container.Resources
+		containerNames = append(containerNames, container.Name)
+	}
+
+	usage := map[string]*containerUsage{}
+	oomKilled := map[string]bool{}
+	var errs []string
+	sampled := 0
+	for _, pod := range pods {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.LastTerminationState.Terminated != nil && status.LastTerminationState.Terminated.Reason == "OOMKilled" {
+				oomKilled[status.Name] = true
+			}
+		}
+		if pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		metrics, err := c.metricsClientset().MetricsV1beta1().PodMetricses(namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("failed to get metrics for pod '%s': %v", pod.Name, err))
+			continue
+		}
+		sampled++
+		for _, container := range metrics.Containers {
+			u, ok := usage[container.Name]
+			if !ok {
+				u = &containerUsage{}
+				usage[container.Name] = u
+			}
+			cpu := container.Usage.Cpu().MilliValue()
+			memory := container.Usage.Memory().Value()
+			u.samples++
+			u.cpuSum += cpu
+			u.memorySum += memory
+			u.cpuMax = max(u.cpuMax, cpu)
+			u.memoryMax = max(u.memoryMax, memory)
+		}
+	}
+	if sampled == 0 {
+		return nil, fmt.Errorf("no metrics available for the pods of %s '%s': %v", kind, name, errs)
+	}
+
+	containers := []map[string]interface{}{}
+	for _, containerName := range containerNames {
+		u, ok := usage[containerName]
+		if !ok {
+			continue
+		}
+		resources := configured[containerName]
+		recCPU := max(int64(float64(u.cpuMax)*requestHeadroom), minCPURequestMilli)
+		recMemory := max(int64(float64(u.memoryMax)*requestHeadroom), minMemoryRequestBytes)
+		recMemoryLimit := max(int64(float64(u.memoryMax)*memoryLimitHeadroom), recMemory)
+
+		var findings []string
+		cpuRequest, hasCPURequest := resources.Requests[corev1.ResourceCPU]
+		memoryRequest, hasMemoryRequest := resources.Requests[corev1.ResourceMemory]
+		if !hasCPURequest {
+			findings = append(findings, "no CPU request set: the container is scheduled as if it needs no CPU")
+		} else if float64(cpuRequest.MilliValue()) > overProvisionedRatio*float64(recCPU) {
+			findings = append(findings, fmt.Sprintf("CPU request %s is well above peak usage %dm", cpuRequest.String(), u.cpuMax))
+		} else if u.cpuMax > cpuRequest.MilliValue() {
+			findings = append(findings, fmt.Sprintf("CPU usage %dm exceeds the request %s", u.cpuMax, cpuRequest.String()))
+		}
+		if !hasMemoryRequest {
+			findings = append(findings, "no memory request set: the container is first in line for eviction under memory pressure")
+		} else if float64(memoryRequest.Value()) > overProvisionedRatio*float64(recMemory) {
+			findings = append(findings, fmt.Sprintf("memory request %s is well above peak usage %s", memoryRequest.String(), formatBytes(u.memoryMax)))
+		} else if u.memoryMax > memoryRequest.Value() {
+			findings = append(findings, fmt.Sprintf("memory usage %s exceeds the request %s", formatBytes(u.memoryMax), memoryRequest.String()))
+		}
+		if cpuLimit, ok := resources.Limits[corev1.ResourceCPU]; ok && float64(u.cpuMax) > 0.9*float64(cpuLimit.MilliValue()) {
+			findings = append(findings, fmt.Sprintf("CPU usage %dm is close to the limit %s and is likely being throttled", u.cpuMax, cpuLimit.String()))
+		}
+		if memoryLimit, ok := resources.Limits[corev1.ResourceMemory]; ok && float64(u.memoryMax) > 0.9*float64(memoryLimit.Value()) {
+			findings = append(findings, fmt.Sprintf("memory usage %s is close to the limit %s", formatBytes(u.memoryMax), memoryLimit.String()))
+		}
+		if oomKilled[containerName] {
+			findings = append(findings, "the container was OOMKilled; its real peak memory is higher than observed, so raise the memory limit beyond the recommendation")
+		}
+
+		containers = append(containers, map[string]interface{}{
+			"name": containerName,
+			"current": map[string]interface{}{
+				"requests": resourceListStrings(resources.Requests),
+				"limits":   resourceListStrings(resources.Limits),
+			},
+			"usage": map[string]interface{}{
+				"cpuPeak":    fmt.Sprintf("%dm", u.cpuMax),
+				"cpuAvg":     fmt.Sprintf("%dm", u.cpuSum/int64(u.samples)),
+				"memoryPeak": formatBytes(u.memoryMax),
+				"memoryAvg":  formatBytes(u.memorySum / int64(u.samples)),
+				"samples":    u.samples,
+			},
+			"recommended": map[string]interface{}{
+				"requests": map[string]string{
+					"cpu":    resource.NewMilliQuantity(recCPU, resource.DecimalSI).String(),
+					"memory": formatBytes(recMemory),
+				},
+				"limits": map[string]string{
+					"memory": formatBytes(recMemoryLimit),
+				},
+			},
+			"findings": findings,
+		})
+	}
+
+	result := map[string]interface{}{
+		"kind":        kind,
+		"name":        name,
+		"namespace":   namespace,
+		"podsSampled": sampled,
+		"containers":  containers,
+	}
+	if len(errs) > 0 {
+		result["errors"] = errs
+	}
+	return result, nil
+}
+
+// resourceListStrings renders a resource list as name to quantity strings.
+func resourceListStrings(list corev1.ResourceList) map[string]string {
+	out := map[string]string{}
+	for name, quantity := range list {
+		out[string(name)] = quantity.String()
+	}
+	return out
+}
+
+// formatBytes renders a byte count as a binary quantity rounded to whole MiB, e.g. "256Mi".
+func formatBytes(bytes int64) string {
+	const mi = 1024 * 1024
+	return resource.NewQuantity((bytes+mi-1)/mi*mi, resource.BinarySI).String()
+}
@@ -0,0 +1,117 @@
+package k8s
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/homedir"
+)
+
+// kubeconfigPollInterval is how often WatchKubeconfig checks the kubeconfig file.
+const kubeconfigPollInterval = 5 * time.Second
+
+// ResolveKubeconfigPath returns the kubeconfig file the kubeconfig-file
+// authentication method reads: the given path, or KUBECONFIG, or ~/.kube/config.
+func ResolveKubeconfigPath(kubeconfigPath string) string {
+	if kubeconfigPath != "" {
+		return kubeconfigPath
+	}
+	if kubeconfigEnv := os.Getenv("KUBECONFIG"); kubeconfigEnv != "" {
+		return kubeconfigEnv
+	}
+	if home := homedir.HomeDir(); home != "" {
+		return filepath.Join(home, ".kube", "config")
+	}
+	return ""
+}
+
+// Reload rebuilds the client's connections from the current configuration, e.g. after
+// the kubeconfig file was rewritten with rotated credentials or a new current context.
+// The API resource caches are cleared and the discovery client and REST mapper are
+// replaced, since the new context may point at a different cluster; callers sharing
+// them must fetch them again. Calls already in progress may finish with either the
+// old or the new connections. The client is left unchanged if the new configuration
+// cannot be loaded.
+func (c *Client) Reload(kubeconfigPath string, method AuthMethod) error {
+	apply, err := c.PrepareReload(kubeconfigPath, method)
+	if err != nil {
+		return err
+	}
+	apply()
+	return nil
+}
+
+// PrepareReload builds the connections Reload would switch to and returns a function
+// that switches to them, without changing the client until it is called. It lets
+// callers reloading several clients switch them only once all have loaded, so that
+// they never point at different clusters.
+func (c *Client) PrepareReload(kubeconfigPath string, method AuthMethod) (func(), error) {
+	reloaded, err := NewClient(kubeconfigPath, method)
+	if err != nil {
+		return nil, err
+	}
+	return func() {
+		c.cacheLock.Lock()
+		defer c.cacheLock.Unlock()
+		c.conns.Store(reloaded.conns.Load())
+		c.apiResourceCache = make(map[string]*schema.GroupVersionResource)
+		c.namespacedCache = make(map[string]bool)
+		c.kindCache = make(map[string]string)
+	}, nil
+}
+
+// WatchKubeconfig calls onChange whenever a kubeconfig file is modified, replaced, or
+// recreated, until ctx is cancelled. path may list several files separated as in
+// KUBECONFIG, in which case each of them is watched. The files are polled rather than
+// watched with inotify, so that replacements through a symlink swap, as done for
+// mounted Secrets, are seen too. A file that is missing is reported once it appears.
+func WatchKubeconfig(ctx context.Context, path string, onChange func()) {
+	type fileState struct {
+		modified time.Time
+		size     int64
+	}
+	var paths []string
+	for _, file := range filepath.SplitList(path) {
+		if file != "" {
+			paths = append(paths, file)
+		}
+	}
+	states := make(map[string]fileState, len(paths))
+	for _, file := range paths {
+		if info, err := os.Stat(file); err == nil {
+			states[file] = fileState{info.ModTime(), info.Size()}
+		}
+	}
+
+	ticker := time.NewTicker(kubeconfigPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		changed := false
+		for _, file := range paths {
+			info, err := os.Stat(file)
+			if err != nil {
+				// Keep the last state, so that a file being replaced is reloaded once it is back
+				continue
+			}
+			state := fileState{info.ModTime(), info.Size()}
+			if last, ok := states[file]; ok && last.modified.Equal(state.modified) && last.size == state.size {
+				continue
+			}
+			states[file] = state
+			changed = true
+		}
+		// Files changed together, e.g. by one rotation, are reloaded once
+		if changed {
+			onChange()
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// deploymentRevisionAnnotation records the rollout revision of a Deployment and of
+// each of its ReplicaSets.
+const deploymentRevisionAnnotation = "deployment.kubernetes.io/revision"
+
+// RollbackImage sets the images of a Deployment's containers back to those of an
+// earlier revision, leaving the rest of the pod template as it is; unlike a full
+// rollout undo, changes made since to environment variables, resources, or other
+// fields are kept. The revision is toRevision, or if it is 0 the most recent earlier
+// revision, read from the Deployment's ReplicaSets, whose images differ from the
+// current ones. With container set, only that container's image is considered and
+// changed. The images are patched by container name, which starts a new rollout.
+// When dryRun is true nothing is changed and the images that would be set are reported.
+// Returns a map with the revisions and the image changes, or an error if no suitable
+// revision exists.
+func (c *Client) RollbackImage(ctx context.Context, namespace, name, container string, toRevision int64, dryRun bool) (map[string]interface{}, error) {
+	deployment, err := c.clientset().AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployment '%s': %w", name, err)
+	}
+	current := templateImages(&deployment.Spec.Template)
+	if container != "" {
+		if _, ok := current[container]; !ok {
+			return nil, fmt.Errorf("deployment '%s' has no container '%s'", name, container)
+		}
+	}
+	currentRevision, _ := strconv.ParseInt(deployment.Annotations[deploymentRevisionAnnotation], 10, 64)
+
+	replicaSets, err := c.ownedReplicaSets(ctx, deployment)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(replicaSets, func(i, j int) bool {
+		return replicaSetRevision(&replicaSets[i]) > replicaSetRevision(&replicaSets[j])
+	})
+
+	// Image changes that setting the images of a revision would make
+	changesFor := func(rs *appsv1.ReplicaSet) []map[string]interface{} {
+		var changes []map[string]interface{}
+		previous := templateImages(&rs.Spec.Template)
+		for _, spec := range podTemplateContainers(&deployment.Spec.Template) {
+			if container != "" && spec.Name != container {
+				continue
+			}
+			image, ok := previous[spec.Name]
+			if !ok || image == spec.Image {
+				continue
+			}
+			changes = append(changes, map[string]interface{}{
+				"container": spec.Name,
+				"init":      spec.init,
+				"from":      spec.Image,
+				"to":        image,
+			})
+		}
+		return changes
+	}
+
+	var target *appsv1.ReplicaSet
+	var changes []map[string]interface{}
+	var examined []int64
+	for i := range replicaSets {
+		rs := &replicaSets[i]
+		revision := replicaSetRevision(rs)
+		if toRevision > 0 {
+			if revision != toRevision {
+				continue
+			}
+			target, changes = rs, changesFor(rs)
+			if len(changes) == 0 {
+				return nil, fmt.Errorf("revision %d of deployment '%s' runs the same images as the current one", toRevision, name)
+			}
+			break
+		}
+		if revision == 0 || (currentRevision > 0 && revision >= currentRevision) {
+			continue
+		}
+		examined = append(examined, revision)
+		if rsChanges := changesFor(rs); len(rsChanges) > 0 {
+			target, changes = rs, rsChanges
+			break
+		}
+	}
+	if target == nil {
+		if toRevision > 0 {
+			return nil, fmt.Errorf("revision %d of deployment '%s' not found; its ReplicaSet may have been removed by revisionHistoryLimit", toRevision, name)
+		}
+		return nil, fmt.Errorf("no earlier revision of deployment '%s' runs a different image (revisions examined: %v)", name, examined)
+	}
+
+	result := map[string]interface{}{
+		"deployment":   name,
+		"namespace":    namespace,
+		"fromRevision": currentRevision,
+		"toRevision":   replicaSetRevision(target),
+		"replicaSet":   target.Name,
+		"changes":      changes,
+		"patched":      false,
+	}
+	if dryRun {
+		return result, nil
+	}
+
+	// A strategic merge patch merges containers by name, so only the images change
+	var containers, initContainers []map[string]interface{}
+	for _, change := range changes {
+		entry := map[string]interface{}{"name": change["container"], "image": change["to"]}
+		if change["init"].(bool) {
+			initContainers = append(initContainers, entry)
+		} else {
+			containers = append(containers, entry)
+		}
+	}
+	podSpec := map[string]interface{}{}
+	if len(containers) > 0 {
+		podSpec["containers"] = containers
+	}
+	if len(initContainers) > 0 {
+		podSpec["initContainers"] = initContainers
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{"template": map[string]interface{}{"spec": podSpec}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build patch: %w", err)
+	}
+	if _, err := c.clientset().AppsV1().Deployments(namespace).Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{}); err != nil {
+		return nil, fmt.Errorf("failed to patch deployment '%s': %w", name, err)
+	}
+	result["patched"] = true
+	return result, nil
+}
+
+// templateContainer is a container of a pod template, and whether it is an init container.
+type templateContainer struct {
+	corev1.Container
+	init bool
+}
+
+// podTemplateContainers returns the init containers and containers of a pod template.
+func podTemplateContainers(template *corev1.PodTemplateSpec) []templateContainer {
+	var containers []templateContainer
+	for _, container := range template.Spec.InitContainers {
+		containers = append(containers, templateContainer{Container: container, init: true})
+	}
+	for _, container := range template.Spec.Containers {
+		containers = append(containers, templateContainer{Container: container})
+	}
+	return containers
+}
+
+// templateImages returns the image of each container of a pod template, by name.
+func templateImages(template *corev1.PodTemplateSpec) map[string]string {
+	images := map[string]string{}
+	for _, container := range podTemplateContainers(template) {
+		images[container.Name] = container.Image
+	}
+	return images
+}
+
+// replicaSetRevision returns the rollout revision of a Deployment's ReplicaSet, or 0
+// if it has none.
+func replicaSetRevision(rs *appsv1.ReplicaSet) int64 {
+	revision, _ := strconv.ParseInt(rs.Annotations[deploymentRevisionAnnotation], 10, 64)
+	return revision
+}
@@ -0,0 +1,106 @@
+package k8s_test
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/reza-gholizade/k8s-mcp-server/pkg/k8s/k8stest"
+)
+
+// rolledOutObjects returns the seeded objects after a second rollout that changed the
+// image to nginx:1.28 and an environment variable: the Deployment is at revision 2 and
+// the seeded ReplicaSet, still running nginx:1.27, is revision 1.
+func rolledOutObjects(t *testing.T) []runtime.Object {
+	objects := k8stest.Objects()
+	deployment := seeded[*appsv1.Deployment](t, objects)
+	oldReplicaSet := seeded[*appsv1.ReplicaSet](t, objects)
+
+	deployment.Annotations["deployment.kubernetes.io/revision"] = "2"
+	container := &deployment.Spec.Template.Spec.Containers[0]
+	container.Image = "nginx:1.28"
+	container.Env = append(container.Env, corev1.EnvVar{Name: "MODE", Value: "fast"})
+
+	newReplicaSet := oldReplicaSet.DeepCopy()
+	newReplicaSet.Name = k8stest.DeploymentName + "-7c9d6b5f4"
+	newReplicaSet.UID = "fake-replicaset-2-uid"
+	newReplicaSet.Annotations = map[string]string{"deployment.kubernetes.io/revision": "2"}
+	newReplicaSet.CreationTimestamp = metav1.NewTime(oldReplicaSet.CreationTimestamp.Add(1))
+	newReplicaSet.Spec.Template.Spec = *deployment.Spec.Template.Spec.DeepCopy()
+	return append(objects, newReplicaSet)
+}
+
+func TestRollbackImage(t *testing.T) {
+	fakes := k8stest.NewFakes(rolledOutObjects(t)...)
+	client := fakes.Client()
+	ctx := context.Background()
+
+	// A dry run reports the change without patching
+	result, err := client.RollbackImage(ctx, k8stest.Namespace, k8stest.DeploymentName, "", 0, true)
+	if err != nil {
+		t.Fatalf("RollbackImage: %v", err)
+	}
+	if result["fromRevision"] != int64(2) || result["toRevision"] != int64(1) || result["replicaSet"] != k8stest.ReplicaSetName || result["patched"] != false {
+		t.Errorf("unexpected dry run result %v", result)
+	}
+	changes := result["changes"].([]map[string]interface{})
+	if len(changes) != 1 || changes[0]["container"] != "web" || changes[0]["from"] != "nginx:1.28" || changes[0]["to"] != "nginx:1.27" {
+		t.Errorf("unexpected changes %v", changes)
+	}
+	if image := deploymentContainer(t, fakes).Image; image != "nginx:1.28" {
+		t.Fatalf("dry run changed the image to %s", image)
+	}
+
+	result, err = client.RollbackImage(ctx, k8stest.Namespace, k8stest.DeploymentName, "web", 1, false)
+	if err != nil {
+		t.Fatalf("RollbackImage: %v", err)
+	}
+	if result["patched"] != true {
+		t.Errorf("expected the deployment to be patched, got %v", result)
+	}
+	// Only the image is rolled back; later changes to the template are kept
+	container := deploymentContainer(t, fakes)
+	if container.Image != "nginx:1.27" {
+		t.Errorf("image: got %s, want nginx:1.27", container.Image)
+	}
+	if len(container.Env) != 1 || container.Env[0].Name != "MODE" {
+		t.Errorf("env: got %v, want MODE to be kept", container.Env)
+	}
+}
+
+func TestRollbackImageErrors(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name       string
+		objects    []runtime.Object
+		container  string
+		toRevision int64
+	}{
+		{name: "unknown container", objects: rolledOutObjects(t), container: "sidecar"},
+		{name: "revision removed", objects: rolledOutObjects(t), toRevision: 5},
+		{name: "same image", objects: rolledOutObjects(t), toRevision: 2},
+		{name: "no earlier revision", objects: k8stest.Objects()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := k8stest.NewFakeClient(tt.objects...)
+			if _, err := client.RollbackImage(ctx, k8stest.Namespace, k8stest.DeploymentName, tt.container, tt.toRevision, true); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+// deploymentContainer returns the container of the seeded Deployment as stored by the fakes.
+func deploymentContainer(t *testing.T, fakes *k8stest.Fakes) corev1.Container {
+	t.Helper()
+	deployment, err := fakes.Clientset.AppsV1().Deployments(k8stest.Namespace).Get(context.Background(), k8stest.DeploymentName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get deployment: %v", err)
+	}
+	return deployment.Spec.Template.Spec.Containers[0]
+}
@@ -0,0 +1,476 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// rolloutPollInterval is how often WatchRollout re-evaluates the rollout status
+// between events.
+const rolloutPollInterval = 2 * time.Second
+
+// maxRolloutTimeline bounds how many entries WatchRollout keeps in its result.
+const maxRolloutTimeline = 200
+
+// RolloutStatus is the state of a workload rollout as reported by `kubectl rollout status`.
+type RolloutStatus struct {
+	Done    bool
+	Failed  bool
+	Message string
+}
+
+// GetRolloutStatus evaluates the rollout of a Deployment, StatefulSet, or DaemonSet
+// using the same rules as `kubectl rollout status`.
+// Returns the current status, or an error.
+func (c *Client) GetRolloutStatus(ctx context.Context, kind, name, namespace string) (RolloutStatus, error) {
+	kind = c.resolveKind(kind)
+	switch kind {
+	case "Deployment":
+		deployment, err := c.clientset().AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return RolloutStatus{}, fmt.Errorf("failed to get deployment '%s': %w", name, err)
+		}
+		return deploymentRolloutStatus(deployment), nil
+	case "StatefulSet":
+		statefulSet, err := c.clientset().AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return RolloutStatus{}, fmt.Errorf("failed to get statefulset '%s': %w", name, err)
+		}
+		return statefulSetRolloutStatus(statefulSet), nil
+	case "DaemonSet":
+		daemonSet, err := c.clientset().AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return RolloutStatus{}, fmt.Errorf("failed to get daemonset '%s': %w", name, err)
+		}
+		return daemonSetRolloutStatus(daemonSet), nil
+	}
+	return RolloutStatus{}, fmt.Errorf("rollout status is not supported for kind '%s': expected Deployment, StatefulSet, or DaemonSet", kind)
+}
+
+func deploymentRolloutStatus(d *appsv1.Deployment) RolloutStatus {
+	if d.Generation > d.Status.ObservedGeneration {
+		return RolloutStatus{Message: "waiting for deployment spec update to be observed"}
+	}
+	for _, condition := range d.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing && condition.Reason == "ProgressDeadlineExceeded" {
+			return RolloutStatus{Failed: true, Message: fmt.Sprintf("deployment %q exceeded its progress deadline", d.Name)}
+		}
+	}
+	replicas := int32(1)
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+	switch {
+	case d.Status.UpdatedReplicas < replicas:
+		return RolloutStatus{Message: fmt.Sprintf("%d out of %d new replicas have been updated", d.Status.UpdatedReplicas, replicas)}
+	case d.Status.Replicas > d.Status.UpdatedReplicas:
+		return RolloutStatus{Message: fmt.Sprintf("%d old replicas are pending termination", d.Status.Replicas-d.Status.UpdatedReplicas)}
+	case d.Status.AvailableReplicas < d.Status.UpdatedReplicas:
+		return RolloutStatus{Message: fmt.Sprintf("%d of %d updated replicas are available", d.Status.AvailableReplicas, d.Status.UpdatedReplicas)}
+	}
+	return RolloutStatus{Done: true, Message: fmt.Sprintf("deployment %q successfully rolled out", d.Name)}
+}
+
+func statefulSetRolloutStatus(s *appsv1.StatefulSet) RolloutStatus {
+	if s.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+		return RolloutStatus{Done: true, Message: "rollout status is not available for the OnDelete update strategy"}
+	}
+	if s.Generation > s.Status.ObservedGeneration {
+		return RolloutStatus{Message: "waiting for statefulset spec update to be observed"}
+	}
+	replicas := int32(1)
+	if s.Spec.Replicas != nil {
+		replicas = *s.Spec.Replicas
+	}
+	if s.Status.ReadyReplicas < replicas {
+		return RolloutStatus{Message: fmt.Sprintf("%d of %d pods are ready", s.Status.ReadyReplicas, replicas)}
+	}
+	if rollingUpdate := s.Spec.UpdateStrategy.RollingUpdate; rollingUpdate != nil && rollingUpdate.Partition != nil && *rollingUpdate.Partition > 0 {
+		if s.Status.UpdatedReplicas < replicas-*rollingUpdate.Partition {
+			return RolloutStatus{Message: fmt.Sprintf("waiting for partitioned rollout: %d new pods have been updated", s.Status.UpdatedReplicas)}
+		}
+		return RolloutStatus{Done: true, Message: fmt.Sprintf("partitioned rollout complete: %d new pods have been updated", s.Status.UpdatedReplicas)}
+	}
+	if s.Status.UpdateRevision != s.Status.CurrentRevision {
+		return RolloutStatus{Message: fmt.Sprintf("%d pods at revision %s", s.Status.UpdatedReplicas, s.Status.UpdateRevision)}
+	}
+	return RolloutStatus{Done: true, Message: fmt.Sprintf("statefulset rolling update complete: %d pods at revision %s", s.Status.CurrentReplicas, s.Status.CurrentRevision)}
+}
+
+func daemonSetRolloutStatus(d *appsv1.DaemonSet) RolloutStatus {
+	if d.Spec.UpdateStrategy.Type != appsv1.RollingUpdateDaemonSetStrategyType {
+		return RolloutStatus{Done: true, Message: "rollout status is only available for the RollingUpdate strategy"}
+	}
+	if d.Generation > d.Status.ObservedGeneration {
+		return RolloutStatus{Message: "waiting for daemonset spec update to be observed"}
+	}
+	if d.Status.UpdatedNumberScheduled < d.Status.DesiredNumberScheduled {
+		return RolloutStatus{Message: fmt.Sprintf("%d out of %d new pods have been updated", d.Status.UpdatedNumberScheduled, d.Status.DesiredNumberScheduled)}
+	}
+	if d.Status.NumberAvailable < d.Status.DesiredNumberScheduled {
+		return RolloutStatus{Message: fmt.Sprintf("%d of %d updated pods are available", d.Status.NumberAvailable, d.Status.DesiredNumberScheduled)}
+	}
+	return RolloutStatus{Done: true, Message: fmt.Sprintf("daemon set %q successfully rolled out", d.Name)}
+}
+
+// WatchRollout follows the rollout of a Deployment, StatefulSet, or DaemonSet until it
+// completes, fails, or the timeout expires, optionally triggering it first with a
+// rollout restart. Events for the workload and the ReplicaSets and pods it creates
+// (pod scheduling, image pulls, probe failures, and so on) are watched as they happen,
+// and every event and rollout status change is passed to onUpdate, if set, as it occurs.
+// Returns a map with the outcome and a timeline of what happened, or an error.
+func (c *Client) WatchRollout(ctx context.Context, kind, name, namespace string, restart bool, timeout time.Duration, onUpdate func(update map[string]interface{})) (map[string]interface{}, error) {
+	kind = c.resolveKind(kind)
+	if _, err := c.GetRolloutStatus(ctx, kind, name, namespace); err != nil {
+		return nil, err
+	}
+	objects, err := c.newRolloutObjects(ctx, kind, name, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start watching before triggering the rollout so that no event is missed
+	eventList, err := c.clientset().CoreV1().Events(namespace).List(ctx, metav1.ListOptions{Limit: 1})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+	watcher, err := c.clientset().CoreV1().Events(namespace).Watch(ctx, metav1.ListOptions{ResourceVersion: eventList.ResourceVersion})
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch events: %w", err)
+	}
+	defer watcher.Stop()
+
+	if restart {
+		if _, err := c.RolloutRestart(ctx, kind, name, namespace); err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	var timeline []map[string]interface{}
+	record := func(update map[string]interface{}) {
+		update["elapsed"] = time.Since(start).Round(time.Second).String()
+		if len(timeline) < maxRolloutTimeline {
+			timeline = append(timeline, update)
+		}
+		if onUpdate != nil {
+			onUpdate(update)
+		}
+	}
+
+	ticker := time.NewTicker(rolloutPollInterval)
+	defer ticker.Stop()
+
+	events := watcher.ResultChan()
+	lastMessage := ""
+	var status RolloutStatus
+	for {
+		// A poll cut short by the timeout keeps the last status that was read
+		current, err := c.GetRolloutStatus(ctx, kind, name, namespace)
+		if err != nil && ctx.Err() == nil {
+			return nil, err
+		}
+		if err == nil {
+			status = current
+			if status.Message != lastMessage {
+				lastMessage = status.Message
+				record(map[string]interface{}{"type": "status", "message": status.Message})
+			}
+		}
+		if status.Done || status.Failed || ctx.Err() != nil {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-ticker.C:
+		case watchEvent, ok := <-events:
+			if !ok {
+				// The watch was closed by the server; keep tracking status by polling
+				events = nil
+				continue
+			}
+			if watchEvent.Type != watch.Added && watchEvent.Type != watch.Modified {
+				continue
+			}
+			if event, ok := watchEvent.Object.(*corev1.Event); ok && objects.involves(ctx, event.InvolvedObject) {
+				record(map[string]interface{}{
+					"type":    "event",
+					"object":  event.InvolvedObject.Kind + "/" + event.InvolvedObject.Name,
+					"reason":  event.Reason,
+					"message": event.Message,
+					"warning": event.Type == corev1.EventTypeWarning,
+				})
+			}
+		}
+	}
+
+	outcome := "complete"
+	switch {
+	case status.Failed:
+		outcome = "failed"
+	case !status.Done:
+		outcome = "timeout"
+	}
+	return map[string]interface{}{
+		"kind":      kind,
+		"name":      name,
+		"namespace": namespace,
+		"restarted": restart,
+		"outcome":   outcome,
+		"status":    status.Message,
+		"duration":  time.Since(start).Round(time.Second).String(),
+		"timeline":  timeline,
+	}, nil
+}
+
+// rolloutObjects tells whether an event concerns a workload or the ReplicaSets and
+// pods its controllers create, by following the controller references of the involved
+// object up to the workload. Whether an object belongs to the rollout is cached by UID.
+type rolloutObjects struct {
+	client    *Client
+	namespace string
+	uid       types.UID
+	owned     map[types.UID]bool
+}
+
+// newRolloutObjects looks up a workload and the ReplicaSets and pods it controls
+// already, so that events for old pods are recognised after the pods are deleted.
+func (c *Client) newRolloutObjects(ctx context.Context, kind, name, namespace string) (*rolloutObjects, error) {
+	objects := &rolloutObjects{client: c, namespace: namespace, owned: map[types.UID]bool{}}
+	owners := map[types.UID]bool{}
+	var selector *metav1.LabelSelector
+	if kind == "Deployment" {
+		deployment, err := c.clientset().AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get deployment '%s': %w", name, err)
+		}
+		replicaSets, err := c.ownedReplicaSets(ctx, deployment)
+		if err != nil {
+			return nil, err
+		}
+		for _, rs := range replicaSets {
+			owners[rs.UID] = true
+			objects.owned[rs.UID] = true
+		}
+		objects.uid, selector = deployment.UID, deployment.Spec.Selector
+	} else {
+		var err error
+		selector, objects.uid, _, err = c.workloadSelector(ctx, kind, name, namespace)
+		if err != nil {
+			return nil, err
+		}
+		owners[objects.uid] = true
+	}
+
+	pods, err := c.ownedPods(ctx, namespace, selector, owners)
+	if err != nil {
+		return nil, err
+	}
+	for _, pod := range pods {
+		objects.owned[pod.UID] = true
+	}
+	return objects, nil
+}
+
+// involves reports whether the object an event is about is the workload, or a
+// ReplicaSet or pod that it controls directly or, for pods of a Deployment, through
+// a ReplicaSet.
+func (o *rolloutObjects) involves(ctx context.Context, object corev1.ObjectReference) bool {
+	if object.UID == o.uid {
+		return true
+	}
+	if object.UID == "" || (object.Kind != "Pod" && object.Kind != "ReplicaSet") {
+		return false
+	}
+	if owned, ok := o.owned[object.UID]; ok {
+		return owned
+	}
+
+	var controller *metav1.OwnerReference
+	switch object.Kind {
+	case "Pod":
+		pod, err := o.client.clientset().CoreV1().Pods(o.namespace).Get(ctx, object.Name, metav1.GetOptions{})
+		if err != nil || pod.UID != object.UID {
+			return false
+		}
+		controller = metav1.GetControllerOf(pod)
+	case "ReplicaSet":
+		rs, err := o.client.clientset().AppsV1().ReplicaSets(o.namespace).Get(ctx, object.Name, metav1.GetOptions{})
+		if err != nil || rs.UID != object.UID {
+			return false
+		}
+		controller = metav1.GetControllerOf(rs)
+	}
+
+	owned := false
+	if controller != nil {
+		owned = controller.UID == o.uid
+		if !owned && object.Kind == "Pod" && controller.Kind == "ReplicaSet" {
+			owned = o.involves(ctx, corev1.ObjectReference{Kind: controller.Kind, Name: controller.Name, UID: controller.UID})
+		}
+	}
+	o.owned[object.UID] = owned
+	return owned
+}
+
+// rolloutReplicas are the replica counts of a workload that rollout progress is measured by.
+type rolloutReplicas struct {
+	desired int32
+	// target is how many replicas the rollout updates, which is less than desired
+	// for a partitioned StatefulSet rollout
+	target  int32
+	updated int32
+	ready   int32
+}
+
+// rolloutSample fetches a workload and returns its rollout status and replica counts.
+func (c *Client) rolloutSample(ctx context.Context, kind, name, namespace string) (RolloutStatus, rolloutReplicas, error) {
+	switch kind {
+	case "Deployment":
+		deployment, err := c.clientset().AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return RolloutStatus{}, rolloutReplicas{}, fmt.Errorf("failed to get deployment '%s': %w", name, err)
+		}
+		replicas := int32(1)
+		if deployment.Spec.Replicas != nil {
+			replicas = *deployment.Spec.Replicas
+		}
+		return deploymentRolloutStatus(deployment), rolloutReplicas{
+			desired: replicas,
+			target:  replicas,
+			updated: deployment.Status.UpdatedReplicas,
+			ready:   deployment.Status.ReadyReplicas,
+		}, nil
+	case "StatefulSet":
+		statefulSet, err := c.clientset().AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return RolloutStatus{}, rolloutReplicas{}, fmt.Errorf("failed to get statefulset '%s': %w", name, err)
+		}
+		replicas := int32(1)
+		if statefulSet.Spec.Replicas != nil {
+			replicas = *statefulSet.Spec.Replicas
+		}
+		target := replicas
+		if rollingUpdate := statefulSet.Spec.UpdateStrategy.RollingUpdate; rollingUpdate != nil && rollingUpdate.Partition != nil && *rollingUpdate.Partition > 0 {
+			target = max(replicas-*rollingUpdate.Partition, 0)
+		}
+		return statefulSetRolloutStatus(statefulSet), rolloutReplicas{
+			desired: replicas,
+			target:  target,
+			updated: statefulSet.Status.UpdatedReplicas,
+			ready:   statefulSet.Status.ReadyReplicas,
+		}, nil
+	case "DaemonSet":
+		daemonSet, err := c.clientset().AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return RolloutStatus{}, rolloutReplicas{}, fmt.Errorf("failed to get daemonset '%s': %w", name, err)
+		}
+		return daemonSetRolloutStatus(daemonSet), rolloutReplicas{
+			desired: daemonSet.Status.DesiredNumberScheduled,
+			target:  daemonSet.Status.DesiredNumberScheduled,
+			updated: daemonSet.Status.UpdatedNumberScheduled,
+			ready:   daemonSet.Status.NumberReady,
+		}, nil
+	}
+	return RolloutStatus{}, rolloutReplicas{}, fmt.Errorf("rollout progress is not supported for kind '%s': expected Deployment, StatefulSet, or DaemonSet", kind)
+}
+
+// rolloutPercent returns part as a percentage of whole, rounded to one decimal and
+// capped at 100, since surge replicas can briefly exceed the desired count. An empty
+// whole counts as complete.
+func rolloutPercent(part, whole int32) float64 {
+	if whole <= 0 {
+		return 100
+	}
+	return math.Min(100, math.Round(float64(part)/float64(whole)*1000)/10)
+}
+
+// progress returns the overall completion percentage of a rollout: the lower of the
+// share of replicas updated and the share ready, or 100 once the rollout is done.
+func (r rolloutReplicas) progress(status RolloutStatus) float64 {
+	if status.Done {
+		return 100
+	}
+	return math.Min(rolloutPercent(r.updated, r.target), rolloutPercent(r.ready, r.desired))
+}
+
+// GetRolloutProgress reports how far the rollout of a Deployment, StatefulSet, or
+// DaemonSet has got as percentages: updated replicas and ready replicas out of the
+// desired count, and an overall progress that is the lower of the two. To estimate
+// the time remaining, the workload is sampled a second time after sampleFor and the
+// observed rate of progress is extrapolated; no estimate is given if sampleFor is
+// zero, the rollout is done or failed, or it made no progress in between.
+// Returns a map with the replica counts, percentages, and estimate, or an error.
+func (c *Client) GetRolloutProgress(ctx context.Context, kind, name, namespace string, sampleFor time.Duration) (map[string]interface{}, error) {
+	kind = c.resolveKind(kind)
+	status, replicas, err := c.rolloutSample(ctx, kind, name, namespace)
+	if err != nil {
+		return nil, err
+	}
+	firstProgress := replicas.progress(status)
+	start := time.Now()
+
+	sampled := false
+	if sampleFor > 0 && !status.Done && !status.Failed {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(sampleFor):
+		}
+		status, replicas, err = c.rolloutSample(ctx, kind, name, namespace)
+		if err != nil {
+			return nil, err
+		}
+		sampled = true
+	}
+	progress := replicas.progress(status)
+
+	result := map[string]interface{}{
+		"kind":            kind,
+		"name":            name,
+		"namespace":       namespace,
+		"done":            status.Done,
+		"failed":          status.Failed,
+		"status":          status.Message,
+		"desiredReplicas": replicas.desired,
+		"updatedReplicas": replicas.updated,
+		"readyReplicas":   replicas.ready,
+		"updatedPercent":  rolloutPercent(replicas.updated, replicas.target),
+		"readyPercent":    rolloutPercent(replicas.ready, replicas.desired),
+		"progressPercent": progress,
+	}
+	if replicas.target != replicas.desired {
+		result["targetReplicas"] = replicas.target
+	}
+	if !sampled {
+		return result, nil
+	}
+
+	elapsed := time.Since(start)
+	rate := (progress - firstProgress) / elapsed.Seconds()
+	result["sampledOver"] = elapsed.Round(time.Second).String()
+	result["percentPerMinute"] = math.Round(rate*600) / 10
+	switch {
+	case status.Done:
+		result["estimatedRemaining"] = "0s"
+	case status.Failed:
+	case rate > 0:
+		remaining := time.Duration((100 - progress) / rate * float64(time.Second))
+		result["estimatedRemaining"] = remaining.Round(time.Second).String()
+	default:
+		result["estimateNote"] = fmt.Sprintf("no progress was observed over %s, so the time remaining cannot be estimated", elapsed.Round(time.Second))
+	}
+	return result, nil
+}
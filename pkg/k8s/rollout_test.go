@@ -0,0 +1,80 @@
+package k8s_test
+
+import (
+	"context"
+	"maps"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/reza-gholizade/k8s-mcp-server/pkg/k8s/k8stest"
+)
+
+func TestWatchRolloutFollowsOwnerReferences(t *testing.T) {
+	running := corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+	objects := rollingOutObjects(t, running, running)
+
+	// A pod of another Deployment whose name starts with the watched one's
+	other := seeded[*corev1.Pod](t, objects).DeepCopy()
+	other.Name = "web-api-6b8f9c7d5-pqrst"
+	other.UID = "fake-pod-uid-web-api"
+	other.OwnerReferences[0].Name = "web-api-6b8f9c7d5"
+	other.OwnerReferences[0].UID = "fake-replicaset-web-api-uid"
+	objects = append(objects, other)
+
+	fakes := k8stest.NewFakes(objects...)
+	client := fakes.Client()
+	ctx := context.Background()
+
+	podEvent := func(name, podName string, uid types.UID) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: k8stest.Namespace},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: podName, Namespace: k8stest.Namespace, UID: uid},
+			Reason:         "Pulled",
+			Type:           corev1.EventTypeNormal,
+		}
+	}
+	oldPod := k8stest.PodNames[0]
+	emitted := false
+	emit := func(update map[string]interface{}) {
+		if emitted || update["type"] != "status" {
+			return
+		}
+		emitted = true
+		// Old pods are deleted as the rollout proceeds, before their last events arrive
+		if err := fakes.Clientset.CoreV1().Pods(k8stest.Namespace).Delete(ctx, oldPod, metav1.DeleteOptions{}); err != nil {
+			t.Errorf("failed to delete pod: %v", err)
+		}
+		for _, event := range []*corev1.Event{
+			podEvent("old", oldPod, types.UID("fake-pod-uid-"+oldPod)),
+			podEvent("new", "web-7c9d6b5f4-klmno", "fake-pod-uid-web-7c9d6b5f4-klmno"),
+			podEvent("other", other.Name, other.UID),
+		} {
+			if _, err := fakes.Clientset.CoreV1().Events(k8stest.Namespace).Create(ctx, event, metav1.CreateOptions{}); err != nil {
+				t.Errorf("failed to create event: %v", err)
+			}
+		}
+	}
+
+	result, err := client.WatchRollout(ctx, "Deployment", k8stest.DeploymentName, k8stest.Namespace, false, 300*time.Millisecond, emit)
+	if err != nil {
+		t.Fatalf("WatchRollout: %v", err)
+	}
+	if result["outcome"] != "timeout" || result["status"] != "1 out of 2 new replicas have been updated" {
+		t.Errorf("expected a timeout keeping the last status, got %v", result)
+	}
+
+	got := map[string]bool{}
+	for _, entry := range result["timeline"].([]map[string]interface{}) {
+		if entry["type"] == "event" {
+			got[entry["object"].(string)] = true
+		}
+	}
+	want := map[string]bool{"Pod/" + oldPod: true, "Pod/web-7c9d6b5f4-klmno": true}
+	if !maps.Equal(got, want) {
+		t.Errorf("events: got %v, want %v", got, want)
+	}
+}
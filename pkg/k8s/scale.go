@@ -0,0 +1,97 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// ScaleResource gets or sets the replica count of a scalable workload through its
+// scale subresource. Before scaling, it looks for HorizontalPodAutoscalers targeting
+// the workload: if one exists the change would be reverted by the autoscaler, so the
+// scale is refused unless force is set. When replicas is nil only the current replica
+// count and any matching autoscalers are returned.
+// Returns a map describing the workload's scale and autoscalers, or an error.
+func (c *Client) ScaleResource(ctx context.Context, kind, name, namespace string, replicas *int64, force bool) (map[string]interface{}, error) {
+	kind = c.resolveKind(kind)
+	gvr, err := c.getCachedGVR(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	resource := c.dynamicClient().Resource(*gvr).Namespace(namespace)
+	scale, err := resource.Get(ctx, name, metav1.GetOptions{}, "scale")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scale of %s %s/%s: %w", kind, namespace, name, err)
+	}
+	currentReplicas, _, _ := unstructured.NestedInt64(scale.Object, "spec", "replicas")
+
+	autoscalers, err := c.findAutoscalers(ctx, namespace, gvr.GroupResource(), kind, name)
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]interface{}{
+		"kind":        kind,
+		"name":        name,
+		"namespace":   namespace,
+		"replicas":    currentReplicas,
+		"autoscalers": autoscalers,
+	}
+
+	if replicas == nil {
+		return result, nil
+	}
+
+	if len(autoscalers) > 0 {
+		warning := fmt.Sprintf("%s %s/%s is managed by a HorizontalPodAutoscaler; a manual scale will be overridden by the autoscaler", kind, namespace, name)
+		if !force {
+			return nil, fmt.Errorf("%s (set force to scale anyway)", warning)
+		}
+		result["warning"] = warning
+	}
+
+	patch := []byte(fmt.Sprintf(`{"spec":{"replicas":%d}}`, *replicas))
+	scaled, err := resource.Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{}, "scale")
+	if err != nil {
+		return nil, fmt.Errorf("failed to scale %s %s/%s: %w", kind, namespace, name, err)
+	}
+	newReplicas, _, _ := unstructured.NestedInt64(scaled.Object, "spec", "replicas")
+
+	result["previousReplicas"] = currentReplicas
+	result["replicas"] = newReplicas
+	return result, nil
+}
+
+// findAutoscalers returns the HorizontalPodAutoscalers in a namespace whose
+// scaleTargetRef points at the given workload.
+func (c *Client) findAutoscalers(ctx context.Context, namespace string, target schema.GroupResource, kind, name string) ([]map[string]interface{}, error) {
+	hpas, err := c.clientset().AutoscalingV2().HorizontalPodAutoscalers(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list horizontal pod autoscalers: %w", err)
+	}
+
+	var autoscalers []map[string]interface{}
+	for _, hpa := range hpas.Items {
+		ref := hpa.Spec.ScaleTargetRef
+		if ref.Kind != kind || ref.Name != name {
+			continue
+		}
+		// An empty group in the target's apiVersion only matches core resources
+		if gv, err := schema.ParseGroupVersion(ref.APIVersion); err == nil && gv.Group != target.Group {
+			continue
+		}
+		autoscalers = append(autoscalers, map[string]interface{}{
+			"name":            hpa.Name,
+			"minReplicas":     hpa.Spec.MinReplicas,
+			"maxReplicas":     hpa.Spec.MaxReplicas,
+			"currentReplicas": hpa.Status.CurrentReplicas,
+			"desiredReplicas": hpa.Status.DesiredReplicas,
+		})
+	}
+	return autoscalers, nil
+}
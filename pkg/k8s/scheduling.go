@@ -0,0 +1,324 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// maxSchedulingNodes bounds how many nodes CheckSchedulability reports individually;
+// all nodes are still counted in the summary.
+const maxSchedulingNodes = 50
+
+// CheckSchedulability explains why a pod cannot be scheduled. Every node is checked
+// against the pod the way the scheduler's filters do: whether it is ready and
+// schedulable, whether it matches the pod's nodeSelector and required node affinity,
+// whether the pod tolerates its taints, and whether it has enough allocatable CPU,
+// memory, pods, and extended resources left once the requests of the pods already
+// on it are accounted for. Unbound PersistentVolumeClaims used by the pod and the
+// scheduler's own FailedScheduling events are reported as well. Inter-pod affinity
+// and topology spread constraints are not evaluated; when the pod uses them they are
+// noted as possible causes.
+// Returns a map with the pod's requests, the reasons counted across nodes in the
+// scheduler's "0/N nodes are available" style, and the per-node reasons, or an error.
+func (c *Client) CheckSchedulability(ctx context.Context, namespace, podName string) (map[string]interface{}, error) {
+	pod, err := c.clientset().CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pod '%s' in namespace '%s': %w", podName, namespace, err)
+	}
+
+	requests, _ := podRequestsAndLimits(pod)
+	result := map[string]interface{}{
+		"podName":   pod.Name,
+		"namespace": pod.Namespace,
+		"phase":     string(pod.Status.Phase),
+		"requests":  resourceListStrings(requests),
+	}
+	if pod.Spec.NodeName != "" {
+		result["scheduled"] = true
+		result["nodeName"] = pod.Spec.NodeName
+		result["message"] = fmt.Sprintf("pod '%s' is already scheduled to node '%s'", podName, pod.Spec.NodeName)
+		return result, nil
+	}
+	result["scheduled"] = false
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionFalse {
+			result["schedulerReason"] = condition.Reason
+			result["schedulerMessage"] = condition.Message
+		}
+	}
+
+	nodes, err := c.clientset().CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+	pods, err := c.clientset().CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	// Requests of the pods already on each node, and how many there are
+	nodeRequests := map[string]corev1.ResourceList{}
+	nodePods := map[string]int64{}
+	for i := range pods.Items {
+		existing := &pods.Items[i]
+		if existing.Spec.NodeName == "" || existing.Status.Phase == corev1.PodSucceeded || existing.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		if nodeRequests[existing.Spec.NodeName] == nil {
+			nodeRequests[existing.Spec.NodeName] = corev1.ResourceList{}
+		}
+		podRequests, _ := podRequestsAndLimits(existing)
+		addResourceList(nodeRequests[existing.Spec.NodeName], podRequests)
+		nodePods[existing.Spec.NodeName]++
+	}
+
+	reasonCounts := map[string]int{}
+	feasible := []string{}
+	nodeResults := []map[string]interface{}{}
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		reasons := nodeSchedulingReasons(pod, node, requests, nodeRequests[node.Name], nodePods[node.Name])
+		if len(reasons) == 0 {
+			feasible = append(feasible, node.Name)
+			continue
+		}
+		for _, reason := range reasons {
+			reasonCounts[schedulingReasonSummary(reason)]++
+		}
+		if len(nodeResults) < maxSchedulingNodes {
+			nodeResults = append(nodeResults, map[string]interface{}{
+				"name":    node.Name,
+				"reasons": reasons,
+			})
+		}
+	}
+
+	summaries := make([]string, 0, len(reasonCounts))
+	for reason, count := range reasonCounts {
+		summaries = append(summaries, fmt.Sprintf("%d %s", count, reason))
+	}
+	sort.Strings(summaries)
+	result["summary"] = fmt.Sprintf("%d/%d nodes are available: %s", len(feasible), len(nodes.Items), strings.Join(summaries, ", "))
+	result["feasibleNodes"] = feasible
+	result["nodes"] = nodeResults
+
+	if unbound := c.unboundClaims(ctx, pod); len(unbound) > 0 {
+		result["unboundClaims"] = unbound
+	}
+
+	var notes []string
+	if affinity := pod.Spec.Affinity; affinity != nil && (affinity.PodAffinity != nil || affinity.PodAntiAffinity != nil) {
+		notes = append(notes, "the pod has inter-pod affinity or anti-affinity rules, which were not evaluated")
+	}
+	if len(pod.Spec.TopologySpreadConstraints) > 0 {
+		notes = append(notes, "the pod has topology spread constraints, which were not evaluated")
+	}
+	if pod.Spec.SchedulerName != "" && pod.Spec.SchedulerName != corev1.DefaultSchedulerName {
+		notes = append(notes, fmt.Sprintf("the pod uses scheduler '%s'; check that it is running", pod.Spec.SchedulerName))
+	}
+	if len(feasible) > 0 && len(notes) == 0 {
+		notes = append(notes, "some nodes pass every check evaluated here; the pod may not have been attempted yet, or is waiting on preemption or volume binding")
+	}
+	if len(notes) > 0 {
+		result["notes"] = notes
+	}
+
+	if events, err := c.objectEvents(ctx, namespace, podName); err == nil {
+		var scheduling []map[string]interface{}
+		for _, event := range events {
+			switch event["reason"] {
+			case "FailedScheduling", "NotTriggerScaleUp", "TriggeredScaleUp":
+				scheduling = append(scheduling, event)
+			}
+		}
+		result["events"] = scheduling
+	}
+
+	return result, nil
+}
+
+// nodeSchedulingReasons returns the reasons a pod cannot be placed on a node, or none
+// if it fits.
+func nodeSchedulingReasons(pod *corev1.Pod, node *corev1.Node, requests, used corev1.ResourceList, podCount int64) []string {
+	var reasons []string
+
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady && condition.Status != corev1.ConditionTrue {
+			reasons = append(reasons, "node is not ready")
+		}
+	}
+	if node.Spec.Unschedulable && !toleratesTaint(pod.Spec.Tolerations, corev1.Taint{Key: corev1.TaintNodeUnschedulable, Effect: corev1.TaintEffectNoSchedule}) {
+		reasons = append(reasons, "node is unschedulable (cordoned)")
+	}
+
+	for key, value := range pod.Spec.NodeSelector {
+		if actual, ok := node.Labels[key]; !ok || actual != value {
+			reasons = append(reasons, fmt.Sprintf("node does not match nodeSelector %s=%s", key, value))
+		}
+	}
+	if affinity := pod.Spec.Affinity; affinity != nil && affinity.NodeAffinity != nil {
+		if required := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution; required != nil && !matchesNodeSelectorTerms(node, required.NodeSelectorTerms) {
+			reasons = append(reasons, "node does not match the pod's required node affinity")
+		}
+	}
+
+	for _, taint := range node.Spec.Taints {
+		if taint.Effect == corev1.TaintEffectPreferNoSchedule || toleratesTaint(pod.Spec.Tolerations, taint) {
+			continue
+		}
+		reasons = append(reasons, fmt.Sprintf("node has untolerated taint %s", taint.ToString()))
+	}
+
+	allocatable := node.Status.Allocatable
+	if maxPods, ok := allocatable[corev1.ResourcePods]; ok && podCount+1 > maxPods.Value() {
+		reasons = append(reasons, fmt.Sprintf("insufficient pods: %d of %d already running", podCount, maxPods.Value()))
+	}
+	names := make([]string, 0, len(requests))
+	for name := range requests {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		requested := requests[corev1.ResourceName(name)]
+		if requested.IsZero() {
+			continue
+		}
+		total := allocatable[corev1.ResourceName(name)]
+		available := total.DeepCopy()
+		if inUse, ok := used[corev1.ResourceName(name)]; ok {
+			available.Sub(inUse)
+		}
+		if available.Cmp(requested) < 0 {
+			if available.Sign() < 0 {
+				available = resource.Quantity{}
+			}
+			reasons = append(reasons, fmt.Sprintf("insufficient %s: requests %s, %s free of %s allocatable", name, requested.String(), available.String(), total.String()))
+		}
+	}
+
+	return reasons
+}
+
+// schedulingReasonSummary shortens a node's reason to the part shared across nodes,
+// so that the same reason on different nodes is counted together.
+func schedulingReasonSummary(reason string) string {
+	if summary, _, ok := strings.Cut(reason, ":"); ok && strings.HasPrefix(reason, "insufficient") {
+		return summary
+	}
+	return reason
+}
+
+// toleratesTaint reports whether any of the tolerations tolerates the taint. An empty
+// effect matches every effect, an empty key with the Exists operator matches every
+// taint, and an empty operator means Equal.
+func toleratesTaint(tolerations []corev1.Toleration, taint corev1.Taint) bool {
+	for _, toleration := range tolerations {
+		if toleration.Effect != "" && toleration.Effect != taint.Effect {
+			continue
+		}
+		if toleration.Key != "" && toleration.Key != taint.Key {
+			continue
+		}
+		switch toleration.Operator {
+		case corev1.TolerationOpExists:
+			return true
+		case "", corev1.TolerationOpEqual:
+			if toleration.Value == taint.Value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchesNodeSelectorTerms reports whether a node matches any of the terms, each of
+// which matches when all of its label expressions and field expressions do.
+func matchesNodeSelectorTerms(node *corev1.Node, terms []corev1.NodeSelectorTerm) bool {
+	for _, term := range terms {
+		if len(term.MatchExpressions) == 0 && len(term.MatchFields) == 0 {
+			continue
+		}
+		matches := true
+		for _, requirement := range term.MatchExpressions {
+			if !matchesNodeSelectorRequirement(node.Labels, requirement) {
+				matches = false
+			}
+		}
+		for _, requirement := range term.MatchFields {
+			// metadata.name is the only field supported in node selector terms
+			if !matchesNodeSelectorRequirement(map[string]string{"metadata.name": node.Name}, requirement) {
+				matches = false
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesNodeSelectorRequirement evaluates a single node selector requirement
+// against a set of labels.
+func matchesNodeSelectorRequirement(labels map[string]string, requirement corev1.NodeSelectorRequirement) bool {
+	value, ok := labels[requirement.Key]
+	switch requirement.Operator {
+	case corev1.NodeSelectorOpIn:
+		return ok && slices.Contains(requirement.Values, value)
+	case corev1.NodeSelectorOpNotIn:
+		return !ok || !slices.Contains(requirement.Values, value)
+	case corev1.NodeSelectorOpExists:
+		return ok
+	case corev1.NodeSelectorOpDoesNotExist:
+		return !ok
+	case corev1.NodeSelectorOpGt, corev1.NodeSelectorOpLt:
+		if !ok || len(requirement.Values) != 1 {
+			return false
+		}
+		actual, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return false
+		}
+		bound, err := strconv.ParseInt(requirement.Values[0], 10, 64)
+		if err != nil {
+			return false
+		}
+		if requirement.Operator == corev1.NodeSelectorOpGt {
+			return actual > bound
+		}
+		return actual < bound
+	}
+	return false
+}
+
+// unboundClaims returns the PersistentVolumeClaims used by a pod that are missing or
+// not yet bound, which keep the pod from being scheduled.
+func (c *Client) unboundClaims(ctx context.Context, pod *corev1.Pod) []map[string]interface{} {
+	var unbound []map[string]interface{}
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim == nil {
+			continue
+		}
+		name := volume.PersistentVolumeClaim.ClaimName
+		claim, err := c.clientset().CoreV1().PersistentVolumeClaims(pod.Namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			unbound = append(unbound, map[string]interface{}{"name": name, "error": err.Error()})
+			continue
+		}
+		if claim.Status.Phase != corev1.ClaimBound {
+			entry := map[string]interface{}{"name": name, "phase": string(claim.Status.Phase)}
+			if claim.Spec.StorageClassName != nil {
+				entry["storageClass"] = *claim.Spec.StorageClassName
+			}
+			unbound = append(unbound, entry)
+		}
+	}
+	return unbound
+}
@@ -0,0 +1,332 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// semanticDiffIgnoredFields are the server-populated fields never compared by SemanticDiff,
+// even if the manifest carries them, e.g. because it was exported from a cluster.
+var semanticDiffIgnoredFields = [][]string{
+	{"metadata", "managedFields"},
+	{"metadata", "resourceVersion"},
+	{"metadata", "uid"},
+	{"metadata", "generation"},
+	{"metadata", "creationTimestamp"},
+	{"metadata", "selfLink"},
+	{"status"},
+}
+
+// fieldDiff accumulates the differences found by SemanticDiff.
+type fieldDiff struct {
+	adds    []map[string]interface{}
+	removes []map[string]interface{}
+	changes []map[string]interface{}
+	// reportRemoved also reports map fields only present in current as removes
+	reportRemoved bool
+}
+
+// SemanticDiff compares a YAML or JSON manifest with the live object it describes,
+// field by field rather than line by line. Only the fields the manifest sets are
+// compared, so fields the server populates or defaults do not show up as differences,
+// and key order and formatting are irrelevant. Quantities are compared by value, so
+// "1000m" equals "1". Lists of objects with a name, such as containers, ports, and
+// env, are matched by name instead of position; entries in the live list that the
+// manifest omits are reported as removes, since the list is replaced as a whole.
+// Unless reveal is set, values of fields matched by the mask rules, such as Secret
+// data, are redacted in the result; the paths that differ are still reported.
+// Returns a map with the adds, removes, and changes by field path, or an error.
+func (c *Client) SemanticDiff(ctx context.Context, namespace, manifest, kind string, reveal bool) (map[string]interface{}, error) {
+	kind = c.resolveKind(kind)
+	jsonData, err := yaml.YAMLToJSON([]byte(manifest))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+
+	obj := &unstructured.Unstructured{}
+	if err := json.Unmarshal(jsonData, &obj.Object); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+
+	if kind == "" {
+		kind = obj.GetKind()
+		if kind == "" {
+			return nil, fmt.Errorf("resource kind is required: either provide it as a parameter or include it in the manifest")
+		}
+	}
+	if obj.GetName() == "" {
+		return nil, fmt.Errorf("resource name is required in manifest")
+	}
+
+	gvr, err := c.getCachedGVR(kind)
+	if err != nil {
+		return nil, err
+	}
+	namespaced, err := c.isNamespaced(kind)
+	if err != nil {
+		return nil, err
+	}
+	if !namespaced {
+		obj.SetNamespace("")
+	} else if namespace != "" {
+		obj.SetNamespace(namespace)
+	}
+	if namespaced && obj.GetNamespace() == "" {
+		obj.SetNamespace("default")
+	}
+
+	result := map[string]interface{}{
+		"kind":      kind,
+		"name":      obj.GetName(),
+		"namespace": obj.GetNamespace(),
+	}
+	live, err := c.dynamicClient().Resource(*gvr).Namespace(obj.GetNamespace()).Get(ctx, obj.GetName(), metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		result["exists"] = false
+		result["message"] = fmt.Sprintf("%s '%s' does not exist; applying the manifest would create it", kind, obj.GetName())
+		return result, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get live resource: %w", err)
+	}
+
+	desired := obj.DeepCopy().Object
+	current := live.DeepCopy().Object
+	for _, field := range semanticDiffIgnoredFields {
+		unstructured.RemoveNestedField(desired, field...)
+		unstructured.RemoveNestedField(current, field...)
+	}
+
+	diff := &fieldDiff{}
+	diff.compareMaps("", desired, current)
+	c.finishDiff(kind, diff, reveal)
+
+	result["exists"] = true
+	result["identical"] = len(diff.adds)+len(diff.removes)+len(diff.changes) == 0
+	result["adds"] = nonNilDiffs(diff.adds)
+	result["removes"] = nonNilDiffs(diff.removes)
+	result["changes"] = nonNilDiffs(diff.changes)
+	return result, nil
+}
+
+// CompareFields compares the fields a desired object sets with the live object the
+// way SemanticDiff does, ignoring the fields the server populates, and returns only
+// the paths of the fields that differ, sorted, so that no values, such as Secret data,
+// are exposed.
+func CompareFields(desired, current map[string]interface{}) (adds, removes, changes []string) {
+	desired = runtime.DeepCopyJSON(desired)
+	current = runtime.DeepCopyJSON(current)
+	for _, field := range semanticDiffIgnoredFields {
+		unstructured.RemoveNestedField(desired, field...)
+		unstructured.RemoveNestedField(current, field...)
+	}
+
+	diff := &fieldDiff{}
+	diff.compareMaps("", desired, current)
+	paths := func(diffs []map[string]interface{}) []string {
+		result := []string{}
+		for _, entry := range diffs {
+			result = append(result, entry["path"].(string))
+		}
+		sort.Strings(result)
+		return result
+	}
+	return paths(diff.adds), paths(diff.removes), paths(diff.changes)
+}
+
+// compareMaps records the differences between the fields the desired map sets and the
+// same fields of the current map. Fields only present in current are ignored unless
+// reportRemoved is set.
+func (d *fieldDiff) compareMaps(prefix string, desired, current map[string]interface{}) {
+	for key, desiredValue := range desired {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		currentValue, ok := current[key]
+		if !ok {
+			if desiredValue != nil {
+				d.adds = append(d.adds, map[string]interface{}{"path": path, "value": desiredValue})
+			}
+			continue
+		}
+		d.compareValues(path, desiredValue, currentValue)
+	}
+	if !d.reportRemoved {
+		return
+	}
+	for key, currentValue := range current {
+		if _, ok := desired[key]; !ok {
+			path := key
+			if prefix != "" {
+				path = prefix + "." + key
+			}
+			d.removes = append(d.removes, map[string]interface{}{"path": path, "value": currentValue})
+		}
+	}
+}
+
+// compareValues records the differences between a desired and a current value.
+func (d *fieldDiff) compareValues(path string, desired, current interface{}) {
+	if desired == nil {
+		// An explicit null in the manifest deletes the field
+		d.removes = append(d.removes, map[string]interface{}{"path": path, "value": current})
+		return
+	}
+	desiredMap, desiredIsMap := desired.(map[string]interface{})
+	currentMap, currentIsMap := current.(map[string]interface{})
+	if desiredIsMap && currentIsMap {
+		d.compareMaps(path, desiredMap, currentMap)
+		return
+	}
+	desiredList, desiredIsList := desired.([]interface{})
+	currentList, currentIsList := current.([]interface{})
+	if desiredIsList && currentIsList {
+		d.compareLists(path, desiredList, currentList)
+		return
+	}
+	if !semanticEqual(desired, current) {
+		d.changes = append(d.changes, map[string]interface{}{"path": path, "from": current, "to": desired})
+	}
+}
+
+// compareLists records the differences between two lists. Lists whose entries are all
+// objects with a name are matched by name; other lists are compared by position.
+func (d *fieldDiff) compareLists(path string, desired, current []interface{}) {
+	desiredNames, desiredNamed := listEntryNames(desired)
+	currentNames, currentNamed := listEntryNames(current)
+	if desiredNamed && currentNamed {
+		currentByName := map[string]interface{}{}
+		for i, name := range currentNames {
+			currentByName[name] = current[i]
+		}
+		wanted := map[string]bool{}
+		for i, name := range desiredNames {
+			wanted[name] = true
+			entryPath := fmt.Sprintf("%s[name=%s]", path, name)
+			if currentEntry, ok := currentByName[name]; ok {
+				d.compareValues(entryPath, desired[i], currentEntry)
+			} else {
+				d.adds = append(d.adds, map[string]interface{}{"path": entryPath, "value": desired[i]})
+			}
+		}
+		for i, name := range currentNames {
+			if !wanted[name] {
+				d.removes = append(d.removes, map[string]interface{}{"path": fmt.Sprintf("%s[name=%s]", path, name), "value": current[i]})
+			}
+		}
+		return
+	}
+
+	for i := range desired {
+		entryPath := fmt.Sprintf("%s[%d]", path, i)
+		if i < len(current) {
+			d.compareValues(entryPath, desired[i], current[i])
+		} else {
+			d.adds = append(d.adds, map[string]interface{}{"path": entryPath, "value": desired[i]})
+		}
+	}
+	for i := len(desired); i < len(current); i++ {
+		d.removes = append(d.removes, map[string]interface{}{"path": fmt.Sprintf("%s[%d]", path, i), "value": current[i]})
+	}
+}
+
+// listEntryNames returns the name of each entry of a list, and whether every entry is
+// an object with a non-empty string name.
+func listEntryNames(list []interface{}) ([]string, bool) {
+	if len(list) == 0 {
+		return nil, false
+	}
+	names := make([]string, 0, len(list))
+	for _, entry := range list {
+		entryMap, ok := entry.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		name, ok := entryMap["name"].(string)
+		if !ok || name == "" {
+			return nil, false
+		}
+		names = append(names, name)
+	}
+	return names, true
+}
+
+// semanticEqual compares two scalar values by their JSON encoding, treating strings
+// that parse as equal quantities, such as "1000m" and "1" or "1Gi" and "1024Mi", as equal.
+func semanticEqual(a, b interface{}) bool {
+	aJSON, errA := json.Marshal(a)
+	bJSON, errB := json.Marshal(b)
+	if errA == nil && errB == nil && string(aJSON) == string(bJSON) {
+		return true
+	}
+	aString, aIsString := a.(string)
+	bString, bIsString := b.(string)
+	if !aIsString || !bIsString {
+		return false
+	}
+	aQuantity, errA := resource.ParseQuantity(aString)
+	bQuantity, errB := resource.ParseQuantity(bString)
+	return errA == nil && errB == nil && aQuantity.Cmp(bQuantity) == 0
+}
+
+// finishDiff orders the differences by path and, unless reveal is set, redacts the
+// values of fields matched by the mask rules for kind.
+func (c *Client) finishDiff(kind string, diff *fieldDiff, reveal bool) {
+	for _, list := range [][]map[string]interface{}{diff.adds, diff.removes, diff.changes} {
+		sort.SliceStable(list, func(i, j int) bool {
+			return list[i]["path"].(string) < list[j]["path"].(string)
+		})
+		if reveal {
+			continue
+		}
+		for _, entry := range list {
+			if c.isMaskedPath(kind, entry["path"].(string)) {
+				for _, key := range []string{"value", "from", "to"} {
+					if _, ok := entry[key]; ok {
+						entry[key] = RedactedValue
+					}
+				}
+			}
+		}
+	}
+}
+
+// isMaskedPath reports whether a diff path is, contains, or lies within a field matched
+// by the mask rules for kind.
+func (c *Client) isMaskedPath(kind, path string) bool {
+	within := func(inner, outer string) bool {
+		return inner == outer || strings.HasPrefix(inner, outer+".") || strings.HasPrefix(inner, outer+"[")
+	}
+	for _, rules := range [][]MaskRule{DefaultMaskRules, c.maskRules} {
+		for _, rule := range rules {
+			if rule.Kind != "*" && !strings.EqualFold(rule.Kind, kind) {
+				continue
+			}
+			masked := strings.Join(rule.Path, ".")
+			if within(path, masked) || within(masked, path) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// nonNilDiffs returns diffs, or an empty slice if there are none, so that it
+// serializes as an empty list rather than null.
+func nonNilDiffs(diffs []map[string]interface{}) []map[string]interface{} {
+	if diffs == nil {
+		return []map[string]interface{}{}
+	}
+	return diffs
+}
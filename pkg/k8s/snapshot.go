@@ -0,0 +1,142 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/duration"
+)
+
+// maxSnapshots bounds how many snapshots are kept; taking another one discards the oldest.
+const maxSnapshots = 100
+
+// snapshotIgnoredFields are the fields that change on every write and are never
+// compared by DiffSnapshot.
+var snapshotIgnoredFields = [][]string{
+	{"metadata", "managedFields"},
+	{"metadata", "resourceVersion"},
+}
+
+// resourceSnapshot is an object as captured by SnapshotResource.
+type resourceSnapshot struct {
+	id        string
+	kind      string
+	name      string
+	namespace string
+	taken     time.Time
+	object    *unstructured.Unstructured
+}
+
+// SnapshotResource captures the current state of an object in memory, so that it can
+// later be compared with DiffSnapshot, e.g. before a change or while waiting for a
+// controller to act. Up to maxSnapshots snapshots are kept, and all of them are lost
+// when the server restarts.
+// Returns a map with the snapshot ID and the object's identity and version, or an error.
+func (c *Client) SnapshotResource(ctx context.Context, kind, name, namespace string) (map[string]interface{}, error) {
+	kind = c.resolveKind(kind)
+	obj, err := c.getObject(ctx, kind, name, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s '%s': %w", kind, name, err)
+	}
+
+	c.snapshotLock.Lock()
+	c.snapshotSeq++
+	snapshot := &resourceSnapshot{
+		id:        fmt.Sprintf("snap-%d", c.snapshotSeq),
+		kind:      kind,
+		name:      obj.GetName(),
+		namespace: obj.GetNamespace(),
+		taken:     time.Now(),
+		object:    obj,
+	}
+	c.snapshots = append(c.snapshots, snapshot)
+	if len(c.snapshots) > maxSnapshots {
+		c.snapshots = c.snapshots[len(c.snapshots)-maxSnapshots:]
+	}
+	c.snapshotLock.Unlock()
+
+	return map[string]interface{}{
+		"snapshotId":      snapshot.id,
+		"kind":            kind,
+		"name":            snapshot.name,
+		"namespace":       snapshot.namespace,
+		"resourceVersion": obj.GetResourceVersion(),
+		"generation":      obj.GetGeneration(),
+		"taken":           snapshot.taken,
+	}, nil
+}
+
+// DiffSnapshot compares the current state of a snapshotted object with the snapshot,
+// field by field: fields added, removed, and changed since it was taken, with list
+// entries that have a name, such as containers, matched by name. The status is only
+// compared when includeStatus is set, since it changes constantly. An object that was
+// deleted, or deleted and recreated, since the snapshot is reported as such. Unless
+// reveal is set, values of fields matched by the mask rules are redacted.
+// Returns a map with the differences by field path, or an error.
+func (c *Client) DiffSnapshot(ctx context.Context, snapshotID string, includeStatus, reveal bool) (map[string]interface{}, error) {
+	snapshot := c.findSnapshot(snapshotID)
+	if snapshot == nil {
+		return nil, fmt.Errorf("snapshot '%s' not found; snapshots are kept in memory and lost when the server restarts", snapshotID)
+	}
+
+	result := map[string]interface{}{
+		"snapshotId": snapshot.id,
+		"kind":       snapshot.kind,
+		"name":       snapshot.name,
+		"namespace":  snapshot.namespace,
+		"taken":      snapshot.taken,
+		"age":        duration.HumanDuration(time.Since(snapshot.taken)),
+	}
+
+	live, err := c.getObject(ctx, snapshot.kind, snapshot.name, snapshot.namespace)
+	if errors.IsNotFound(err) {
+		result["exists"] = false
+		result["message"] = fmt.Sprintf("%s '%s' was deleted since the snapshot was taken", snapshot.kind, snapshot.name)
+		return result, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s '%s': %w", snapshot.kind, snapshot.name, err)
+	}
+	result["exists"] = true
+	if live.GetUID() != snapshot.object.GetUID() {
+		result["recreated"] = true
+	}
+	result["resourceVersion"] = map[string]interface{}{"from": snapshot.object.GetResourceVersion(), "to": live.GetResourceVersion()}
+	result["generation"] = map[string]interface{}{"from": snapshot.object.GetGeneration(), "to": live.GetGeneration()}
+
+	before := snapshot.object.DeepCopy().Object
+	after := live.DeepCopy().Object
+	ignored := snapshotIgnoredFields
+	if !includeStatus {
+		ignored = append(ignored, []string{"status"})
+	}
+	for _, field := range ignored {
+		unstructured.RemoveNestedField(before, field...)
+		unstructured.RemoveNestedField(after, field...)
+	}
+
+	diff := &fieldDiff{reportRemoved: true}
+	diff.compareMaps("", after, before)
+	c.finishDiff(snapshot.kind, diff, reveal)
+
+	result["unchanged"] = len(diff.adds)+len(diff.removes)+len(diff.changes) == 0
+	result["adds"] = nonNilDiffs(diff.adds)
+	result["removes"] = nonNilDiffs(diff.removes)
+	result["changes"] = nonNilDiffs(diff.changes)
+	return result, nil
+}
+
+// findSnapshot returns the snapshot with the given ID, or nil if it does not exist.
+func (c *Client) findSnapshot(id string) *resourceSnapshot {
+	c.snapshotLock.Lock()
+	defer c.snapshotLock.Unlock()
+	for _, snapshot := range c.snapshots {
+		if snapshot.id == id {
+			return snapshot
+		}
+	}
+	return nil
+}
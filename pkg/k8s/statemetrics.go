@@ -0,0 +1,166 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// StateMetric is a gauge computed from the state of cluster objects, named and
+// labelled after its kube-state-metrics counterpart where there is one.
+type StateMetric struct {
+	Name    string              `json:"name"`
+	Help    string              `json:"help"`
+	Samples []StateMetricSample `json:"samples"`
+}
+
+// StateMetricSample is one labelled value of a StateMetric.
+type StateMetricSample struct {
+	Labels map[string]string `json:"labels"`
+	Value  float64           `json:"value"`
+}
+
+// GetStateMetrics computes kube-state-metrics style gauges from the objects in a
+// namespace, or in all namespaces if namespace is empty, for clusters that do not run
+// kube-state-metrics: pods by phase, aggregated per namespace rather than reported per
+// pod; the desired, ready, available, updated, and unavailable replicas of each
+// Deployment; and the Ready condition and schedulability of each node, which are
+// always cluster-wide. Objects are listed from the API server's watch cache, so the
+// values may be slightly stale.
+// Returns the metrics sorted by name, or an error.
+func (c *Client) GetStateMetrics(ctx context.Context, namespace string) ([]StateMetric, error) {
+	cached := metav1.ListOptions{ResourceVersion: "0", ResourceVersionMatch: metav1.ResourceVersionMatchNotOlderThan}
+
+	pods, err := c.clientset().CoreV1().Pods(namespace).List(ctx, cached)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	deployments, err := c.clientset().AppsV1().Deployments(namespace).List(ctx, cached)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	nodes, err := c.clientset().CoreV1().Nodes().List(ctx, cached)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	// Every phase is reported for every namespace with pods, as kube-state-metrics
+	// reports every phase for every pod, so that absent phases read as 0
+	phases := []corev1.PodPhase{corev1.PodPending, corev1.PodRunning, corev1.PodSucceeded, corev1.PodFailed, corev1.PodUnknown}
+	podCounts := map[string]map[corev1.PodPhase]int{}
+	for _, pod := range pods.Items {
+		if podCounts[pod.Namespace] == nil {
+			podCounts[pod.Namespace] = map[corev1.PodPhase]int{}
+		}
+		phase := pod.Status.Phase
+		if phase == "" {
+			phase = corev1.PodUnknown
+		}
+		podCounts[pod.Namespace][phase]++
+	}
+	podPhase := StateMetric{Name: "kube_pod_status_phase_count", Help: "The number of pods in each phase, per namespace."}
+	for ns, counts := range podCounts {
+		for _, phase := range phases {
+			podPhase.Samples = append(podPhase.Samples, StateMetricSample{
+				Labels: map[string]string{"namespace": ns, "phase": string(phase)},
+				Value:  float64(counts[phase]),
+			})
+		}
+	}
+
+	specReplicas := StateMetric{Name: "kube_deployment_spec_replicas", Help: "Number of desired pods for a deployment."}
+	readyReplicas := StateMetric{Name: "kube_deployment_status_replicas_ready", Help: "The number of ready replicas per deployment."}
+	availableReplicas := StateMetric{Name: "kube_deployment_status_replicas_available", Help: "The number of available replicas per deployment."}
+	updatedReplicas := StateMetric{Name: "kube_deployment_status_replicas_updated", Help: "The number of updated replicas per deployment."}
+	unavailableReplicas := StateMetric{Name: "kube_deployment_status_replicas_unavailable", Help: "The number of unavailable replicas per deployment."}
+	for _, d := range deployments.Items {
+		labels := map[string]string{"namespace": d.Namespace, "deployment": d.Name}
+		desired := int32(1)
+		if d.Spec.Replicas != nil {
+			desired = *d.Spec.Replicas
+		}
+		specReplicas.Samples = append(specReplicas.Samples, StateMetricSample{Labels: labels, Value: float64(desired)})
+		readyReplicas.Samples = append(readyReplicas.Samples, StateMetricSample{Labels: labels, Value: float64(d.Status.ReadyReplicas)})
+		availableReplicas.Samples = append(availableReplicas.Samples, StateMetricSample{Labels: labels, Value: float64(d.Status.AvailableReplicas)})
+		updatedReplicas.Samples = append(updatedReplicas.Samples, StateMetricSample{Labels: labels, Value: float64(d.Status.UpdatedReplicas)})
+		unavailableReplicas.Samples = append(unavailableReplicas.Samples, StateMetricSample{Labels: labels, Value: float64(d.Status.UnavailableReplicas)})
+	}
+
+	nodeCondition := StateMetric{Name: "kube_node_status_condition", Help: "The condition of a cluster node."}
+	unschedulable := StateMetric{Name: "kube_node_spec_unschedulable", Help: "Whether a node can schedule new pods."}
+	for _, node := range nodes.Items {
+		ready := corev1.ConditionUnknown
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == corev1.NodeReady {
+				ready = condition.Status
+			}
+		}
+		for _, status := range []corev1.ConditionStatus{corev1.ConditionTrue, corev1.ConditionFalse, corev1.ConditionUnknown} {
+			nodeCondition.Samples = append(nodeCondition.Samples, StateMetricSample{
+				Labels: map[string]string{"node": node.Name, "condition": string(corev1.NodeReady), "status": strings.ToLower(string(status))},
+				Value:  boolValue(ready == status),
+			})
+		}
+		unschedulable.Samples = append(unschedulable.Samples, StateMetricSample{
+			Labels: map[string]string{"node": node.Name},
+			Value:  boolValue(node.Spec.Unschedulable),
+		})
+	}
+
+	metrics := []StateMetric{podPhase, specReplicas, readyReplicas, availableReplicas, updatedReplicas, unavailableReplicas, nodeCondition, unschedulable}
+	sort.Slice(metrics, func(i, j int) bool { return metrics[i].Name < metrics[j].Name })
+	for i := range metrics {
+		samples := metrics[i].Samples
+		if samples == nil {
+			metrics[i].Samples = []StateMetricSample{}
+		}
+		sort.Slice(samples, func(a, b int) bool { return labelString(samples[a].Labels) < labelString(samples[b].Labels) })
+	}
+	return metrics, nil
+}
+
+// FormatPrometheus renders metrics in the Prometheus text exposition format, each
+// with its HELP and TYPE lines.
+func FormatPrometheus(metrics []StateMetric) string {
+	var b strings.Builder
+	for _, metric := range metrics {
+		fmt.Fprintf(&b, "# HELP %s %s\n", metric.Name, metric.Help)
+		fmt.Fprintf(&b, "# TYPE %s gauge\n", metric.Name)
+		for _, sample := range metric.Samples {
+			fmt.Fprintf(&b, "%s%s %s\n", metric.Name, labelString(sample.Labels), strconv.FormatFloat(sample.Value, 'g', -1, 64))
+		}
+	}
+	return b.String()
+}
+
+// labelString renders labels as a Prometheus label set, such as {a="1",b="2"}, with
+// the names sorted and the values escaped, or "" if there are none.
+func labelString(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	pairs := make([]string, 0, len(names))
+	for _, name := range names {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, name, escaper.Replace(labels[name])))
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// boolValue returns 1 for true and 0 for false, as gauges represent booleans.
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
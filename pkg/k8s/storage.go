@@ -0,0 +1,89 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ListStorage returns a storage overview: PersistentVolumeClaims with their phase,
+// requested and actual capacity, storage class, and bound volume, followed by
+// PersistentVolumes that are not bound to any claim.
+// An empty namespace lists claims across all namespaces.
+// Returns a map containing claims and unbound volumes, or an error.
+func (c *Client) ListStorage(ctx context.Context, namespace string) (map[string]interface{}, error) {
+	pvcs, err := c.clientset().CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list persistent volume claims: %w", err)
+	}
+
+	pvs, err := c.clientset().CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list persistent volumes: %w", err)
+	}
+
+	volumes := make(map[string]*corev1.PersistentVolume, len(pvs.Items))
+	for i := range pvs.Items {
+		volumes[pvs.Items[i].Name] = &pvs.Items[i]
+	}
+
+	var claims []map[string]interface{}
+	pendingClaims := 0
+	for _, pvc := range pvcs.Items {
+		claim := map[string]interface{}{
+			"name":        pvc.Name,
+			"namespace":   pvc.Namespace,
+			"phase":       pvc.Status.Phase,
+			"volumeName":  pvc.Spec.VolumeName,
+			"accessModes": pvc.Spec.AccessModes,
+		}
+		if pvc.Spec.StorageClassName != nil {
+			claim["storageClass"] = *pvc.Spec.StorageClassName
+		}
+		if requested, ok := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; ok {
+			claim["requested"] = requested.String()
+		}
+		if capacity, ok := pvc.Status.Capacity[corev1.ResourceStorage]; ok {
+			claim["capacity"] = capacity.String()
+		}
+		if pv, ok := volumes[pvc.Spec.VolumeName]; ok {
+			claim["volume"] = map[string]interface{}{
+				"phase":         pv.Status.Phase,
+				"reclaimPolicy": pv.Spec.PersistentVolumeReclaimPolicy,
+			}
+		}
+		if pvc.Status.Phase == corev1.ClaimPending {
+			pendingClaims++
+		}
+		claims = append(claims, claim)
+	}
+
+	var unboundVolumes []map[string]interface{}
+	for _, pv := range pvs.Items {
+		if pv.Status.Phase == corev1.VolumeBound {
+			continue
+		}
+		volume := map[string]interface{}{
+			"name":          pv.Name,
+			"phase":         pv.Status.Phase,
+			"storageClass":  pv.Spec.StorageClassName,
+			"reclaimPolicy": pv.Spec.PersistentVolumeReclaimPolicy,
+			"reason":        pv.Status.Reason,
+		}
+		if capacity, ok := pv.Spec.Capacity[corev1.ResourceStorage]; ok {
+			volume["capacity"] = capacity.String()
+		}
+		if pv.Spec.ClaimRef != nil {
+			volume["previousClaim"] = pv.Spec.ClaimRef.Namespace + "/" + pv.Spec.ClaimRef.Name
+		}
+		unboundVolumes = append(unboundVolumes, volume)
+	}
+
+	return map[string]interface{}{
+		"claims":         claims,
+		"pendingClaims":  pendingClaims,
+		"unboundVolumes": unboundVolumes,
+	}, nil
+}
@@ -0,0 +1,198 @@
+package k8s
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// SuspendAnnotation is an annotation that tells a GitOps controller to stop
+// reconciling the object it is set on.
+type SuspendAnnotation struct {
+	Key   string
+	Value string
+}
+
+// DefaultSuspendAnnotations are set by SuspendResource unless others are configured:
+// Flux's kustomize-controller skips objects with reconciliation disabled, and Argo CD
+// skips reconciling Applications marked with skip-reconcile.
+var DefaultSuspendAnnotations = []SuspendAnnotation{
+	{Key: "kustomize.toolkit.fluxcd.io/reconcile", Value: "disabled"},
+	{Key: "argocd.argoproj.io/skip-reconcile", Value: "true"},
+}
+
+// gitOpsOwnerMarkers are labels and annotations by which GitOps controllers mark the
+// objects they manage, used to report which controller is likely to revert changes.
+var gitOpsOwnerMarkers = map[string]string{
+	"kustomize.toolkit.fluxcd.io/name": "Flux Kustomization",
+	"helm.toolkit.fluxcd.io/name":      "Flux HelmRelease",
+	"argocd.argoproj.io/instance":      "Argo CD Application",
+	"argocd.argoproj.io/tracking-id":   "Argo CD Application",
+}
+
+// ParseSuspendAnnotations parses a comma-separated list of "key=value" annotations,
+// e.g. "kustomize.toolkit.fluxcd.io/reconcile=disabled,example.com/paused=true".
+func ParseSuspendAnnotations(value string) ([]SuspendAnnotation, error) {
+	var annotations []SuspendAnnotation
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		key, val, ok := strings.Cut(entry, "=")
+		if !ok || key == "" || val == "" {
+			return nil, fmt.Errorf("invalid suspend annotation '%s': expected 'key=value'", entry)
+		}
+		annotations = append(annotations, SuspendAnnotation{Key: key, Value: val})
+	}
+	return annotations, nil
+}
+
+// SetSuspendAnnotations replaces the annotations SuspendResource sets and
+// ResumeResource removes.
+func (c *Client) SetSuspendAnnotations(annotations []SuspendAnnotation) {
+	c.suspendAnnotations = annotations
+}
+
+// suspendAnnotationSet returns the configured suspend annotations, or the defaults.
+func (c *Client) suspendAnnotationSet() []SuspendAnnotation {
+	if len(c.suspendAnnotations) > 0 {
+		return c.suspendAnnotations
+	}
+	return DefaultSuspendAnnotations
+}
+
+// SuspendResource sets the suspend annotations on an object so that GitOps
+// controllers stop reverting manual changes to it, e.g. during an emergency fix.
+// Only the object's annotations are changed.
+// Returns a map describing the annotations set and the GitOps controllers that
+// appear to manage the object, or an error.
+func (c *Client) SuspendResource(ctx context.Context, kind, name, namespace string) (map[string]interface{}, error) {
+	kind = c.resolveKind(kind)
+	obj, err := c.getObject(ctx, kind, name, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s '%s': %w", kind, name, err)
+	}
+
+	annotations := map[string]interface{}{}
+	for _, annotation := range c.suspendAnnotationSet() {
+		annotations[annotation.Key] = annotation.Value
+	}
+	if err := c.patchAnnotations(ctx, obj, annotations); err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"kind":      kind,
+		"name":      obj.GetName(),
+		"namespace": obj.GetNamespace(),
+		"suspended": true,
+		"set":       annotations,
+		"managedBy": gitOpsOwners(obj),
+	}, nil
+}
+
+// ResumeResource removes the suspend annotations SuspendResource set from an object,
+// handing it back to its GitOps controllers, which revert any changes made meanwhile
+// on their next reconciliation. An annotation whose value was changed since is left
+// in place, since it was not set by SuspendResource.
+// Returns a map describing the annotations removed and kept, or an error.
+func (c *Client) ResumeResource(ctx context.Context, kind, name, namespace string) (map[string]interface{}, error) {
+	kind = c.resolveKind(kind)
+	obj, err := c.getObject(ctx, kind, name, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s '%s': %w", kind, name, err)
+	}
+
+	current := obj.GetAnnotations()
+	patch := map[string]interface{}{}
+	removed := []string{}
+	kept := map[string]string{}
+	for _, annotation := range c.suspendAnnotationSet() {
+		value, ok := current[annotation.Key]
+		switch {
+		case !ok:
+		case value == annotation.Value:
+			// A null value removes the key in a merge patch
+			patch[annotation.Key] = nil
+			removed = append(removed, annotation.Key)
+		default:
+			kept[annotation.Key] = value
+		}
+	}
+	if len(patch) > 0 {
+		if err := c.patchAnnotations(ctx, obj, patch); err != nil {
+			return nil, err
+		}
+	}
+
+	result := map[string]interface{}{
+		"kind":      kind,
+		"name":      obj.GetName(),
+		"namespace": obj.GetNamespace(),
+		"resumed":   len(removed) > 0,
+		"removed":   removed,
+		"managedBy": gitOpsOwners(obj),
+	}
+	if len(kept) > 0 {
+		result["kept"] = kept
+	}
+	if len(removed) == 0 {
+		result["message"] = fmt.Sprintf("%s '%s' has none of the suspend annotations; nothing to resume", kind, name)
+	}
+	return result, nil
+}
+
+// patchAnnotations applies a merge patch to an object's annotations.
+func (c *Client) patchAnnotations(ctx context.Context, obj *unstructured.Unstructured, annotations map[string]interface{}) error {
+	kind := obj.GetKind()
+	gvr, err := c.getCachedGVR(kind)
+	if err != nil {
+		return err
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{"annotations": annotations},
+	})
+	if err != nil {
+		return err
+	}
+
+	if obj.GetNamespace() != "" {
+		_, err = c.dynamicClient().Resource(*gvr).Namespace(obj.GetNamespace()).Patch(ctx, obj.GetName(), types.MergePatchType, patch, metav1.PatchOptions{})
+	} else {
+		_, err = c.dynamicClient().Resource(*gvr).Patch(ctx, obj.GetName(), types.MergePatchType, patch, metav1.PatchOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update annotations of %s '%s': %w", kind, obj.GetName(), err)
+	}
+	return nil
+}
+
+// gitOpsOwners returns the GitOps controllers whose labels or annotations mark an
+// object as managed by them, with the name of the managing object.
+func gitOpsOwners(obj *unstructured.Unstructured) []string {
+	owners := []string{}
+	seen := map[string]bool{}
+	for _, markers := range []map[string]string{obj.GetLabels(), obj.GetAnnotations()} {
+		for key, controller := range gitOpsOwnerMarkers {
+			value, ok := markers[key]
+			if !ok || seen[controller] {
+				continue
+			}
+			seen[controller] = true
+			if key == "argocd.argoproj.io/tracking-id" {
+				// The tracking ID has the form <application>:<group>/<kind>:<namespace>/<name>
+				value, _, _ = strings.Cut(value, ":")
+			}
+			owners = append(owners, fmt.Sprintf("%s '%s'", controller, value))
+		}
+	}
+	sort.Strings(owners)
+	return owners
+}
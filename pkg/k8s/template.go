@@ -0,0 +1,49 @@
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"text/template"
+)
+
+// CreateFromTemplate renders a manifest containing Go template placeholders
+// (e.g. {{ .tenant }}) with the given variables and applies the result.
+// Referencing a variable that is not provided is an error, and the rendered output
+// must parse as YAML or JSON documents with a kind and name before anything is applied.
+// Namespaced objects without a namespace are placed in the given namespace.
+// Returns a map listing the applied objects and any per-object errors, or an error.
+func (c *Client) CreateFromTemplate(ctx context.Context, namespace, manifestTemplate string, variables map[string]interface{}) (map[string]interface{}, error) {
+	tmpl, err := template.New("manifest").Option("missingkey=error").Parse(manifestTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, variables); err != nil {
+		return nil, fmt.Errorf("failed to render template: %w", err)
+	}
+
+	objects, err := decodeManifests(rendered.String())
+	if err != nil {
+		return nil, fmt.Errorf("rendered template is not a valid manifest: %w", err)
+	}
+	if len(objects) == 0 {
+		return nil, fmt.Errorf("rendered template contains no resources")
+	}
+
+	var appliedObjects []string
+	var errs []string
+	for _, obj := range objects {
+		if err := c.applyObject(ctx, namespace, obj); err != nil {
+			errs = append(errs, fmt.Sprintf("%s/%s: %v", obj.GetKind(), obj.GetName(), err))
+			continue
+		}
+		appliedObjects = append(appliedObjects, objectKey(obj.GetKind(), obj.GetNamespace(), obj.GetName()))
+	}
+
+	return map[string]interface{}{
+		"applied": appliedObjects,
+		"errors":  errs,
+	}, nil
+}
@@ -0,0 +1,68 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+)
+
+// ParseFieldValidation validates a server-side field validation level, case-insensitively:
+// Ignore drops unknown and duplicate fields silently, Warn drops them and returns a
+// warning for each, and Strict rejects the request. An empty value leaves the choice
+// to the API server, which defaults to Warn.
+func ParseFieldValidation(value string) (string, error) {
+	for _, level := range []string{metav1.FieldValidationIgnore, metav1.FieldValidationWarn, metav1.FieldValidationStrict} {
+		if strings.EqualFold(value, level) {
+			return level, nil
+		}
+	}
+	if value == "" {
+		return "", nil
+	}
+	return "", fmt.Errorf("invalid field validation '%s': expected Ignore, Warn, or Strict", value)
+}
+
+// warningCollectorKey is the context key under which CollectWarnings stores its collector.
+type warningCollectorKey struct{}
+
+// warningCollector accumulates the warnings returned for the requests of one call.
+type warningCollector struct {
+	mu       sync.Mutex
+	warnings []string
+}
+
+// CollectWarnings returns a context that records the warnings the API server returns
+// for requests made with it, such as those for unknown fields with Warn field
+// validation, and a function that returns the warnings recorded so far.
+func CollectWarnings(ctx context.Context) (context.Context, func() []string) {
+	collector := &warningCollector{}
+	return context.WithValue(ctx, warningCollectorKey{}, collector), func() []string {
+		collector.mu.Lock()
+		defer collector.mu.Unlock()
+		return append([]string(nil), collector.warnings...)
+	}
+}
+
+// contextWarningHandler records API server warnings in the collector of the request's
+// context, if it has one, and logs them otherwise.
+type contextWarningHandler struct{}
+
+// HandleWarningHeaderWithContext implements rest.WarningHandlerWithContext.
+func (contextWarningHandler) HandleWarningHeaderWithContext(ctx context.Context, code int, agent, message string) {
+	collector, ok := ctx.Value(warningCollectorKey{}).(*warningCollector)
+	if !ok {
+		rest.WarningLogger{}.HandleWarningHeaderWithContext(ctx, code, agent, message)
+		return
+	}
+	// Only code 299 is used for warnings meant for the client
+	if code != 299 || message == "" {
+		return
+	}
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+	collector.warnings = append(collector.warnings, message)
+}
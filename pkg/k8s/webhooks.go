@@ -0,0 +1,176 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ListWebhooks lists the admission webhooks registered in the cluster, from both
+// ValidatingWebhookConfigurations and MutatingWebhookConfigurations. Each webhook
+// reports the operations and resources its rules intercept, its failure policy,
+// timeout, side effects, namespace and object selectors, and where it is served. For
+// webhooks backed by an in-cluster Service, the Service is checked for ready
+// endpoints, since a webhook with failurePolicy Fail and no ready backends rejects
+// every request it matches.
+// Returns a slice of maps, each describing a webhook, or an error.
+func (c *Client) ListWebhooks(ctx context.Context) ([]map[string]interface{}, error) {
+	validating, err := c.clientset().AdmissionregistrationV1().ValidatingWebhookConfigurations().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list validating webhook configurations: %w", err)
+	}
+	mutating, err := c.clientset().AdmissionregistrationV1().MutatingWebhookConfigurations().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list mutating webhook configurations: %w", err)
+	}
+
+	backends := map[string]map[string]interface{}{}
+	webhooks := []map[string]interface{}{}
+	add := func(kind, configuration, name string, rules []admissionregistrationv1.RuleWithOperations, clientConfig admissionregistrationv1.WebhookClientConfig,
+		failurePolicy *admissionregistrationv1.FailurePolicyType, matchPolicy *admissionregistrationv1.MatchPolicyType, sideEffects *admissionregistrationv1.SideEffectClass,
+		timeoutSeconds *int32, namespaceSelector, objectSelector *metav1.LabelSelector) map[string]interface{} {
+		webhook := map[string]interface{}{
+			"kind":          kind,
+			"configuration": configuration,
+			"name":          name,
+			"rules":         webhookRules(rules),
+			// The API server defaults these, but report the defaults if they are unset
+			"failurePolicy":  "Fail",
+			"matchPolicy":    "Equivalent",
+			"timeoutSeconds": int32(10),
+		}
+		if failurePolicy != nil {
+			webhook["failurePolicy"] = string(*failurePolicy)
+		}
+		if matchPolicy != nil {
+			webhook["matchPolicy"] = string(*matchPolicy)
+		}
+		if timeoutSeconds != nil {
+			webhook["timeoutSeconds"] = *timeoutSeconds
+		}
+		if sideEffects != nil {
+			webhook["sideEffects"] = string(*sideEffects)
+		}
+		if namespaceSelector != nil && (len(namespaceSelector.MatchLabels) > 0 || len(namespaceSelector.MatchExpressions) > 0) {
+			webhook["namespaceSelector"] = metav1.FormatLabelSelector(namespaceSelector)
+		}
+		if objectSelector != nil && (len(objectSelector.MatchLabels) > 0 || len(objectSelector.MatchExpressions) > 0) {
+			webhook["objectSelector"] = metav1.FormatLabelSelector(objectSelector)
+		}
+
+		if clientConfig.URL != nil {
+			webhook["url"] = *clientConfig.URL
+		}
+		if service := clientConfig.Service; service != nil {
+			port := int32(443)
+			if service.Port != nil {
+				port = *service.Port
+			}
+			target := fmt.Sprintf("%s/%s:%d", service.Namespace, service.Name, port)
+			if service.Path != nil {
+				target += *service.Path
+			}
+			webhook["service"] = target
+
+			key := service.Namespace + "/" + service.Name
+			backend, ok := backends[key]
+			if !ok {
+				backend = c.webhookBackend(ctx, service.Namespace, service.Name)
+				backends[key] = backend
+			}
+			webhook["backend"] = backend
+			if ready, _ := backend["readyEndpoints"].(int); ready == 0 && webhook["failurePolicy"] == "Fail" {
+				webhook["warning"] = "the webhook service has no ready endpoints and failurePolicy is Fail, so every matching request is rejected"
+			}
+		}
+		webhooks = append(webhooks, webhook)
+		return webhook
+	}
+
+	for _, config := range validating.Items {
+		for _, hook := range config.Webhooks {
+			add("Validating", config.Name, hook.Name, hook.Rules, hook.ClientConfig, hook.FailurePolicy, hook.MatchPolicy, hook.SideEffects,
+				hook.TimeoutSeconds, hook.NamespaceSelector, hook.ObjectSelector)
+		}
+	}
+	for _, config := range mutating.Items {
+		for _, hook := range config.Webhooks {
+			webhook := add("Mutating", config.Name, hook.Name, hook.Rules, hook.ClientConfig, hook.FailurePolicy, hook.MatchPolicy, hook.SideEffects,
+				hook.TimeoutSeconds, hook.NamespaceSelector, hook.ObjectSelector)
+			webhook["reinvocationPolicy"] = "Never"
+			if hook.ReinvocationPolicy != nil {
+				webhook["reinvocationPolicy"] = string(*hook.ReinvocationPolicy)
+			}
+		}
+	}
+
+	sort.SliceStable(webhooks, func(i, j int) bool {
+		if webhooks[i]["kind"] != webhooks[j]["kind"] {
+			return webhooks[i]["kind"].(string) < webhooks[j]["kind"].(string)
+		}
+		if webhooks[i]["configuration"] != webhooks[j]["configuration"] {
+			return webhooks[i]["configuration"].(string) < webhooks[j]["configuration"].(string)
+		}
+		return webhooks[i]["name"].(string) < webhooks[j]["name"].(string)
+	})
+	return webhooks, nil
+}
+
+// webhookRules renders webhook rules as the operations and resources they intercept.
+func webhookRules(rules []admissionregistrationv1.RuleWithOperations) []map[string]interface{} {
+	out := []map[string]interface{}{}
+	for _, rule := range rules {
+		entry := map[string]interface{}{
+			"operations":  rule.Operations,
+			"apiGroups":   rule.APIGroups,
+			"apiVersions": rule.APIVersions,
+			"resources":   rule.Resources,
+			"scope":       "*",
+		}
+		if rule.Scope != nil {
+			entry["scope"] = string(*rule.Scope)
+		}
+		out = append(out, entry)
+	}
+	return out
+}
+
+// webhookBackend reports whether a webhook's Service exists and how many ready
+// endpoints back it. Lookup failures are reported in the result rather than failing
+// the listing.
+func (c *Client) webhookBackend(ctx context.Context, namespace, name string) map[string]interface{} {
+	backend := map[string]interface{}{}
+	if _, err := c.clientset().CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{}); err != nil {
+		if errors.IsNotFound(err) {
+			backend["serviceExists"] = false
+			backend["readyEndpoints"] = 0
+		} else {
+			backend["error"] = fmt.Sprintf("failed to get service: %v", err)
+		}
+		return backend
+	}
+	backend["serviceExists"] = true
+
+	slices, err := c.clientset().DiscoveryV1().EndpointSlices(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: discoveryv1.LabelServiceName + "=" + name,
+	})
+	if err != nil {
+		backend["error"] = fmt.Sprintf("failed to list endpoints: %v", err)
+		return backend
+	}
+	ready := 0
+	for _, slice := range slices.Items {
+		for _, endpoint := range slice.Endpoints {
+			if endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready {
+				ready++
+			}
+		}
+	}
+	backend["readyEndpoints"] = ready
+	return backend
+}
@@ -0,0 +1,119 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetWorkloadReadiness lists Deployments, StatefulSets, and DaemonSets and returns those
+// whose ready replica count differs from the desired count, with the shortfall and the
+// workload's age. An empty namespace checks all namespaces.
+// Returns a slice of maps, each describing an unhealthy workload, or an error.
+func (c *Client) GetWorkloadReadiness(ctx context.Context, namespace string) ([]map[string]interface{}, error) {
+	unhealthy := []map[string]interface{}{}
+	add := func(kind string, meta metav1.ObjectMeta, desired, ready, updated, available int32) {
+		if ready == desired {
+			return
+		}
+		unhealthy = append(unhealthy, map[string]interface{}{
+			"kind":      kind,
+			"name":      meta.Name,
+			"namespace": meta.Namespace,
+			"desired":   desired,
+			"ready":     ready,
+			"updated":   updated,
+			"available": available,
+			"shortfall": desired - ready,
+			"age":       time.Since(meta.CreationTimestamp.Time).Round(time.Second).String(),
+		})
+	}
+
+	deployments, err := c.clientset().AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	for _, d := range deployments.Items {
+		desired := int32(1)
+		if d.Spec.Replicas != nil {
+			desired = *d.Spec.Replicas
+		}
+		add("Deployment", d.ObjectMeta, desired, d.Status.ReadyReplicas, d.Status.UpdatedReplicas, d.Status.AvailableReplicas)
+	}
+
+	statefulSets, err := c.clientset().AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list statefulsets: %w", err)
+	}
+	for _, s := range statefulSets.Items {
+		desired := int32(1)
+		if s.Spec.Replicas != nil {
+			desired = *s.Spec.Replicas
+		}
+		add("StatefulSet", s.ObjectMeta, desired, s.Status.ReadyReplicas, s.Status.UpdatedReplicas, s.Status.AvailableReplicas)
+	}
+
+	daemonSets, err := c.clientset().AppsV1().DaemonSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list daemonsets: %w", err)
+	}
+	for _, ds := range daemonSets.Items {
+		add("DaemonSet", ds.ObjectMeta, ds.Status.DesiredNumberScheduled, ds.Status.NumberReady, ds.Status.UpdatedNumberScheduled, ds.Status.NumberAvailable)
+	}
+
+	return unhealthy, nil
+}
+
+// RestartNamespaceWorkloads performs a rollout restart of every Deployment, StatefulSet,
+// and DaemonSet in a namespace that matches the label selector (all when empty). When
+// dryRun is true nothing is restarted and the workloads that would be are listed.
+// A failure to restart one workload does not stop the others.
+// Returns a slice of maps with the result for each workload, or an error.
+func (c *Client) RestartNamespaceWorkloads(ctx context.Context, namespace, labelSelector string, dryRun bool) ([]map[string]interface{}, error) {
+	options := metav1.ListOptions{LabelSelector: labelSelector}
+
+	type workload struct{ kind, name string }
+	var workloads []workload
+
+	deployments, err := c.clientset().AppsV1().Deployments(namespace).List(ctx, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+	for _, d := range deployments.Items {
+		workloads = append(workloads, workload{"Deployment", d.Name})
+	}
+	statefulSets, err := c.clientset().AppsV1().StatefulSets(namespace).List(ctx, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list statefulsets: %w", err)
+	}
+	for _, s := range statefulSets.Items {
+		workloads = append(workloads, workload{"StatefulSet", s.Name})
+	}
+	daemonSets, err := c.clientset().AppsV1().DaemonSets(namespace).List(ctx, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list daemonsets: %w", err)
+	}
+	for _, d := range daemonSets.Items {
+		workloads = append(workloads, workload{"DaemonSet", d.Name})
+	}
+
+	results := []map[string]interface{}{}
+	for _, w := range workloads {
+		entry := map[string]interface{}{
+			"kind":      w.kind,
+			"name":      w.name,
+			"restarted": false,
+		}
+		if !dryRun {
+			if _, err := c.RolloutRestart(ctx, w.kind, w.name, namespace); err != nil {
+				entry["error"] = err.Error()
+			} else {
+				entry["restarted"] = true
+			}
+		}
+		results = append(results, entry)
+	}
+	return results, nil
+}
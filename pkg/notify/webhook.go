@@ -0,0 +1,169 @@
+// Package notify posts notifications about mutating tool calls to a webhook, so that
+// changes made through the server show up in chat channels or audit systems.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// queueSize bounds the number of pending notifications; further events are
+	// dropped rather than slowing down tool calls.
+	queueSize = 100
+	// maxAttempts is the number of delivery attempts per notification.
+	maxAttempts = 3
+)
+
+// identifyingArguments are the tool arguments that name the object a call acted on.
+// Only these are sent, since others can hold manifests, Secret data, Helm values, or
+// whole chart archives.
+var identifyingArguments = []string{
+	"kind", "name", "namespace", "releaseName", "podName", "nodeName", "containerName",
+	"sourceNamespace", "targetNamespace", "targetName", "finalizer", "repoName", "operation",
+}
+
+// Event describes a completed mutating tool call. Its Arguments are only those in
+// identifyingArguments.
+type Event struct {
+	// Text is a one-line summary, rendered by Slack and Teams incoming webhooks.
+	Text      string                 `json:"text"`
+	Tool      string                 `json:"tool"`
+	Arguments map[string]interface{} `json:"arguments,omitempty"`
+	Success   bool                   `json:"success"`
+	Error     string                 `json:"error,omitempty"`
+	Timestamp time.Time              `json:"timestamp"`
+}
+
+// WebhookNotifier delivers events to a webhook URL from a background goroutine.
+type WebhookNotifier struct {
+	url        string
+	httpClient *http.Client
+	events     chan Event
+}
+
+// NewWebhookNotifier creates a notifier for the given URL and starts its delivery loop.
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	n := &WebhookNotifier{
+		url:        url,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		events:     make(chan Event, queueSize),
+	}
+	go n.run()
+	return n
+}
+
+// Notify queues an event for delivery without blocking. If the queue is full the
+// event is dropped and logged.
+func (n *WebhookNotifier) Notify(event Event) {
+	select {
+	case n.events <- event:
+	default:
+		log.Printf("notify: queue full, dropping notification for tool '%s'", event.Tool)
+	}
+}
+
+// run delivers queued events, retrying failed deliveries with exponential backoff.
+func (n *WebhookNotifier) run() {
+	for event := range n.events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			log.Printf("notify: failed to encode notification for tool '%s': %v", event.Tool, err)
+			continue
+		}
+
+		backoff := time.Second
+		for attempt := 1; attempt <= maxAttempts; attempt++ {
+			if err = n.send(payload); err == nil {
+				break
+			}
+			if attempt < maxAttempts {
+				time.Sleep(backoff)
+				backoff *= 2
+			}
+		}
+		if err != nil {
+			log.Printf("notify: giving up on notification for tool '%s' after %d attempts: %v", event.Tool, maxAttempts, err)
+		}
+	}
+}
+
+// send posts a single payload to the webhook.
+func (n *WebhookNotifier) send(payload []byte) error {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, n.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// ToolMiddleware notifies the webhook after every call to a tool that is not
+// annotated as read-only, whether the call succeeded or failed. Calls to tools with a
+// confirm parameter that do not set it are previews that change nothing and are not
+// reported. lookup resolves a tool name to its definition.
+func (n *WebhookNotifier) ToolMiddleware(lookup func(name string) (mcp.Tool, bool)) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			result, err := next(ctx, request)
+
+			tool, found := lookup(request.Params.Name)
+			if found && tool.Annotations.ReadOnlyHint != nil && *tool.Annotations.ReadOnlyHint {
+				return result, err
+			}
+			args, _ := request.Params.Arguments.(map[string]interface{})
+			if _, hasConfirm := tool.InputSchema.Properties["confirm"]; found && hasConfirm {
+				if confirm, _ := args["confirm"].(bool); !confirm {
+					return result, err
+				}
+			}
+
+			event := Event{
+				Tool:      request.Params.Name,
+				Success:   err == nil && (result == nil || !result.IsError),
+				Timestamp: time.Now().UTC(),
+			}
+			for _, key := range identifyingArguments {
+				if value, ok := args[key].(string); ok && value != "" {
+					if event.Arguments == nil {
+						event.Arguments = map[string]interface{}{}
+					}
+					event.Arguments[key] = value
+				}
+			}
+			if err != nil {
+				event.Error = err.Error()
+			}
+			status := "succeeded"
+			if !event.Success {
+				status = "failed"
+			}
+			event.Text = fmt.Sprintf("k8s-mcp-server: %s %s", event.Tool, status)
+			if name, ok := event.Arguments["name"].(string); ok && name != "" {
+				event.Text = fmt.Sprintf("k8s-mcp-server: %s %s for '%s'", event.Tool, status, name)
+			} else if name, ok := event.Arguments["releaseName"].(string); ok && name != "" {
+				event.Text = fmt.Sprintf("k8s-mcp-server: %s %s for release '%s'", event.Tool, status, name)
+			}
+
+			n.Notify(event)
+			return result, err
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package observability
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// AlertmanagerClient queries an Alertmanager v2 HTTP API.
+type AlertmanagerClient struct {
+	api *httpAPI
+}
+
+// NewAlertmanagerClient creates a client for the Alertmanager API at the given endpoint.
+func NewAlertmanagerClient(endpoint Endpoint) (*AlertmanagerClient, error) {
+	api, err := newHTTPAPI(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure Alertmanager client: %w", err)
+	}
+	return &AlertmanagerClient{api: api}, nil
+}
+
+// alertmanagerAlert is a single alert as returned by GET /api/v2/alerts.
+type alertmanagerAlert struct {
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations"`
+	StartsAt     time.Time         `json:"startsAt"`
+	EndsAt       time.Time         `json:"endsAt"`
+	GeneratorURL string            `json:"generatorURL"`
+	Fingerprint  string            `json:"fingerprint"`
+	Status       struct {
+		State       string   `json:"state"`
+		SilencedBy  []string `json:"silencedBy"`
+		InhibitedBy []string `json:"inhibitedBy"`
+	} `json:"status"`
+}
+
+// GetActiveAlerts returns the alerts currently firing in Alertmanager, newest first.
+// Matchers use Alertmanager's filter syntax (e.g. severity="critical", namespace=~"prod-.*")
+// and are combined with AND. Silenced and inhibited alerts are excluded unless
+// includeSuppressed is true.
+// Returns a slice of maps, each describing an alert, or an error.
+func (c *AlertmanagerClient) GetActiveAlerts(ctx context.Context, matchers []string, includeSuppressed bool) ([]map[string]interface{}, error) {
+	params := url.Values{
+		"active":    {"true"},
+		"silenced":  {strconv.FormatBool(includeSuppressed)},
+		"inhibited": {strconv.FormatBool(includeSuppressed)},
+	}
+	for _, matcher := range matchers {
+		params.Add("filter", matcher)
+	}
+
+	var alerts []alertmanagerAlert
+	if err := c.api.getJSON(ctx, "/api/v2/alerts", params, &alerts); err != nil {
+		return nil, fmt.Errorf("failed to query Alertmanager: %w", err)
+	}
+
+	sort.SliceStable(alerts, func(i, j int) bool {
+		return alerts[i].StartsAt.After(alerts[j].StartsAt)
+	})
+
+	result := []map[string]interface{}{}
+	for _, alert := range alerts {
+		entry := map[string]interface{}{
+			"name":        alert.Labels["alertname"],
+			"severity":    alert.Labels["severity"],
+			"state":       alert.Status.State,
+			"labels":      alert.Labels,
+			"annotations": alert.Annotations,
+			"startsAt":    alert.StartsAt,
+			"fingerprint": alert.Fingerprint,
+		}
+		if alert.GeneratorURL != "" {
+			entry["generatorURL"] = alert.GeneratorURL
+		}
+		if len(alert.Status.SilencedBy) > 0 {
+			entry["silencedBy"] = alert.Status.SilencedBy
+		}
+		if len(alert.Status.InhibitedBy) > 0 {
+			entry["inhibitedBy"] = alert.Status.InhibitedBy
+		}
+		result = append(result, entry)
+	}
+	return result, nil
+}
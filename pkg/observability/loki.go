@@ -0,0 +1,106 @@
+package observability
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// LokiClient queries a Loki HTTP API.
+type LokiClient struct {
+	api *httpAPI
+}
+
+// NewLokiClient creates a client for the Loki API at the given endpoint.
+func NewLokiClient(endpoint Endpoint) (*LokiClient, error) {
+	api, err := newHTTPAPI(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure Loki client: %w", err)
+	}
+	return &LokiClient{api: api}, nil
+}
+
+// lokiResponse is the envelope returned by Loki's query_range API for log queries.
+type lokiResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Stream map[string]string `json:"stream"`
+			Values [][2]string       `json:"values"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// QueryLogs runs a LogQL log query between start and end and returns up to limit
+// matching lines across all streams, newest first. A zero start defaults to one hour
+// before end, and a zero end to now.
+// Returns a slice of maps, each holding a line with its timestamp and stream labels, or an error.
+func (c *LokiClient) QueryLogs(ctx context.Context, query string, start, end time.Time, limit int) ([]map[string]interface{}, error) {
+	if query == "" {
+		return nil, fmt.Errorf("query is required")
+	}
+	if end.IsZero() {
+		end = time.Now()
+	}
+	if start.IsZero() {
+		start = end.Add(-time.Hour)
+	}
+	if limit <= 0 {
+		limit = 100
+	}
+
+	params := url.Values{
+		"query":     {query},
+		"start":     {strconv.FormatInt(start.UnixNano(), 10)},
+		"end":       {strconv.FormatInt(end.UnixNano(), 10)},
+		"limit":     {strconv.Itoa(limit)},
+		"direction": {"backward"},
+	}
+
+	var response lokiResponse
+	if err := c.api.getJSON(ctx, "/loki/api/v1/query_range", params, &response); err != nil {
+		return nil, fmt.Errorf("failed to query Loki: %w", err)
+	}
+	if response.Status != "success" {
+		return nil, fmt.Errorf("loki query failed with status '%s'", response.Status)
+	}
+	if response.Data.ResultType != "streams" {
+		return nil, fmt.Errorf("query returned %s rather than log lines; use queryMetrics for metric queries", response.Data.ResultType)
+	}
+
+	type logLine struct {
+		timestamp int64
+		labels    map[string]string
+		line      string
+	}
+	var lines []logLine
+	for _, stream := range response.Data.Result {
+		for _, value := range stream.Values {
+			timestamp, err := strconv.ParseInt(value[0], 10, 64)
+			if err != nil {
+				continue
+			}
+			lines = append(lines, logLine{timestamp: timestamp, labels: stream.Stream, line: value[1]})
+		}
+	}
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].timestamp > lines[j].timestamp
+	})
+	if len(lines) > limit {
+		lines = lines[:limit]
+	}
+
+	result := []map[string]interface{}{}
+	for _, l := range lines {
+		result = append(result, map[string]interface{}{
+			"timestamp": time.Unix(0, l.timestamp).UTC().Format(time.RFC3339Nano),
+			"labels":    l.labels,
+			"line":      l.line,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,90 @@
+package observability
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// PrometheusClient queries a Prometheus-compatible HTTP API (Prometheus, Thanos, Mimir).
+type PrometheusClient struct {
+	api *httpAPI
+}
+
+// NewPrometheusClient creates a client for the Prometheus API at the given endpoint.
+func NewPrometheusClient(endpoint Endpoint) (*PrometheusClient, error) {
+	api, err := newHTTPAPI(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure Prometheus client: %w", err)
+	}
+	return &PrometheusClient{api: api}, nil
+}
+
+// prometheusResponse is the envelope returned by the Prometheus query APIs.
+type prometheusResponse struct {
+	Status    string                 `json:"status"`
+	Data      map[string]interface{} `json:"data"`
+	ErrorType string                 `json:"errorType"`
+	Error     string                 `json:"error"`
+	Warnings  []string               `json:"warnings"`
+}
+
+// QueryPrometheus runs a PromQL query. With a zero start it evaluates an instant
+// query at end (or now when end is also zero); otherwise it evaluates a range query
+// between start and end at the given step, defaulting the step so the range yields
+// roughly 60 points.
+// Returns a map with the result type, the result, and any warnings, or an error.
+func (c *PrometheusClient) QueryPrometheus(ctx context.Context, query string, start, end time.Time, step time.Duration) (map[string]interface{}, error) {
+	if query == "" {
+		return nil, fmt.Errorf("query is required")
+	}
+
+	params := url.Values{"query": {query}}
+	path := "/api/v1/query"
+	if start.IsZero() {
+		if !end.IsZero() {
+			params.Set("time", formatPrometheusTime(end))
+		}
+	} else {
+		if end.IsZero() {
+			end = time.Now()
+		}
+		if !end.After(start) {
+			return nil, fmt.Errorf("range end must be after start")
+		}
+		if step <= 0 {
+			step = end.Sub(start) / 60
+			if step < time.Second {
+				step = time.Second
+			}
+		}
+		path = "/api/v1/query_range"
+		params.Set("start", formatPrometheusTime(start))
+		params.Set("end", formatPrometheusTime(end))
+		params.Set("step", strconv.FormatFloat(step.Seconds(), 'f', -1, 64))
+	}
+
+	var response prometheusResponse
+	if err := c.api.getJSON(ctx, path, params, &response); err != nil {
+		return nil, fmt.Errorf("failed to query Prometheus: %w", err)
+	}
+	if response.Status != "success" {
+		return nil, fmt.Errorf("prometheus query failed (%s): %s", response.ErrorType, response.Error)
+	}
+
+	result := map[string]interface{}{
+		"resultType": response.Data["resultType"],
+		"result":     response.Data["result"],
+	}
+	if len(response.Warnings) > 0 {
+		result["warnings"] = response.Warnings
+	}
+	return result, nil
+}
+
+// formatPrometheusTime formats a time as fractional Unix seconds.
+func formatPrometheusTime(t time.Time) string {
+	return strconv.FormatFloat(float64(t.UnixNano())/1e9, 'f', 3, 64)
+}
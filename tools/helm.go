@@ -9,7 +9,8 @@ func HelmInstallTool() mcp.Tool {
 	return mcp.NewTool("helmInstall",
 		mcp.WithDescription("Install a Helm chart to the Kubernetes cluster"),
 		mcp.WithString("releaseName", mcp.Required(), mcp.Description("Name of the Helm release")),
-		mcp.WithString("chartName", mcp.Required(), mcp.Description("Name or path of the Helm chart")),
+		mcp.WithString("chartName", mcp.Description("Name or path of the Helm chart (required unless chartData is given)")),
+		mcp.WithString("chartData", mcp.Description("A packaged chart archive (.tgz, as produced by 'helm package'), base64-encoded, for charts not published to a repository; takes precedence over chartName")),
 		mcp.WithString("namespace", mcp.Description("Kubernetes namespace for the release")),
 		mcp.WithString("repoURL", mcp.Description("Helm repository URL (optional)")),
 		mcp.WithObject("values", mcp.Description("Values to override in the chart")),
@@ -25,7 +26,8 @@ func HelmUpgradeTool() mcp.Tool {
 	return mcp.NewTool("helmUpgrade",
 		mcp.WithDescription("Upgrade an existing Helm release"),
 		mcp.WithString("releaseName", mcp.Required(), mcp.Description("Name of the Helm release to upgrade")),
-		mcp.WithString("chartName", mcp.Required(), mcp.Description("Name or path of the Helm chart")),
+		mcp.WithString("chartName", mcp.Description("Name or path of the Helm chart (required unless chartData is given)")),
+		mcp.WithString("chartData", mcp.Description("A packaged chart archive (.tgz, as produced by 'helm package'), base64-encoded, for charts not published to a repository; takes precedence over chartName")),
 		mcp.WithString("namespace", mcp.Required(), mcp.Description("Kubernetes namespace of the release")),
 		mcp.WithObject("values", mcp.Required(), mcp.Description("Values to override in the chart")),
 		mcp.WithObject("repoURL", mcp.Required(), mcp.Description("URL of the Helm repository")),
@@ -54,6 +56,7 @@ func HelmListTool() mcp.Tool {
 	return mcp.NewTool("helmList",
 		mcp.WithDescription("List all Helm releases in the cluster or a specific namespace"),
 		mcp.WithString("namespace", mcp.Required(), mcp.Description("Kubernetes namespace to list releases from (empty for all namespaces)")),
+		mcp.WithString("selector", mcp.Description("Release label selector to filter by (e.g. 'team=payments,env!=dev'); requires releases stored with labels (Helm 3.8+)")),
 		mcp.WithToolAnnotation(mcp.ToolAnnotation{
 			Title:        "Helm List",
 			ReadOnlyHint: mcp.ToBoolPtr(true),
@@ -122,3 +125,130 @@ func HelmRepoListTool() mcp.Tool {
 		}),
 	)
 }
+
+// HelmReleaseStatusTool returns the MCP tool definition for checking the live health of a release's objects
+func HelmReleaseStatusTool() mcp.Tool {
+	return mcp.NewTool("helmReleaseStatus",
+		mcp.WithDescription("Check the live state of every object declared in a Helm release's manifest and report which are healthy, degraded, or missing"),
+		mcp.WithString("releaseName", mcp.Required(), mcp.Description("Name of the Helm release")),
+		mcp.WithString("namespace", mcp.Required(), mcp.Description("Kubernetes namespace of the release")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Helm Release Status",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// HelmRecoverTool returns the MCP tool definition for recovering releases stuck in a pending state
+func HelmRecoverTool() mcp.Tool {
+	return mcp.NewTool("helmRecover",
+		mcp.WithDescription("Recover a Helm release stuck in pending-install, pending-upgrade, or pending-rollback after an interrupted operation, by rolling back or marking the pending revision failed or deployed"),
+		mcp.WithString("releaseName", mcp.Required(), mcp.Description("Name of the Helm release to recover")),
+		mcp.WithString("namespace", mcp.Required(), mcp.Description("Kubernetes namespace of the release")),
+		mcp.WithString("strategy", mcp.Enum("auto", "rollback", "mark-failed", "mark-deployed"), mcp.Description("How to recover: 'auto' (default) rolls back to the last deployed revision if there is one and otherwise marks the release failed")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:           "Helm Recover",
+			DestructiveHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// HelmValidateValuesTool returns the MCP tool definition for validating values against a chart's schema
+func HelmValidateValuesTool() mcp.Tool {
+	return mcp.NewTool("helmValidateValues",
+		mcp.WithDescription("Validate proposed values against a chart's values.schema.json without installing, returning any schema violations"),
+		mcp.WithString("chartName", mcp.Required(), mcp.Description("Name or path of the Helm chart")),
+		mcp.WithString("repoURL", mcp.Description("Helm repository URL (optional)")),
+		mcp.WithString("version", mcp.Description("Chart version to validate against (default: latest)")),
+		mcp.WithObject("values", mcp.Description("The values to validate")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Helm Validate Values",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// HelmListFailedReleasesTool returns the MCP tool definition for listing failed and pending Helm releases
+func HelmListFailedReleasesTool() mcp.Tool {
+	return mcp.NewTool("listFailedReleases",
+		mcp.WithDescription("List Helm releases whose latest revision is failed or stuck in a pending state, with the last error recorded for each"),
+		mcp.WithString("namespace", mcp.Description("Kubernetes namespace to list releases from (empty for all namespaces)")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "List Failed Releases",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// HelmGetOverridesTool returns the MCP tool definition for showing how a release's values differ from its chart defaults
+func HelmGetOverridesTool() mcp.Tool {
+	return mcp.NewTool("helmGetOverrides",
+		mcp.WithDescription("Show which values of a deployed Helm release were customized: each user-supplied value that differs from the chart's default, as a dotted path with the value set and the default it replaces. Values that merely repeat the default are listed as redundant."),
+		mcp.WithString("releaseName", mcp.Required(), mcp.Description("Name of the Helm release")),
+		mcp.WithString("namespace", mcp.Required(), mcp.Description("Kubernetes namespace of the release")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Helm Get Overrides",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// HelmGetValuesTool returns the MCP tool definition for getting the values of a Helm release
+func HelmGetValuesTool() mcp.Tool {
+	return mcp.NewTool("helmGetValues",
+		mcp.WithDescription("Get the values of a deployed Helm release: the user-supplied values, the computed values the chart was rendered with (chart defaults merged with the user-supplied values), or both side by side to compare what was set with what took effect."),
+		mcp.WithString("releaseName", mcp.Required(), mcp.Description("Name of the Helm release")),
+		mcp.WithString("namespace", mcp.Required(), mcp.Description("Kubernetes namespace of the release")),
+		mcp.WithString("view", mcp.Enum("user", "computed", "both"), mcp.Description("Which values to return: 'user' (default) for the user-supplied values, 'computed' for the values after merging the chart defaults, or 'both'")),
+		mcp.WithNumber("revision", mcp.Description("Release revision to get the values of (default: the current revision)")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Helm Get Values",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// DetectDriftTool returns the MCP tool definition for detecting Helm releases modified outside Helm
+func DetectDriftTool() mcp.Tool {
+	return mcp.NewTool("detectDrift",
+		mcp.WithDescription("Detect configuration drift across deployed Helm releases: each object of a release's stored manifest is compared with its live state, and releases with objects modified or deleted outside Helm are reported first, with the paths of the fields that were changed, added, or removed. Only fields the chart sets are compared, so server defaults are not drift, but changes made by controllers, such as replicas set by an autoscaler or injected sidecars, are."),
+		mcp.WithString("namespace", mcp.Description("Kubernetes namespace to check releases in (empty for all namespaces)")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Detect Drift",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// HelmBatchTool returns the MCP tool definition for applying one operation to many Helm releases
+func HelmBatchTool() mcp.Tool {
+	return mcp.NewTool("helmBatch",
+		mcp.WithDescription("Apply the same upgrade, rollback, or uninstall to a list of Helm releases, several at a time. A failure on one release does not stop the others; the result lists each release's outcome and how many succeeded and failed. If the request carries a progress token, each release's result is streamed as a progress notification when it finishes."),
+		mcp.WithString("operation", mcp.Required(), mcp.Enum("upgrade", "rollback", "uninstall"), mcp.Description("The operation to apply to every release")),
+		mcp.WithArray("releases", mcp.Required(), mcp.WithStringItems(), mcp.Description("The releases to operate on, as 'namespace/name', or as a bare name in the namespace given by namespace")),
+		mcp.WithString("namespace", mcp.Description("Namespace of releases given without one (default: 'default')")),
+		mcp.WithString("chartName", mcp.Description("For upgrade: name or path of the chart every release is upgraded to (required unless chartData is given)")),
+		mcp.WithString("chartData", mcp.Description("For upgrade: a packaged chart archive (.tgz), base64-encoded; takes precedence over chartName")),
+		mcp.WithObject("values", mcp.Description("For upgrade: values every release is upgraded with")),
+		mcp.WithNumber("revision", mcp.Description("For rollback: revision to roll every release back to (default: 0, the previous revision)")),
+		mcp.WithNumber("parallelism", mcp.Description("How many releases to operate on at once, at most 10 (default: 4)")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:           "Helm Batch",
+			DestructiveHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// HelmReleaseObjectsTool returns the MCP tool definition for finding a release's live objects by their Helm labels
+func HelmReleaseObjectsTool() mcp.Tool {
+	return mcp.NewTool("helmReleaseObjects",
+		mcp.WithDescription("List the live objects that belong to a Helm release according to their Helm labels and annotations (app.kubernetes.io/managed-by=Helm with the meta.helm.sh/release-name annotation or app.kubernetes.io/instance label), independent of the stored manifest. Each object is marked with whether the release manifest declares it, and declared objects not found by label are listed, revealing resources added outside the release or left behind by it."),
+		mcp.WithString("releaseName", mcp.Required(), mcp.Description("Name of the Helm release")),
+		mcp.WithString("namespace", mcp.Description("Kubernetes namespace of the release (default: 'default')")),
+		mcp.WithBoolean("allNamespaces", mcp.Description("Search namespaced kinds in every namespace instead of only the release namespace, for charts that deploy into other namespaces (default: false)")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Helm Release Objects",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
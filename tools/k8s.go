@@ -39,9 +39,18 @@ func ListResourcesTool() mcp.Tool {
 		"listResources",
 		mcp.WithDescription("List all resources in the Kubernetes cluster of a specific type"),
 		mcp.WithString("Kind", mcp.Required(), mcp.Description("The type of resource to list")),
-		mcp.WithString("namespace", mcp.Description("The namespace to list resources in")),
+		mcp.WithString("namespace", mcp.Description("The namespace to list resources in (defaults to 'default' unless namespaces or allNamespaces is set)")),
+		mcp.WithString("namespaces", mcp.Description("Comma-separated namespaces to list resources in, e.g. 'team-a,team-b'; they are listed concurrently and each item keeps its namespace. Overrides namespace")),
+		mcp.WithBoolean("allNamespaces", mcp.Description("List resources across all namespaces; namespace and namespaces are ignored when set")),
 		mcp.WithString("labelSelector", mcp.Description("A label selector to filter resources")),
 		mcp.WithString("fieldSelector", mcp.Description("A field selector to filter resources")),
+		mcp.WithString("createdAfter", mcp.Description("Only return resources created after this time: an RFC3339 timestamp or a duration ago such as '1h'")),
+		mcp.WithString("createdBefore", mcp.Description("Only return resources created before this time: an RFC3339 timestamp or a duration ago such as '30m'")),
+		mcp.WithString("consistency", mcp.Enum("strong", "cached"), mcp.Description("Read consistency: 'strong' (default) reads the latest state, 'cached' serves from the API server cache, which is cheaper for large lists but may be slightly stale")),
+		mcp.WithString("fields", mcp.Description("Comma-separated field paths to return instead of the default summary, e.g. 'metadata.name,status.phase,spec.replicas'")),
+		mcp.WithBoolean("printerColumns", mcp.Description("For custom resources, add a 'columns' map to each item with the values of the additionalPrinterColumns defined by the CRD (the columns 'kubectl get' shows)")),
+		mcp.WithBoolean("reveal", mcp.Description("Return sensitive fields such as Secret data unmasked (default: false)")),
+		mcp.WithNumber("pageSize", mcp.Description("Fetch the list in pages of this many items. If the request carries a progress token, each page is streamed as a progress notification and the final result only reports the total count.")),
 		mcp.WithToolAnnotation(mcp.ToolAnnotation{
 			Title:        "List Resources",
 			ReadOnlyHint: mcp.ToBoolPtr(true),
@@ -58,7 +67,10 @@ func GetResourcesTool() mcp.Tool {
 		mcp.WithDescription("Get a specific resource in the Kubernetes cluster"),
 		mcp.WithString("kind", mcp.Required(), mcp.Description("The type of resource to get")),
 		mcp.WithString("name", mcp.Required(), mcp.Description("The name of the resource to get")),
-		mcp.WithString("namespace", mcp.Description("The namespace of the resource")),
+		mcp.WithString("namespace", mcp.Description("The namespace of the resource (required for namespaced kinds, omit for cluster-scoped kinds)")),
+		mcp.WithString("consistency", mcp.Enum("strong", "cached"), mcp.Description("Read consistency: 'strong' (default) reads the latest state, 'cached' serves from the API server cache")),
+		mcp.WithString("fields", mcp.Description("Comma-separated field paths to return instead of the full object, e.g. 'metadata.name,status.phase,spec.replicas'")),
+		mcp.WithBoolean("reveal", mcp.Description("Return sensitive fields such as Secret data unmasked (default: false)")),
 		mcp.WithToolAnnotation(mcp.ToolAnnotation{
 			Title:        "Get Resource",
 			ReadOnlyHint: mcp.ToBoolPtr(true),
@@ -75,7 +87,8 @@ func DescribeResourcesTool() mcp.Tool {
 		mcp.WithDescription("Describe a resource in the Kubernetes cluster based on given kind and name"),
 		mcp.WithString("Kind", mcp.Required(), mcp.Description("The type of resource to describe")),
 		mcp.WithString("name", mcp.Required(), mcp.Description("The name of the resource to describe")),
-		mcp.WithString("namespace", mcp.Description("The namespace of the resource")),
+		mcp.WithString("namespace", mcp.Description("The namespace of the resource (required for namespaced kinds, omit for cluster-scoped kinds)")),
+		mcp.WithBoolean("reveal", mcp.Description("Return sensitive fields such as Secret data unmasked (default: false)")),
 		mcp.WithToolAnnotation(mcp.ToolAnnotation{
 			Title:        "Describe Resource",
 			ReadOnlyHint: mcp.ToBoolPtr(true),
@@ -91,8 +104,10 @@ func GetPodsLogsTools() mcp.Tool {
 		"getPodsLogs",
 		mcp.WithDescription("Get logs of a specific pod in the Kubernetes cluster"),
 		mcp.WithString("Name", mcp.Required(), mcp.Description("The name of the pod to get logs from")),
-		mcp.WithString("containerName", mcp.Description("The name of the container to get logs from")),
+		mcp.WithString("containerName", mcp.Description("The name of the container to get logs from (default: the container named by the kubectl.kubernetes.io/default-container annotation, or all containers)")),
 		mcp.WithString("namespace", mcp.Required(), mcp.Description("The namespace of the pod")),
+		mcp.WithBoolean("includeInit", mcp.Description("Include init and ephemeral containers when no container is specified (default: true)")),
+		mcp.WithNumber("limitBytes", mcp.Description("Maximum number of bytes of logs to return per container, applied to the most recent 100 lines (default: 0, no limit)")),
 		mcp.WithToolAnnotation(mcp.ToolAnnotation{
 			Title:        "Get Pod Logs",
 			ReadOnlyHint: mcp.ToBoolPtr(true),
@@ -123,6 +138,7 @@ func GetPodMetricsTool() mcp.Tool {
 		mcp.WithDescription("Get CPU and Memory metrics for a specific pod"),
 		mcp.WithString("namespace", mcp.Required(), mcp.Description("The namespace of the pod")),
 		mcp.WithString("podName", mcp.Required(), mcp.Description("The name of the pod")),
+		mcp.WithBoolean("includeUtilization", mcp.Description("Also report each container's requests and limits and its CPU and memory usage as a percentage of them (default: false)")),
 		mcp.WithToolAnnotation(mcp.ToolAnnotation{
 			Title:        "Get Pod Metrics",
 			ReadOnlyHint: mcp.ToBoolPtr(true),
@@ -137,8 +153,11 @@ func GetEventsTool() mcp.Tool {
 	return mcp.NewTool(
 		"getEvents",
 		mcp.WithDescription("Get events in the Kubernetes cluster"),
-		mcp.WithString("namespace", mcp.Description("The namespace to get events from")),
+		mcp.WithString("namespace", mcp.Description("The namespace to get events from (defaults to 'default' unless allNamespaces is set)")),
+		mcp.WithBoolean("allNamespaces", mcp.Description("Get events across all namespaces; namespace is ignored when set")),
 		mcp.WithString("labelSelector", mcp.Description("A label selector to filter events")),
+		mcp.WithBoolean("sorted", mcp.Description("Return events newest first with a relative age, collapsing repeated events into one entry with a total count")),
+		mcp.WithNumber("limit", mcp.Description("Maximum number of events to return when sorted is set")),
 		mcp.WithToolAnnotation(mcp.ToolAnnotation{
 			Title:        "Get Events",
 			ReadOnlyHint: mcp.ToBoolPtr(true),
@@ -154,6 +173,12 @@ func CreateOrUpdateResourceJSONTool() mcp.Tool {
 		mcp.WithString("kind", mcp.Required(), mcp.Description("The type of resource to create")),
 		mcp.WithString("namespace", mcp.Description("The namespace of the resource")),
 		mcp.WithString("manifest", mcp.Required(), mcp.Description("The manifest of the resource to create")),
+		mcp.WithString("ownerKind", mcp.Description("Kind of an owner to set in ownerReferences, so the resource is garbage-collected when the owner is deleted")),
+		mcp.WithString("ownerName", mcp.Description("Name of the owner (required with ownerKind); it must be in the resource's namespace or cluster-scoped")),
+		mcp.WithString("ownerUID", mcp.Description("Expected UID of the owner (optional, resolved automatically; the call fails if it does not match)")),
+		mcp.WithBoolean("ownerController", mcp.Description("Mark the owner as the managing controller (default: false)")),
+		mcp.WithBoolean("recordLastApplied", mcp.Description("Record the manifest in the kubectl.kubernetes.io/last-applied-configuration annotation, as kubectl apply does, so the resource stays compatible with kubectl apply (default: false)")),
+		mcp.WithString("fieldValidation", mcp.Description("How the API server handles unknown or duplicate fields in the manifest: 'Ignore' drops them silently, 'Warn' drops them and returns a warning for each, 'Strict' rejects the request, catching typos such as 'replcas' (default: the server's, usually Warn)")),
 		mcp.WithToolAnnotation(mcp.ToolAnnotation{
 			Title:           "Create Resource",
 			DestructiveHint: mcp.ToBoolPtr(true),
@@ -169,6 +194,12 @@ func CreateOrUpdateResourceYAMLTool() mcp.Tool {
 		mcp.WithString("kind", mcp.Description("The type of resource to create (optional, will be inferred from YAML manifest if not provided)")),
 		mcp.WithString("namespace", mcp.Description("The namespace of the resource (overrides namespace in YAML manifest if provided)")),
 		mcp.WithString("yamlManifest", mcp.Required(), mcp.Description("The YAML manifest of the resource to create or update. Must be valid Kubernetes YAML format.")),
+		mcp.WithString("ownerKind", mcp.Description("Kind of an owner to set in ownerReferences, so the resource is garbage-collected when the owner is deleted")),
+		mcp.WithString("ownerName", mcp.Description("Name of the owner (required with ownerKind); it must be in the resource's namespace or cluster-scoped")),
+		mcp.WithString("ownerUID", mcp.Description("Expected UID of the owner (optional, resolved automatically; the call fails if it does not match)")),
+		mcp.WithBoolean("ownerController", mcp.Description("Mark the owner as the managing controller (default: false)")),
+		mcp.WithBoolean("recordLastApplied", mcp.Description("Record the manifest in the kubectl.kubernetes.io/last-applied-configuration annotation, as kubectl apply does, so the resource stays compatible with kubectl apply (default: false)")),
+		mcp.WithString("fieldValidation", mcp.Description("How the API server handles unknown or duplicate fields in the manifest: 'Ignore' drops them silently, 'Warn' drops them and returns a warning for each, 'Strict' rejects the request, catching typos such as 'replcas' (default: the server's, usually Warn)")),
 		mcp.WithToolAnnotation(mcp.ToolAnnotation{
 			Title:           "Create Resource YAML",
 			DestructiveHint: mcp.ToBoolPtr(true),
@@ -219,3 +250,928 @@ func RolloutRestartTool() mcp.Tool {
 		}),
 	)
 }
+
+// RunJobAndWaitTool creates a tool for running a Job and waiting for it to finish.
+// It defines the tool's name, description, and parameters for the job name,
+// namespace, an optional manifest, and the timeout.
+func RunJobAndWaitTool() mcp.Tool {
+	return mcp.NewTool(
+		"runJobAndWait",
+		mcp.WithDescription("Optionally create a Job from a YAML manifest, then wait until it completes or fails and return its final status and pod logs. If the Job is still running when the timeout expires, its current status and the pod logs so far are returned, marked as timedOut."),
+		mcp.WithString("name", mcp.Description("The name of an existing Job to wait for (required if no manifest is given)")),
+		mcp.WithString("namespace", mcp.Required(), mcp.Description("The namespace of the Job")),
+		mcp.WithString("manifest", mcp.Description("YAML manifest of a Job to create before waiting. An existing Job is never replaced; use metadata.generateName to create a uniquely named Job on every run")),
+		mcp.WithNumber("timeoutSeconds", mcp.Description("Maximum time to wait for the Job to finish (default: 300)")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:           "Run Job And Wait",
+			DestructiveHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// GetClusterCapacityTool creates a tool for summarizing cluster capacity.
+// It reports allocatable CPU and memory against pod requests and limits.
+func GetClusterCapacityTool() mcp.Tool {
+	return mcp.NewTool(
+		"getClusterCapacity",
+		mcp.WithDescription("Summarize allocatable CPU and memory across schedulable nodes against the requests and limits of the pods running on them, including utilization and overcommit. Requests of pods not yet scheduled to a node are reported separately."),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Get Cluster Capacity",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// EvictPodTool creates a tool for evicting a single pod.
+// It defines the tool's name, description, and parameters for the pod name,
+// namespace, and an optional grace period.
+func EvictPodTool() mcp.Tool {
+	return mcp.NewTool(
+		"evictPod",
+		mcp.WithDescription("Evict a single pod using the eviction API, respecting PodDisruptionBudgets. Reports whether the eviction was allowed or blocked by a disruption budget."),
+		mcp.WithString("name", mcp.Required(), mcp.Description("The name of the pod to evict")),
+		mcp.WithString("namespace", mcp.Required(), mcp.Description("The namespace of the pod")),
+		mcp.WithNumber("gracePeriodSeconds", mcp.Description("Override the pod's termination grace period in seconds")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:           "Evict Pod",
+			DestructiveHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// ScaleResourceTool creates a tool for getting and setting a workload's replica count.
+// It defines the tool's name, description, and parameters for kind, name, namespace,
+// replicas, and force.
+func ScaleResourceTool() mcp.Tool {
+	return mcp.NewTool(
+		"scaleResource",
+		mcp.WithDescription("Get or set the replica count of a Deployment, StatefulSet, ReplicaSet, or other scalable resource. Detects HorizontalPodAutoscalers targeting the resource and refuses to scale unless forced, since the autoscaler would revert the change."),
+		mcp.WithString("kind", mcp.Required(), mcp.Description("The type of resource to scale (e.g., Deployment, StatefulSet)")),
+		mcp.WithString("name", mcp.Required(), mcp.Description("The name of the resource")),
+		mcp.WithString("namespace", mcp.Required(), mcp.Description("The namespace of the resource")),
+		mcp.WithNumber("replicas", mcp.Description("The desired replica count (omit to only read the current count)")),
+		mcp.WithBoolean("force", mcp.Description("Scale even if a HorizontalPodAutoscaler manages the resource")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:           "Scale Resource",
+			DestructiveHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// ApplyAndPruneTool creates a tool for declaratively reconciling a group of resources.
+// It defines the tool's name, description, and parameters for the manifests bundle,
+// namespace, prune selector, and additional kinds to prune.
+func ApplyAndPruneTool() mcp.Tool {
+	return mcp.NewTool(
+		"applyAndPrune",
+		mcp.WithDescription("Apply a multi-document YAML bundle and delete previously applied resources carrying the prune label that are no longer in the bundle, like `kubectl apply --prune`"),
+		mcp.WithString("manifests", mcp.Required(), mcp.Description("Multi-document YAML bundle of the resources to apply")),
+		mcp.WithString("pruneSelector", mcp.Required(), mcp.Description("Equality-based label selector identifying the managed set (e.g. app.kubernetes.io/part-of=myapp); applied to every resource")),
+		mcp.WithString("namespace", mcp.Description("Namespace for resources that don't specify one, and the scope of pruning (default: 'default')")),
+		mcp.WithString("pruneKinds", mcp.Description("Comma-separated additional kinds to prune that may no longer appear in the bundle")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:           "Apply And Prune",
+			DestructiveHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// GetControlPlaneHealthTool creates a tool for checking control-plane health.
+func GetControlPlaneHealthTool() mcp.Tool {
+	return mcp.NewTool(
+		"getControlPlaneHealth",
+		mcp.WithDescription("Check control-plane health: API server readiness checks, component statuses, and the state of kube-apiserver, kube-scheduler, kube-controller-manager, and etcd pods in kube-system where visible"),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Get Control Plane Health",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// GetStorageTool creates a tool for getting a storage overview.
+// It defines the tool's name, description, and parameters for the namespace.
+func GetStorageTool() mcp.Tool {
+	return mcp.NewTool(
+		"getStorage",
+		mcp.WithDescription("Get a storage overview: PersistentVolumeClaims with status (Bound/Pending), capacity, storage class, and bound PersistentVolume, plus unbound PersistentVolumes"),
+		mcp.WithString("namespace", mcp.Description("The namespace to list claims in (defaults to 'default' unless allNamespaces is set)")),
+		mcp.WithBoolean("allNamespaces", mcp.Description("List claims across all namespaces; namespace is ignored when set")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Get Storage",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// ResolveSelectorTool creates a tool for resolving a label selector to pods.
+// It defines the tool's name, description, and parameters for the label selector
+// and namespace.
+func ResolveSelectorTool() mcp.Tool {
+	return mcp.NewTool(
+		"resolveSelector",
+		mcp.WithDescription("List the pods a label selector currently matches in a namespace, to verify that a Service, NetworkPolicy, or workload selector targets the intended pods"),
+		mcp.WithString("labelSelector", mcp.Required(), mcp.Description("The label selector to resolve (e.g. app=web,tier=frontend)")),
+		mcp.WithString("namespace", mcp.Required(), mcp.Description("The namespace to resolve the selector in")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Resolve Selector",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// GetWorkloadReadinessTool creates a tool for finding workloads that are not fully ready.
+// It defines the tool's name, description, and parameters for the namespace.
+func GetWorkloadReadinessTool() mcp.Tool {
+	return mcp.NewTool(
+		"getWorkloadReadiness",
+		mcp.WithDescription("List Deployments, StatefulSets, and DaemonSets whose ready replicas differ from the desired count, with the shortfall and age"),
+		mcp.WithString("namespace", mcp.Description("The namespace to check (defaults to 'default' unless allNamespaces is set)")),
+		mcp.WithBoolean("allNamespaces", mcp.Description("Check workloads across all namespaces; namespace is ignored when set")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Get Workload Readiness",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// GetPodEnvTool creates a tool for inspecting the resolved environment of a pod.
+// It defines the tool's name, description, and parameters for the pod, container,
+// and whether to reveal secret values.
+func GetPodEnvTool() mcp.Tool {
+	return mcp.NewTool(
+		"getPodEnv",
+		mcp.WithDescription("Get the effective environment variables of a pod's containers, resolving values from ConfigMaps, Secrets, and pod fields. Secret values are redacted by default."),
+		mcp.WithString("name", mcp.Required(), mcp.Description("The name of the pod")),
+		mcp.WithString("namespace", mcp.Required(), mcp.Description("The namespace of the pod")),
+		mcp.WithString("containerName", mcp.Description("Only return the environment of this container")),
+		mcp.WithBoolean("reveal", mcp.Description("Return secret values unredacted (default: false)")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Get Pod Environment",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// CreateFromTemplateTool creates a tool for applying a templated manifest.
+// It defines the tool's name, description, and parameters for the template,
+// its variables, and the default namespace.
+func CreateFromTemplateTool() mcp.Tool {
+	return mcp.NewTool(
+		"createFromTemplate",
+		mcp.WithDescription("Render a manifest containing Go template placeholders such as {{ .name }} with the given variables, validate the result, and create or update the resources"),
+		mcp.WithString("template", mcp.Required(), mcp.Description("YAML or JSON manifest with {{ .variable }} placeholders; may contain multiple documents")),
+		mcp.WithObject("variables", mcp.Description("Values for the template placeholders; every referenced variable must be provided")),
+		mcp.WithString("namespace", mcp.Description("Namespace for resources that don't specify one (default: 'default')")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:           "Create From Template",
+			DestructiveHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// DiagnoseAlertTool creates a tool for turning an alert into diagnostic context.
+// It defines the tool's name, description, and parameters for the alert labels.
+func DiagnoseAlertTool() mcp.Tool {
+	return mcp.NewTool(
+		"diagnoseAlert",
+		mcp.WithDescription("Given an alert's labels (namespace plus pod, deployment, statefulset, daemonset, job_name, persistentvolumeclaim, or node), collect the related resource, its events, and the logs and metrics of its pods in one response"),
+		mcp.WithObject("labels", mcp.Required(), mcp.Description("The alert's labels, e.g. {\"namespace\": \"prod\", \"deployment\": \"api\"}")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Diagnose Alert",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// ExportNamespaceTool creates a tool for exporting a namespace as manifests.
+// It defines the tool's name, description, and parameters for the namespace,
+// the kinds to export, and whether to reveal sensitive values.
+func ExportNamespaceTool() mcp.Tool {
+	return mcp.NewTool(
+		"exportNamespace",
+		mcp.WithDescription("Export the resources of a namespace as a multi-document YAML bundle suitable for re-applying elsewhere, with server-populated fields such as uid, resourceVersion, status, and managedFields removed"),
+		mcp.WithString("namespace", mcp.Required(), mcp.Description("The namespace to export")),
+		mcp.WithString("kinds", mcp.Description("Comma-separated kinds to export (default: common workload, configuration, networking, and RBAC kinds)")),
+		mcp.WithBoolean("reveal", mcp.Description("Include sensitive fields such as Secret data unmasked (default: false)")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Export Namespace",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// CloneResourceTool creates a tool for copying a resource to another namespace or name.
+// It defines the tool's name, description, and parameters for the source resource,
+// the target namespace and name, and overrides to apply to the copy.
+func CloneResourceTool() mcp.Tool {
+	return mcp.NewTool(
+		"cloneResource",
+		mcp.WithDescription("Copy a resource into another namespace and/or under a new name, stripping server-populated fields (uid, resourceVersion, status, cluster IPs, bound volumes) and optionally applying overrides"),
+		mcp.WithString("kind", mcp.Required(), mcp.Description("The kind of the resource to clone")),
+		mcp.WithString("name", mcp.Required(), mcp.Description("The name of the resource to clone")),
+		mcp.WithString("namespace", mcp.Description("The namespace of the source resource (default: 'default')")),
+		mcp.WithString("targetNamespace", mcp.Description("The namespace to create the copy in (default: the source namespace)")),
+		mcp.WithString("targetName", mcp.Description("The name of the copy (default: the source name)")),
+		mcp.WithObject("overrides", mcp.Description("Fields to merge into the copy using JSON merge patch semantics, e.g. {\"spec\": {\"replicas\": 1}}")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:           "Clone Resource",
+			DestructiveHint: mcp.ToBoolPtr(false),
+		}),
+	)
+}
+
+// GetPodOwnerTool creates a tool for finding the workload that controls a pod.
+// It defines the tool's name, description, and parameters for the pod.
+func GetPodOwnerTool() mcp.Tool {
+	return mcp.NewTool(
+		"getPodOwner",
+		mcp.WithDescription("Find the top-level workload controlling a pod by walking its ownerReferences, e.g. Pod → ReplicaSet → Deployment or Pod → Job → CronJob"),
+		mcp.WithString("name", mcp.Required(), mcp.Description("The name of the pod")),
+		mcp.WithString("namespace", mcp.Required(), mcp.Description("The namespace of the pod")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Get Pod Owner",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// WithFullOutputOption adds the "full" parameter, which bypasses response
+// truncation, to a tool definition.
+func WithFullOutputOption(tool mcp.Tool) mcp.Tool {
+	if tool.InputSchema.Properties == nil {
+		tool.InputSchema.Properties = map[string]any{}
+	}
+	tool.InputSchema.Properties["full"] = map[string]any{
+		"type":        "boolean",
+		"description": "Return the complete response even if it exceeds the server's response size limit (default: false)",
+	}
+	return tool
+}
+
+// WithIdempotencyKeyOption adds the "idempotencyKey" parameter, which deduplicates
+// retried calls, to a tool definition.
+func WithIdempotencyKeyOption(tool mcp.Tool) mcp.Tool {
+	if tool.InputSchema.Properties == nil {
+		tool.InputSchema.Properties = map[string]any{}
+	}
+	tool.InputSchema.Properties["idempotencyKey"] = map[string]any{
+		"type":        "string",
+		"description": "A unique key for this operation; retrying with the same key returns the first call's result instead of executing again",
+	}
+	return tool
+}
+
+// DryRunResourceTool creates a tool for previewing a resource after server-side defaulting.
+// It defines the tool's name, description, and parameters for submitting a manifest in dry-run mode.
+func DryRunResourceTool() mcp.Tool {
+	return mcp.NewTool(
+		"dryRunResource",
+		mcp.WithDescription("Submit a YAML or JSON manifest to the API server in dry-run mode (nothing is persisted) and return the object as it would be stored after defaulting, mutating admission webhooks, and validation. Shows whether the apply would create or update the object."),
+		mcp.WithString("manifest", mcp.Required(), mcp.Description("The YAML or JSON manifest of the resource")),
+		mcp.WithString("kind", mcp.Description("The type of resource (optional, inferred from the manifest if not provided)")),
+		mcp.WithString("namespace", mcp.Description("The namespace of the resource (overrides the namespace in the manifest if provided)")),
+		mcp.WithBoolean("reveal", mcp.Description("Return sensitive fields such as Secret data unmasked (default: false)")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Dry Run Resource",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// GetClusterWarningsTool creates a tool for summarizing recent warning events cluster-wide.
+// It defines the tool's name, description, and parameters for the warning overview.
+func GetClusterWarningsTool() mcp.Tool {
+	return mcp.NewTool(
+		"getClusterWarnings",
+		mcp.WithDescription("Summarize Warning events across all namespaces within a recent window, grouped by reason and involved object kind with occurrence counts, affected objects, and the latest message. A quick overview of what is generating warnings right now."),
+		mcp.WithString("window", mcp.Description("How far back to look, as a duration such as '15m' or '2h' (default: '1h')")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Get Cluster Warnings",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// WatchRolloutTool creates a tool for following a workload rollout as it happens.
+// It defines the tool's name, description, and parameters for watching a rollout.
+func WatchRolloutTool() mcp.Tool {
+	return mcp.NewTool(
+		"watchRollout",
+		mcp.WithDescription("Follow the rollout of a Deployment, StatefulSet, or DaemonSet until it completes, fails, or times out, optionally triggering it with a rollout restart first. Pod creation, scheduling, image pull, and readiness events are collected as they happen and, if the request carries a progress token, streamed as progress notifications. Returns the outcome and a timeline."),
+		mcp.WithString("kind", mcp.Required(), mcp.Enum("Deployment", "StatefulSet", "DaemonSet"), mcp.Description("The kind of workload")),
+		mcp.WithString("name", mcp.Required(), mcp.Description("The name of the workload")),
+		mcp.WithString("namespace", mcp.Description("The namespace of the workload (default: 'default')")),
+		mcp.WithBoolean("restart", mcp.Description("Trigger a rollout restart before watching (default: false, only observe)")),
+		mcp.WithString("timeout", mcp.Description("How long to wait for the rollout, as a duration such as '10m' (default: '5m')")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:           "Watch Rollout",
+			DestructiveHint: mcp.ToBoolPtr(false),
+		}),
+	)
+}
+
+// RolloutProgressTool creates a tool for measuring how far a workload rollout has got.
+// It defines the tool's name, description, and parameters for getting rollout progress.
+func RolloutProgressTool() mcp.Tool {
+	return mcp.NewTool(
+		"rolloutProgress",
+		mcp.WithDescription("Get the progress of a Deployment, StatefulSet, or DaemonSet rollout as percentages: updated replicas and ready replicas out of the desired count, and an overall progressPercent (the lower of the two, 100 once the rollout is done). The workload is sampled twice, sampleFor apart, to measure the rate of progress and estimate the time remaining."),
+		mcp.WithString("kind", mcp.Required(), mcp.Enum("Deployment", "StatefulSet", "DaemonSet"), mcp.Description("The kind of workload")),
+		mcp.WithString("name", mcp.Required(), mcp.Description("The name of the workload")),
+		mcp.WithString("namespace", mcp.Description("The namespace of the workload (default: 'default')")),
+		mcp.WithString("sampleFor", mcp.Description("How long to observe the rollout to estimate its rate, as a duration of at most '1m'; '0s' skips the estimate (default: '10s')")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Get Rollout Progress",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// RestartNamespaceTool creates a tool for restarting every workload in a namespace.
+// It defines the tool's name, description, and parameters for the namespace-wide restart.
+func RestartNamespaceTool() mcp.Tool {
+	return mcp.NewTool(
+		"restartNamespace",
+		mcp.WithDescription("Perform a rollout restart of every Deployment, StatefulSet, and DaemonSet in a namespace, e.g. after a shared ConfigMap or Secret changed. Without confirm set to true, nothing is restarted and the workloads that would be are listed."),
+		mcp.WithString("namespace", mcp.Required(), mcp.Description("The namespace whose workloads to restart")),
+		mcp.WithString("labelSelector", mcp.Description("Only restart workloads matching this label selector")),
+		mcp.WithBoolean("confirm", mcp.Description("Must be true to actually restart the workloads (default: false, preview only)")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:           "Restart Namespace",
+			DestructiveHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// GetMergedLogsTool creates a tool for reading a workload's logs as one time-ordered stream.
+// It defines the tool's name, description, and parameters for merging workload logs.
+func GetMergedLogsTool() mcp.Tool {
+	return mcp.NewTool(
+		"getMergedLogs",
+		mcp.WithDescription("Get the recent logs of every container in every pod of a workload (Deployment, StatefulSet, DaemonSet, ReplicaSet, or Job), merged into a single stream ordered by timestamp with each line labeled by pod/container. Useful for following a request across replicas."),
+		mcp.WithString("kind", mcp.Required(), mcp.Description("The kind of workload, e.g. 'Deployment'")),
+		mcp.WithString("name", mcp.Required(), mcp.Description("The name of the workload")),
+		mcp.WithString("namespace", mcp.Description("The namespace of the workload (default: 'default')")),
+		mcp.WithNumber("tailLines", mcp.Description("Number of most recent lines to return, read from each container and kept after merging (default: 100)")),
+		mcp.WithNumber("limitBytes", mcp.Description("Maximum number of bytes of logs to read from each container, applied to its tailLines lines (default: 0, no limit)")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Get Merged Logs",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// GetTerminationDetailsTool creates a tool for inspecting how a pod's containers terminated.
+// It defines the tool's name, description, and parameters for reading termination details.
+func GetTerminationDetailsTool() mcp.Tool {
+	return mcp.NewTool(
+		"getTerminationDetails",
+		mcp.WithDescription("Get how each terminated container of a pod exited, for its current and previous run: exit code, reason (e.g. OOMKilled, Error), signal, run time, and the termination message the container wrote to /dev/termination-log, which often contains the exact error"),
+		mcp.WithString("podName", mcp.Required(), mcp.Description("The name of the pod")),
+		mcp.WithString("namespace", mcp.Description("The namespace of the pod (default: 'default')")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Get Termination Details",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// GetResourceBundleTool creates a tool for fetching a resource with its related objects.
+// It defines the tool's name, description, and parameters for assembling a resource bundle.
+func GetResourceBundleTool() mcp.Tool {
+	return mcp.NewTool(
+		"getResourceBundle",
+		mcp.WithDescription("Get a resource together with the objects relevant to investigating it, in one call: for a Deployment its ReplicaSets, pods, and the Services selecting them; for a StatefulSet, DaemonSet, ReplicaSet, or Job its pods and Services; for a Service the pods it selects; for a Pod its owner and Services. Recent events for all of them are included."),
+		mcp.WithString("kind", mcp.Required(), mcp.Description("The kind of the resource, e.g. 'Deployment'")),
+		mcp.WithString("name", mcp.Required(), mcp.Description("The name of the resource")),
+		mcp.WithString("namespace", mcp.Description("The namespace of the resource (default: 'default')")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Get Resource Bundle",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// TestDNSTool creates a tool for testing DNS resolution from inside the cluster.
+// It defines the tool's name, description, and parameters for the DNS probe.
+func TestDNSTool() mcp.Tool {
+	return mcp.NewTool(
+		"testDNS",
+		mcp.WithDescription("Resolve a hostname through the cluster DNS from a short-lived pod in the given namespace (so its search domains apply, e.g. 'my-svc' or 'my-svc.other-ns'), returning the resolved addresses and the raw nslookup output. The probe pod is deleted afterwards."),
+		mcp.WithString("hostname", mcp.Required(), mcp.Description("The hostname to resolve")),
+		mcp.WithString("namespace", mcp.Description("The namespace to run the probe pod in (default: 'default')")),
+		mcp.WithString("image", mcp.Description("Image for the probe pod; it must provide nslookup (default: 'busybox:1.36')")),
+		mcp.WithString("timeout", mcp.Description("How long to wait for the lookup once the pod is running, as a duration (default: '30s')")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:           "Test DNS",
+			DestructiveHint: mcp.ToBoolPtr(false),
+		}),
+	)
+}
+
+// TestConnectivityTool creates a tool for testing a Service's reachability from inside the cluster.
+// It defines the tool's name, description, and parameters for the connectivity probe.
+func TestConnectivityTool() mcp.Tool {
+	return mcp.NewTool(
+		"testConnectivity",
+		mcp.WithDescription("Test whether a Service is reachable by connecting to its ClusterIP and port from a short-lived pod, reporting success and, for HTTP, the connection latency and status code. Run the probe from the client's namespace (sourceNamespace) to test network policies between services. The probe pod is deleted afterwards."),
+		mcp.WithString("service", mcp.Required(), mcp.Description("The name of the target Service")),
+		mcp.WithString("namespace", mcp.Description("The namespace of the target Service (default: 'default')")),
+		mcp.WithString("port", mcp.Description("The Service port, by number or name (optional for single-port Services)")),
+		mcp.WithString("mode", mcp.Enum("tcp", "http", "https"), mcp.Description("'tcp' only opens a connection; 'http' or 'https' sends a GET request (default: 'tcp')")),
+		mcp.WithString("path", mcp.Description("Request path for http/https mode (default: '/')")),
+		mcp.WithString("sourceNamespace", mcp.Description("The namespace to run the probe pod in (default: the Service's namespace)")),
+		mcp.WithString("image", mcp.Description("Image for the probe pod; it must provide nc for tcp and curl for http(s) (default: 'curlimages/curl:8.10.1')")),
+		mcp.WithString("timeout", mcp.Description("Connection timeout as a duration (default: '10s')")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:           "Test Connectivity",
+			DestructiveHint: mcp.ToBoolPtr(false),
+		}),
+	)
+}
+
+// GetRBACTool creates a tool for inspecting role bindings and the access they grant.
+// It defines the tool's name, description, and parameters for the RBAC inspection.
+func GetRBACTool() mcp.Tool {
+	return mcp.NewTool(
+		"getRBAC",
+		mcp.WithDescription("List the role bindings that apply in a namespace (its RoleBindings plus all ClusterRoleBindings) or cluster-wide, each joined with the rules of its Role or ClusterRole, plus a per-subject summary. Filter by subject to answer 'what can this service account do'; omit the filter to answer 'who has access to this namespace'."),
+		mcp.WithString("namespace", mcp.Description("The namespace to inspect (empty for cluster-wide bindings only)")),
+		mcp.WithString("subjectKind", mcp.Enum("User", "Group", "ServiceAccount"), mcp.Description("Only return bindings granting access to a subject of this kind")),
+		mcp.WithString("subjectName", mcp.Description("The name of the subject to filter by (required with subjectKind)")),
+		mcp.WithString("subjectNamespace", mcp.Description("The namespace of the ServiceAccount to filter by")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Get RBAC",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// ListStuckResourcesTool creates a tool for finding objects stuck in Terminating.
+// It defines the tool's name, description, and parameters for the stuck resource search.
+func ListStuckResourcesTool() mcp.Tool {
+	return mcp.NewTool(
+		"listStuckResources",
+		mcp.WithDescription("Find objects stuck in Terminating: those with a deletionTimestamp older than a threshold, with the finalizers blocking their deletion. For namespaces, the conditions describing the remaining content are included."),
+		mcp.WithString("namespace", mcp.Description("The namespace to search (empty for all namespaces)")),
+		mcp.WithArray("kinds", mcp.WithStringItems(), mcp.Description("Kinds to check (default: Namespace, Pod, PersistentVolumeClaim, PersistentVolume, Service, Deployment, StatefulSet, Job, ConfigMap, Secret, Ingress, CustomResourceDefinition)")),
+		mcp.WithString("olderThan", mcp.Description("Only report objects terminating for longer than this duration (default: '5m')")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "List Stuck Resources",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// RemoveFinalizersTool creates a tool for clearing the finalizers of a terminating object.
+// It defines the tool's name, description, and parameters for unblocking a deletion.
+func RemoveFinalizersTool() mcp.Tool {
+	return mcp.NewTool(
+		"removeFinalizers",
+		mcp.WithDescription("Remove all finalizers from an object stuck in Terminating so its deletion can complete. Only objects already being deleted are accepted. Finalizers guard cleanup (e.g. of cloud resources), so without confirm set to true nothing is changed and the finalizers that would be removed are listed."),
+		mcp.WithString("kind", mcp.Required(), mcp.Description("The kind of the object")),
+		mcp.WithString("name", mcp.Required(), mcp.Description("The name of the object")),
+		mcp.WithString("namespace", mcp.Description("The namespace of the object (for namespaced kinds)")),
+		mcp.WithBoolean("confirm", mcp.Description("Must be true to actually remove the finalizers (default: false, preview only)")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:           "Remove Finalizers",
+			DestructiveHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// AddFinalizerTool creates a tool for adding a finalizer to an object.
+// It defines the tool's name, description, and parameters for protecting an object from deletion.
+func AddFinalizerTool() mcp.Tool {
+	return mcp.NewTool(
+		"addFinalizer",
+		mcp.WithDescription("Add a finalizer to an object so that it is not removed on deletion until the finalizer is removed again. Adding a finalizer the object already has is a no-op."),
+		mcp.WithString("kind", mcp.Required(), mcp.Description("The kind of the object")),
+		mcp.WithString("name", mcp.Required(), mcp.Description("The name of the object")),
+		mcp.WithString("namespace", mcp.Description("The namespace of the object (for namespaced kinds)")),
+		mcp.WithString("finalizer", mcp.Required(), mcp.Description("The finalizer to add, e.g. 'example.com/protect'")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:           "Add Finalizer",
+			DestructiveHint: mcp.ToBoolPtr(false),
+		}),
+	)
+}
+
+// RemoveFinalizerTool creates a tool for removing a single finalizer from an object.
+// It defines the tool's name, description, and parameters for unblocking a deletion.
+func RemoveFinalizerTool() mcp.Tool {
+	return mcp.NewTool(
+		"removeFinalizer",
+		mcp.WithDescription("Remove one specific finalizer from an object, leaving the others in place; if the object is terminating and this was its last finalizer, the deletion completes. Without confirm set to true nothing is changed and the resulting finalizers are shown."),
+		mcp.WithString("kind", mcp.Required(), mcp.Description("The kind of the object")),
+		mcp.WithString("name", mcp.Required(), mcp.Description("The name of the object")),
+		mcp.WithString("namespace", mcp.Description("The namespace of the object (for namespaced kinds)")),
+		mcp.WithString("finalizer", mcp.Required(), mcp.Description("The finalizer to remove")),
+		mcp.WithBoolean("confirm", mcp.Description("Must be true to actually remove the finalizer (default: false, preview only)")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:           "Remove Finalizer",
+			DestructiveHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// GetConfigMapConsumersTool creates a tool for finding the workloads that use a ConfigMap.
+// It defines the tool's name, description, and parameters for the consumer lookup.
+func GetConfigMapConsumersTool() mcp.Tool {
+	return mcp.NewTool(
+		"getConfigMapConsumers",
+		mcp.WithDescription("Get a ConfigMap's data keys and the workloads in its namespace that use it as a volume, through envFrom, or through env entries. Each consumer shows how it uses the ConfigMap and whether its pods must be restarted to pick up a change (env vars and subPath mounts are not updated in place)."),
+		mcp.WithString("name", mcp.Required(), mcp.Description("The name of the ConfigMap")),
+		mcp.WithString("namespace", mcp.Description("The namespace of the ConfigMap (default: 'default')")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Get ConfigMap Consumers",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// UpdateConfigAndRestartTool creates a tool for updating a ConfigMap or Secret and restarting its consumers.
+// It defines the tool's name, description, and parameters for the update and rollout restart.
+func UpdateConfigAndRestartTool() mcp.Tool {
+	return mcp.NewTool(
+		"updateConfigAndRestart",
+		mcp.WithDescription("Set keys of a ConfigMap or Secret and then perform a rollout restart of every Deployment, StatefulSet, and DaemonSet that uses it (as a volume, envFrom, or env entry), so the change actually takes effect. CronJobs, bare Jobs, and bare Pods that use it are listed but not restarted. Without confirm set to true, nothing is changed and the changed keys and affected workloads are listed."),
+		mcp.WithString("kind", mcp.Required(), mcp.Description("Either 'ConfigMap' or 'Secret'")),
+		mcp.WithString("name", mcp.Required(), mcp.Description("The name of the ConfigMap or Secret")),
+		mcp.WithString("namespace", mcp.Description("The namespace of the ConfigMap or Secret (default: 'default')")),
+		mcp.WithObject("data", mcp.Required(), mcp.Description("The keys to set, as key/value pairs; Secret values are given in plain text")),
+		mcp.WithBoolean("replace", mcp.Description("Replace the entire contents with data, removing keys not given (default: false, other keys are kept)")),
+		mcp.WithBoolean("confirm", mcp.Description("Must be true to actually update and restart (default: false, preview only)")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:           "Update Config And Restart",
+			DestructiveHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// PlanReconcileTool creates a tool for previewing what applyAndPrune would change.
+// It defines the tool's name, description, and parameters for the reconcile plan.
+func PlanReconcileTool() mcp.Tool {
+	return mcp.NewTool(
+		"planReconcile",
+		mcp.WithDescription("Preview what applyAndPrune would do with the same arguments, without changing anything: which resources in the bundle would be created, which updated (with the changed field paths, computed by a server-side dry run), which are unchanged, and which resources carrying the prune label would be deleted. Use it to present the full change plan for approval."),
+		mcp.WithString("manifests", mcp.Required(), mcp.Description("Multi-document YAML bundle of the desired resources")),
+		mcp.WithString("pruneSelector", mcp.Required(), mcp.Description("Equality-based label selector identifying the managed set (e.g. app.kubernetes.io/part-of=myapp)")),
+		mcp.WithString("namespace", mcp.Description("Namespace for resources that don't specify one, and the scope of pruning (default: 'default')")),
+		mcp.WithString("pruneKinds", mcp.Description("Comma-separated additional kinds to prune that may no longer appear in the bundle")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Plan Reconcile",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// RecommendResourcesTool creates a tool for right-sizing a workload's requests and limits.
+// It defines the tool's name, description, and parameters for the recommendation.
+func RecommendResourcesTool() mcp.Tool {
+	return mcp.NewTool(
+		"recommendResources",
+		mcp.WithDescription("Compare the CPU and memory requests and limits of each container in a workload (Deployment, StatefulSet, DaemonSet, ...) with its pods' current usage from the metrics API, and suggest adjusted values with findings such as requests set far too high, usage above the request, missing requests, or usage near a limit. Requires metrics-server; usage is a point-in-time sample."),
+		mcp.WithString("kind", mcp.Required(), mcp.Description("The kind of workload, e.g. 'Deployment'")),
+		mcp.WithString("name", mcp.Required(), mcp.Description("The name of the workload")),
+		mcp.WithString("namespace", mcp.Description("The namespace of the workload (default: 'default')")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Recommend Resources",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// ListOOMKillsTool creates a tool for finding containers that were OOMKilled.
+// It defines the tool's name, description, and parameters for the OOM kill scan.
+func ListOOMKillsTool() mcp.Tool {
+	return mcp.NewTool(
+		"listOOMKills",
+		mcp.WithDescription("Find containers whose current or last termination was OOMKilled, with the pod, container, owning workload, memory request and limit, restart count, and when it was killed, most recent first"),
+		mcp.WithString("namespace", mcp.Description("The namespace to scan (defaults to 'default' unless allNamespaces is set)")),
+		mcp.WithBoolean("allNamespaces", mcp.Description("Scan pods across all namespaces; namespace is ignored when set")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "List OOM Kills",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// ListImagePullErrorsTool creates a tool for finding images that fail to pull.
+// It defines the tool's name, description, and parameters for the image pull scan.
+func ListImagePullErrorsTool() mcp.Tool {
+	return mcp.NewTool(
+		"listImagePullErrors",
+		mcp.WithDescription("Find containers stuck in ErrImagePull, ImagePullBackOff, or related states, grouped by image, with the registry's error message from the pod events (e.g. not found or unauthorized) and the affected pods and workloads. Useful after a bad deploy or a registry outage."),
+		mcp.WithString("namespace", mcp.Description("The namespace to scan (defaults to 'default' unless allNamespaces is set)")),
+		mcp.WithBoolean("allNamespaces", mcp.Description("Scan pods across all namespaces; namespace is ignored when set")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "List Image Pull Errors",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// WatchEventsTool creates a tool for following cluster events as they happen.
+// It defines the tool's name, description, and parameters for watching events.
+func WatchEventsTool() mcp.Tool {
+	return mcp.NewTool(
+		"watchEvents",
+		mcp.WithDescription("Follow new Kubernetes events live for a period of time instead of polling, optionally filtered by type and involved object. If the request carries a progress token, each event is streamed as a progress notification as it arrives. Cancelling the request stops the watch. Returns the events seen with counts by type and reason."),
+		mcp.WithString("namespace", mcp.Description("The namespace to watch (defaults to 'default' unless allNamespaces is set)")),
+		mcp.WithBoolean("allNamespaces", mcp.Description("Watch events across all namespaces; namespace is ignored when set")),
+		mcp.WithString("type", mcp.Enum("Normal", "Warning"), mcp.Description("Only report events of this type")),
+		mcp.WithString("involvedObjectKind", mcp.Description("Only report events about objects of this kind, e.g. 'Pod'")),
+		mcp.WithString("involvedObjectName", mcp.Description("Only report events about the object with this name")),
+		mcp.WithString("duration", mcp.Description("How long to watch, as a duration such as '30s' or '5m' (default: '1m')")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Watch Events",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// ListWebhooksTool creates a tool for listing admission webhooks.
+// It defines the tool's name, description, and parameters for the webhook listing.
+func ListWebhooksTool() mcp.Tool {
+	return mcp.NewTool(
+		"listWebhooks",
+		mcp.WithDescription("List the validating and mutating admission webhooks in the cluster with the operations and resources each intercepts, failure policy, timeout, selectors, and target service or URL. In-cluster webhook services are checked for ready endpoints. Use this when creates or updates fail or get changed unexpectedly."),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "List Admission Webhooks",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// SemanticDiffTool creates a tool for comparing a manifest with the live resource.
+// It defines the tool's name, description, and parameters for the semantic diff.
+func SemanticDiffTool() mcp.Tool {
+	return mcp.NewTool(
+		"semanticDiff",
+		mcp.WithDescription("Compare a YAML or JSON manifest with the live resource field by field and return the fields that would be added, removed, or changed, by path. Only fields the manifest sets are compared, so server-populated and defaulted fields, key order, and formatting do not show up as differences; quantities are compared by value and named list entries such as containers are matched by name."),
+		mcp.WithString("manifest", mcp.Required(), mcp.Description("The YAML or JSON manifest of the resource")),
+		mcp.WithString("kind", mcp.Description("The type of resource (optional, inferred from the manifest if not provided)")),
+		mcp.WithString("namespace", mcp.Description("The namespace of the resource (overrides the namespace in the manifest if provided)")),
+		mcp.WithBoolean("reveal", mcp.Description("Return sensitive values such as Secret data unmasked (default: false)")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Semantic Diff",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// DeployAndVerifyTool creates a tool for applying a workload and verifying its rollout.
+// It defines the tool's name, description, and parameters for deploy and verify.
+func DeployAndVerifyTool() mcp.Tool {
+	return mcp.NewTool(
+		"deployAndVerify",
+		mcp.WithDescription("Apply a Deployment, StatefulSet, or DaemonSet manifest and follow its rollout until it completes, fails, or times out. As soon as a pod is crash looping, cannot pull its image, or cannot create a container, the rollout is reported as failed. On failure or timeout, the state, recent logs (including the previous run of restarted containers), and events of every unready pod are returned. Rollout updates are streamed as progress notifications if the request carries a progress token."),
+		mcp.WithString("manifest", mcp.Required(), mcp.Description("The YAML or JSON manifest of the workload")),
+		mcp.WithString("kind", mcp.Enum("Deployment", "StatefulSet", "DaemonSet"), mcp.Description("The kind of workload (optional, inferred from the manifest if not provided)")),
+		mcp.WithString("namespace", mcp.Description("The namespace of the workload (overrides the namespace in the manifest if provided)")),
+		mcp.WithString("timeout", mcp.Description("How long to wait for the rollout, as a duration such as '10m' (default: '5m')")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:           "Deploy and Verify",
+			DestructiveHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// AnalyzeDeletionImpactTool creates a tool for computing the blast radius of a delete.
+// It defines the tool's name, description, and parameters for analyzing deletion impact.
+func AnalyzeDeletionImpactTool() mcp.Tool {
+	return mcp.NewTool(
+		"analyzeDeletionImpact",
+		mcp.WithDescription("Show what deleting a resource would affect, without deleting it. Lists the dependents the garbage collector would cascade-delete through ownerReferences (e.g. a Deployment's ReplicaSets and pods, or everything in a Namespace), the objects that refer to it and would break (Services and PodDisruptionBudgets selecting its pods, HorizontalPodAutoscalers scaling it, workloads mounting a ConfigMap, Secret, or PersistentVolumeClaim, Ingresses routing to a Service), and the configuration its pods use that would be left behind. Call this before deleteResource."),
+		mcp.WithString("kind", mcp.Required(), mcp.Description("The kind of the resource, e.g. 'Deployment'")),
+		mcp.WithString("name", mcp.Required(), mcp.Description("The name of the resource")),
+		mcp.WithString("namespace", mcp.Description("The namespace of the resource (default: 'default'; ignored for cluster-scoped kinds)")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Analyze Deletion Impact",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// SuspendResourceTool creates a tool for pausing GitOps reconciliation of an object.
+// It defines the tool's name, description, and parameters for suspending a resource.
+func SuspendResourceTool() mcp.Tool {
+	return mcp.NewTool(
+		"suspendResource",
+		mcp.WithDescription("Stop GitOps controllers from reverting manual changes to an object, e.g. during an emergency fix, by setting suspend annotations on it: by default Flux's 'kustomize.toolkit.fluxcd.io/reconcile: disabled' and Argo CD's 'argocd.argoproj.io/skip-reconcile: true' (the server can be configured with others). Reports which GitOps controllers appear to manage the object. Undo with resumeResource."),
+		mcp.WithString("kind", mcp.Required(), mcp.Description("The kind of the object")),
+		mcp.WithString("name", mcp.Required(), mcp.Description("The name of the object")),
+		mcp.WithString("namespace", mcp.Description("The namespace of the object (for namespaced kinds)")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:           "Suspend Resource",
+			DestructiveHint: mcp.ToBoolPtr(false),
+		}),
+	)
+}
+
+// ResumeResourceTool creates a tool for resuming GitOps reconciliation of an object.
+// It defines the tool's name, description, and parameters for resuming a resource.
+func ResumeResourceTool() mcp.Tool {
+	return mcp.NewTool(
+		"resumeResource",
+		mcp.WithDescription("Remove the suspend annotations set by suspendResource from an object, handing it back to its GitOps controllers. They revert any manual changes on their next reconciliation. Annotations whose value was changed since are left in place."),
+		mcp.WithString("kind", mcp.Required(), mcp.Description("The kind of the object")),
+		mcp.WithString("name", mcp.Required(), mcp.Description("The name of the object")),
+		mcp.WithString("namespace", mcp.Description("The namespace of the object (for namespaced kinds)")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:           "Resume Resource",
+			DestructiveHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// ClusterOverviewTool creates a tool for a one-call snapshot of cluster health.
+// It summarizes nodes, pods, unhealthy workloads, warnings, and top consumers.
+func ClusterOverviewTool() mcp.Tool {
+	return mcp.NewTool(
+		"clusterOverview",
+		mcp.WithDescription("Get a one-call snapshot of cluster health: node count and readiness (with nodes that are not ready, cordoned, or under pressure), pods by phase, workloads with fewer ready replicas than desired, the most frequent warning events of the last hour, and the pods using the most CPU and memory. A good first call to get situational awareness; sections that cannot be gathered are reported under errors."),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Cluster Overview",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// DiagnoseSchedulingTool creates a tool for explaining why a pod is stuck Pending.
+// It defines the tool's name, description, and parameters for diagnosing scheduling.
+func DiagnoseSchedulingTool() mcp.Tool {
+	return mcp.NewTool(
+		"diagnoseScheduling",
+		mcp.WithDescription("Explain why a pod is stuck Pending. Checks the pod against every node for readiness and cordoning, nodeSelector and required node affinity, untolerated taints, and insufficient CPU, memory, pod slots, or extended resources given the requests already on the node. Reports a scheduler-style summary (e.g. '0/5 nodes are available: 3 insufficient cpu, 2 node has untolerated taint ...'), the reasons per node, unbound PersistentVolumeClaims, and FailedScheduling events."),
+		mcp.WithString("podName", mcp.Required(), mcp.Description("The name of the pod")),
+		mcp.WithString("namespace", mcp.Description("The namespace of the pod (default: 'default')")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Diagnose Scheduling",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// SnapshotResourceTool creates a tool for capturing an object's state for later comparison.
+// It defines the tool's name, description, and parameters for taking a snapshot.
+func SnapshotResourceTool() mcp.Tool {
+	return mcp.NewTool(
+		"snapshotResource",
+		mcp.WithDescription("Capture the current state of an object in memory and return a snapshot ID. Compare the object with the snapshot later using diffSnapshot, e.g. before making a change or while waiting for a controller to act. The 100 most recent snapshots are kept until the server restarts."),
+		mcp.WithString("kind", mcp.Required(), mcp.Description("The kind of the object")),
+		mcp.WithString("name", mcp.Required(), mcp.Description("The name of the object")),
+		mcp.WithString("namespace", mcp.Description("The namespace of the object (for namespaced kinds)")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Snapshot Resource",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// DiffSnapshotTool creates a tool for comparing an object with an earlier snapshot.
+// It defines the tool's name, description, and parameters for diffing a snapshot.
+func DiffSnapshotTool() mcp.Tool {
+	return mcp.NewTool(
+		"diffSnapshot",
+		mcp.WithDescription("Compare the current state of an object with a snapshot taken by snapshotResource, field by field: fields added, removed, and changed since, with list entries such as containers matched by name. Reports whether the object was deleted or recreated meanwhile."),
+		mcp.WithString("snapshotId", mcp.Required(), mcp.Description("The snapshot ID returned by snapshotResource")),
+		mcp.WithBoolean("includeStatus", mcp.Description("Also compare the status, which changes constantly (default: false)")),
+		mcp.WithBoolean("reveal", mcp.Description("Return sensitive values such as Secret data unmasked (default: false)")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Diff Snapshot",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// GetImageDetailsTool creates a tool for inspecting the images a pod or workload runs.
+// It defines the tool's name, description, and parameters for getting image details.
+func GetImageDetailsTool() mcp.Tool {
+	return mcp.NewTool(
+		"getImageDetails",
+		mcp.WithDescription("Get the container images of a pod or workload: each container's image reference, imagePullPolicy, whether it is pinned to a digest, and the digests the pods are actually running (from status.containerStatuses[].imageID), with the pods running each. Use it to verify a rollout runs the expected build; pods running different digests of the same tag, and mutable tags such as :latest that are not always pulled, are flagged."),
+		mcp.WithString("kind", mcp.Required(), mcp.Description("The kind: Pod, Deployment, StatefulSet, DaemonSet, ReplicaSet, or Job")),
+		mcp.WithString("name", mcp.Required(), mcp.Description("The name of the pod or workload")),
+		mcp.WithString("namespace", mcp.Description("The namespace (default: 'default')")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Get Image Details",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// DiagnosePodTool creates a tool for gathering a full diagnostic bundle for a pod.
+// It defines the tool's name, description, and parameters for diagnosing a pod.
+func DiagnosePodTool() mcp.Tool {
+	return mcp.NewTool(
+		"diagnosePod",
+		mcp.WithDescription("Gather everything needed to troubleshoot a failing pod in one call: status and conditions, each container's state with exit codes of the current and previous run, recent events, the last 200 log lines per container (and of the previous run if it restarted), CPU and memory usage against requests and limits, and the owning workload. Likely causes such as CrashLoopBackOff, image pull errors, or OOM kills are summarized under findings."),
+		mcp.WithString("podName", mcp.Required(), mcp.Description("The name of the pod")),
+		mcp.WithString("namespace", mcp.Description("The namespace of the pod (default: 'default')")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Diagnose Pod",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// CheckCertificatesTool creates a tool for reporting TLS certificate expiry.
+// It defines the tool's name, description, and parameters for checking certificates.
+func CheckCertificatesTool() mcp.Tool {
+	return mcp.NewTool(
+		"checkCertificates",
+		mcp.WithDescription("Check the TLS certificates stored in the cluster: every Secret of type kubernetes.io/tls and every Secret referenced by an Ingress's tls section. Each certificate chain is parsed and reported with its subject, issuer, DNS names, and expiry date, ordered by expiry, with a status of expired, expiringSoon, notYetValid, valid, or invalid. Ingress hosts not covered by their certificate and Ingresses referencing missing Secrets are flagged. Private keys are never returned."),
+		mcp.WithString("namespace", mcp.Description("The namespace to check (defaults to 'default' unless allNamespaces is set)")),
+		mcp.WithBoolean("allNamespaces", mcp.Description("Check certificates in all namespaces; namespace is ignored when set")),
+		mcp.WithNumber("warnDays", mcp.Description("Report certificates expiring within this many days as expiringSoon (default: 30)")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Check Certificates",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// RollbackImageTool creates a tool for rolling a Deployment back to a previous image.
+// It defines the tool's name, description, and parameters for the image rollback.
+func RollbackImageTool() mcp.Tool {
+	return mcp.NewTool(
+		"rollbackImage",
+		mcp.WithDescription("Undo a bad image rollout of a Deployment: set its container images back to those of an earlier revision, found from its ReplicaSets, leaving every other change to the pod template in place. By default the most recent earlier revision with a different image is used. Only the images are patched, which starts a new rollout. Without confirm set to true, nothing is changed and the image changes are listed."),
+		mcp.WithString("name", mcp.Required(), mcp.Description("The name of the Deployment")),
+		mcp.WithString("namespace", mcp.Description("The namespace of the Deployment (default: 'default')")),
+		mcp.WithString("container", mcp.Description("Only roll back the image of this container (default: all containers whose image differs)")),
+		mcp.WithNumber("toRevision", mcp.Description("The revision whose images to restore (default: the most recent earlier revision with a different image)")),
+		mcp.WithBoolean("confirm", mcp.Description("Must be true to actually patch the Deployment (default: false, preview only)")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:           "Rollback Image",
+			DestructiveHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// FindConsumersTool creates a tool for finding the workloads that depend on a resource.
+// It defines the tool's name, description, and parameters for finding consumers.
+func FindConsumersTool() mcp.Tool {
+	return mcp.NewTool(
+		"findConsumers",
+		mcp.WithDescription("Find the workloads that depend on a ConfigMap, Secret, PersistentVolumeClaim, or ServiceAccount before changing or deleting it. Pod templates of Deployments, StatefulSets, DaemonSets, CronJobs, and standalone Jobs and Pods are scanned for volumes (including projected ones), envFrom, env valueFrom, imagePullSecrets, PVC claims (including StatefulSet volumeClaimTemplates), and serviceAccountName. Each consumer lists how it uses the resource; references to a resource that does not exist are reported too."),
+		mcp.WithString("kind", mcp.Required(), mcp.Enum("ConfigMap", "Secret", "PersistentVolumeClaim", "ServiceAccount"), mcp.Description("The kind of the resource")),
+		mcp.WithString("name", mcp.Required(), mcp.Description("The name of the resource")),
+		mcp.WithString("namespace", mcp.Description("The namespace of the resource (default: 'default')")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Find Consumers",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// GetStateMetricsTool creates a tool for computing metrics about the state of cluster objects.
+// It defines the tool's name, description, and parameters for computing state metrics.
+func GetStateMetricsTool() mcp.Tool {
+	return mcp.NewTool(
+		"getStateMetrics",
+		mcp.WithDescription("Compute kube-state-metrics style gauges from the cluster's objects, for clusters without kube-state-metrics: pods by phase per namespace (kube_pod_status_phase_count), desired, ready, available, updated, and unavailable replicas per Deployment (kube_deployment_*), and node readiness and schedulability (kube_node_status_condition, kube_node_spec_unschedulable). Returned as JSON or in the Prometheus text exposition format."),
+		mcp.WithString("namespace", mcp.Description("Only compute pod and Deployment metrics for this namespace (default: all namespaces); node metrics are always cluster-wide")),
+		mcp.WithString("format", mcp.Enum("json", "prometheus"), mcp.Description("Output format: 'json' (default) or 'prometheus' for the text exposition format")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Get State Metrics",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// NodeDiagnosticsTool creates a tool for gathering a node's health signals.
+// It defines the tool's name, description, and parameters for diagnosing a node.
+func NodeDiagnosticsTool() mcp.Tool {
+	return mcp.NewTool(
+		"nodeDiagnostics",
+		mcp.WithDescription("Gather a node's health signals in one call to troubleshoot a misbehaving node: the kubelet's conditions with their reasons and heartbeats, memory, disk, and PID pressure, allocatable CPU, memory, and ephemeral storage against the requests and limits of its pods and its actual usage from metrics-server, its pod count against maxPods, its taints and whether it is cordoned, and its most recent events. Problems such as the node not being ready or under pressure are summarized under findings."),
+		mcp.WithString("nodeName", mcp.Required(), mcp.Description("The name of the node")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Node Diagnostics",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// CollectIncidentLogsTool creates a tool for gathering the recent logs of many pods at once.
+// It defines the tool's name, description, and parameters for collecting incident logs.
+func CollectIncidentLogsTool() mcp.Tool {
+	return mcp.NewTool(
+		"collectIncidentLogs",
+		mcp.WithDescription("Collect the logs written over a recent time window by every container of the pods matching a namespace and/or label selector, e.g. all logs of a set of services over the last 10 minutes during an incident, in one call. Logs of a container's previous run are included if it restarted within the window. Reads are made a few at a time and rate limited, each container's output is capped in lines and bytes, and the total response is capped at 1 MiB; the result marks which logs were truncated and which pods were skipped."),
+		mcp.WithString("namespace", mcp.Description("The namespace of the pods (empty for all namespaces, which requires labelSelector)")),
+		mcp.WithString("labelSelector", mcp.Description("Label selector of the pods, e.g. 'app in (api,worker)'")),
+		mcp.WithString("since", mcp.Description("How far back to collect logs from, as a Go duration (default: '10m')")),
+		mcp.WithNumber("maxLinesPerContainer", mcp.Description("Most recent lines to collect per container (default: 500)")),
+		mcp.WithNumber("maxBytesPerContainer", mcp.Description("Bytes to read at most per container (default: 65536)")),
+		mcp.WithNumber("maxPods", mcp.Description("Pods to collect logs from at most, in name order, up to 200 (default: 50)")),
+		mcp.WithNumber("parallelism", mcp.Description("How many log reads to run at once, at most 10 (default: 4)")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Collect Incident Logs",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
@@ -0,0 +1,57 @@
+package tools
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// QueryMetricsTool creates a tool for querying historical metrics from Prometheus.
+// It defines the tool's name, description, and parameters for the PromQL query
+// and an optional time range.
+func QueryMetricsTool() mcp.Tool {
+	return mcp.NewTool(
+		"queryMetrics",
+		mcp.WithDescription("Run a PromQL query against the configured Prometheus. Without start it returns the current value; with start it returns a time series, e.g. CPU usage over the last hour."),
+		mcp.WithString("query", mcp.Required(), mcp.Description("The PromQL expression, e.g. sum(rate(container_cpu_usage_seconds_total{namespace=\"default\"}[5m])) by (pod)")),
+		mcp.WithString("start", mcp.Description("Start of a range query: an RFC3339 timestamp or a duration ago such as '1h'")),
+		mcp.WithString("end", mcp.Description("End of the range, or the evaluation time of an instant query: an RFC3339 timestamp or a duration ago (default: now)")),
+		mcp.WithString("step", mcp.Description("Resolution of a range query, e.g. '1m' (default: about 60 points over the range)")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Query Metrics",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// QueryLogsTool creates a tool for searching aggregated logs in Loki.
+// It defines the tool's name, description, and parameters for the LogQL query,
+// time range, and result limit.
+func QueryLogsTool() mcp.Tool {
+	return mcp.NewTool(
+		"queryLogs",
+		mcp.WithDescription("Search aggregated logs in the configured Loki with a LogQL query, across pods and time, including pods that have since restarted or been deleted"),
+		mcp.WithString("query", mcp.Required(), mcp.Description("The LogQL log query, e.g. {namespace=\"default\", app=\"api\"} |= \"error\"")),
+		mcp.WithString("start", mcp.Description("Start of the time range: an RFC3339 timestamp or a duration ago such as '6h' (default: 1h before end)")),
+		mcp.WithString("end", mcp.Description("End of the time range: an RFC3339 timestamp or a duration ago (default: now)")),
+		mcp.WithNumber("limit", mcp.Description("Maximum number of log lines to return, newest first (default: 100)")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Query Logs",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}
+
+// GetAlertsTool creates a tool for listing firing alerts from Alertmanager.
+// It defines the tool's name, description, and parameters for label matchers
+// and whether to include silenced or inhibited alerts.
+func GetAlertsTool() mcp.Tool {
+	return mcp.NewTool(
+		"getAlerts",
+		mcp.WithDescription("List the alerts currently firing in the configured Alertmanager with their labels, severity, and annotations, newest first"),
+		mcp.WithString("filter", mcp.Description("Comma-separated label matchers, e.g. severity=\"critical\",namespace=\"prod\"")),
+		mcp.WithBoolean("includeSuppressed", mcp.Description("Also return silenced and inhibited alerts (default: false)")),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:        "Get Alerts",
+			ReadOnlyHint: mcp.ToBoolPtr(true),
+		}),
+	)
+}